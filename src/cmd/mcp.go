@@ -53,6 +53,18 @@ func mcpServer(_ *cobra.Command, _ []string) {
 	groupHandler := mcp.InitMcpGroup(groupUsecase)
 	groupHandler.AddGroupTools(mcpServer)
 
+	communityHandler := mcp.InitMcpCommunity(communityUsecase)
+	communityHandler.AddCommunityTools(mcpServer)
+
+	newsletterHandler := mcp.InitMcpNewsletter(newsletterUsecase)
+	newsletterHandler.AddNewsletterTools(mcpServer)
+
+	chatHandler := mcp.InitMcpChat(chatUsecase)
+	chatHandler.AddChatTools(mcpServer)
+
+	sessionHandler := mcp.InitMcpSession(sessionUsecase)
+	sessionHandler.AddSessionTools(mcpServer)
+
 	// Create SSE server
 	sseServer := server.NewSSEServer(
 		mcpServer,
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/repository"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var migrateAgentStoresRemoveFiles bool
+
+var migrateAgentStoresCmd = &cobra.Command{
+	Use:   "migrate-agent-stores",
+	Short: "Move agent session stores from SQLite files into the shared Postgres store",
+	Long:  `Copy the device of every storages/whatsapp-<agent>.db file into the database given by --db-agents-uri and link it to its agent. Stop the server before running it, and start it with the same --db-agents-uri afterwards.`,
+	Run:   migrateAgentStores,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateAgentStoresCmd)
+	migrateAgentStoresCmd.Flags().BoolVar(&migrateAgentStoresRemoveFiles, "remove-files", false, "Remove the SQLite files of migrated agents")
+}
+
+func migrateAgentStores(_ *cobra.Command, _ []string) {
+	if !strings.HasPrefix(config.DBAgentsURI, "postgres://") && !strings.HasPrefix(config.DBAgentsURI, "postgresql://") {
+		logrus.Fatalf("--db-agents-uri must be a postgres uri to migrate agent stores")
+	}
+	if config.DBAgentsURI == config.DBURI {
+		logrus.Fatalf("DB_AGENTS_URI must point to a different database than DB_URI")
+	}
+
+	chatDB, err := initChatStorage()
+	if err != nil {
+		logrus.Fatalf("failed to initialize chat storage: %v", err)
+	}
+	defer chatDB.Close()
+	sessions := repository.NewSessionRepository(chatDB)
+
+	agentsDB, err := sql.Open("postgres", config.DBAgentsURI)
+	if err != nil {
+		logrus.Fatalf("failed to open agent store database: %v", err)
+	}
+	defer agentsDB.Close()
+
+	files, err := filepath.Glob(filepath.Join(config.PathStorages, "whatsapp-*.db"))
+	if err != nil {
+		logrus.Fatalf("failed to list agent stores: %v", err)
+	}
+
+	ctx := context.Background()
+	migrated, failed := 0, 0
+	for _, file := range files {
+		agentID := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "whatsapp-"), ".db")
+		if _, err := sessions.FindByAgentID(agentID); err != nil {
+			logrus.Warnf("migrate: skipping %s, no session found for agent %s: %v", file, agentID, err)
+			continue
+		}
+
+		deviceJID, err := whatsapp.MigrateAgentStore(ctx, agentID, agentsDB, "postgres")
+		if err != nil {
+			logrus.Errorf("migrate: agent %s failed: %v", agentID, err)
+			failed++
+			continue
+		}
+		if deviceJID.IsEmpty() {
+			logrus.Infof("migrate: agent %s has no linked device, it will show a new QR code", agentID)
+		} else if err := sessions.SetDeviceJID(agentID, deviceJID.String()); err != nil {
+			logrus.Errorf("migrate: failed to link agent %s to device %s: %v", agentID, deviceJID, err)
+			failed++
+			continue
+		} else {
+			logrus.Infof("migrate: agent %s moved with device %s", agentID, deviceJID)
+		}
+		migrated++
+
+		if migrateAgentStoresRemoveFiles {
+			for _, path := range []string{file, file + "-shm", file + "-wal"} {
+				_ = os.Remove(path)
+			}
+		}
+	}
+
+	logrus.Infof("migrate: %d agent stores migrated, %d failed", migrated, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -77,7 +77,7 @@ func restServer(_ *cobra.Command, _ []string) {
 	}
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept",
+		AllowHeaders: "Origin, Content-Type, Accept, Idempotency-Key",
 	}))
 
 	if len(config.AppBasicAuthCredential) > 0 {
@@ -121,10 +121,14 @@ func restServer(_ *cobra.Command, _ []string) {
 	// Rest - Existing routes
 	rest.InitRestApp(apiGroup, appUsecase)
 	rest.InitRestChat(apiGroup, chatUsecase)
-	rest.InitRestSend(apiGroup, sendUsecase)
+	rest.InitRestSend(apiGroup, sendUsecase, sendJobUsecase)
+	rest.InitRestStatus(apiGroup, statusUsecase)
 	rest.InitRestUser(apiGroup, userUsecase)
 	rest.InitRestMessage(apiGroup, messageUsecase)
 	rest.InitRestGroup(apiGroup, groupUsecase)
+	rest.InitRestCommunity(apiGroup, communityUsecase)
+	rest.InitRestModeration(apiGroup, moderationUsecase)
+	rest.InitRestJoinRequest(apiGroup, joinRequestUsecase)
 	rest.InitRestNewsletter(apiGroup, newsletterUsecase)
 	admin.InitRoutes(apiGroup, webhookUsecase)
 
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"go.mau.fi/whatsmeow/proto/waCompanionReg"
 )
 
@@ -12,7 +14,10 @@ var (
 	AppPlatform            = waCompanionReg.DeviceProps_PlatformType(1)
 	AppBasicAuthCredential []string
 	AppBasePath            = ""
-	AppTrustedProxies      []string // Trusted proxy IP ranges (e.g., "0.0.0.0/0" for all, or specific CIDRs)
+	AppTrustedProxies      []string         // Trusted proxy IP ranges (e.g., "0.0.0.0/0" for all, or specific CIDRs)
+	AppIdempotencyTTL      = 24 * time.Hour // How long responses stored under an Idempotency-Key are replayed
+	AppSendJobWorkers      = 2              // Number of workers processing async send jobs
+	AppSendJobRetention    = time.Hour      // How long finished async send jobs stay queryable
 
 	McpPort = "8080"
 	McpHost = "localhost"
@@ -22,8 +27,9 @@ var (
 	PathMedia     = "statics/media"
 	PathStorages  = "storages"
 
-	DBURI     = "file:storages/whatsapp.db?_foreign_keys=on"
-	DBKeysURI = ""
+	DBURI       = "file:storages/whatsapp.db?_foreign_keys=on"
+	DBKeysURI   = ""
+	DBAgentsURI = "" // Shared Postgres device store for agent sessions, empty keeps a SQLite file per agent
 
 	WhatsappAutoReplyMessage       string
 	WhatsappAutoMarkRead           = false // Auto-mark incoming messages as read
@@ -38,6 +44,8 @@ var (
 	WhatsappTypeUser                     = "@s.whatsapp.net"
 	WhatsappTypeGroup                    = "@g.us"
 	WhatsappAccountValidation            = true
+	WhatsappRefuseBlocked                = false
+	WhatsappNumberCheckTTL               = 24 * time.Hour // How long a check of whether a number is on WhatsApp is reused
 
 	ChatStorageURI               = "file:storages/chatstorage.db"
 	ChatStorageEnableForeignKeys = true
@@ -20,7 +20,8 @@ type SendMessageRequest struct {
 }
 
 type SendMessageResponse struct {
-	Delivered bool `json:"delivered"`
+	Delivered bool   `json:"delivered"`
+	MessageID string `json:"messageId,omitempty"`
 }
 
 type SendMediaRequest struct {
@@ -34,6 +35,7 @@ type SendMediaRequest struct {
 
 type SendMediaResponse struct {
 	Delivered   bool   `json:"delivered"`
+	MessageID   string `json:"messageId,omitempty"`
 	PreviewPath string `json:"previewPath,omitempty"`
 }
 
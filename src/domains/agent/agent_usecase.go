@@ -137,7 +137,7 @@ func (u *AgentUsecase) ExecuteRun(agentID, apiKey string, request RunRequest) (*
 	// 6. Send reply via WhatsApp if present
 	replySent := false
 	if reply != "" {
-		if err := u.sendText(client, jid, reply); err != nil {
+		if _, err := u.sendText(client, jid, reply); err != nil {
 			logrus.Errorf("[%s] Failed to send reply: %v", traceID, err)
 		} else {
 			replySent = true
@@ -177,11 +177,12 @@ func (u *AgentUsecase) SendMessage(agentID, apiKey string, request SendMessageRe
 	jid := normalizeJID(request.To)
 
 	// Send message
-	if err := u.sendText(client, jid, request.Message); err != nil {
+	messageID, err := u.sendText(client, jid, request.Message)
+	if err != nil {
 		return nil, err
 	}
 
-	return &SendMessageResponse{Delivered: true}, nil
+	return &SendMessageResponse{Delivered: true, MessageID: messageID}, nil
 }
 
 func (u *AgentUsecase) SendMedia(agentID, apiKey string, request SendMediaRequest) (*SendMediaResponse, error) {
@@ -341,14 +342,14 @@ func (u *AgentUsecase) SendMedia(agentID, apiKey string, request SendMediaReques
 		return nil, fmt.Errorf("invalid JID: %w", err)
 	}
 
-	_, err = client.SendMessage(context.Background(), recipient, msg)
+	sent, err := client.SendMessage(context.Background(), recipient, msg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
 	metrics.IncMessagesSent()
 
-	return &SendMediaResponse{Delivered: true, PreviewPath: previewPath}, nil
+	return &SendMediaResponse{Delivered: true, MessageID: sent.ID, PreviewPath: previewPath}, nil
 }
 
 // Helper functions
@@ -429,21 +430,22 @@ func (u *AgentUsecase) callAIBackend(endpoint, apiKey string, payload map[string
 	return reply, nil
 }
 
-func (u *AgentUsecase) sendText(client *whatsmeow.Client, jid, text string) error {
+func (u *AgentUsecase) sendText(client *whatsmeow.Client, jid, text string) (string, error) {
 	recipient, err := types.ParseJID(jid)
 	if err != nil {
-		return fmt.Errorf("invalid JID: %w", err)
+		return "", fmt.Errorf("invalid JID: %w", err)
 	}
 
 	msg := &waProto.Message{
 		Conversation: proto.String(text),
 	}
 
-	_, err = client.SendMessage(context.Background(), recipient, msg)
-	if err == nil {
-		metrics.IncMessagesSent()
+	sent, err := client.SendMessage(context.Background(), recipient, msg)
+	if err != nil {
+		return "", err
 	}
-	return err
+	metrics.IncMessagesSent()
+	return sent.ID, nil
 }
 
 // sendTyping sends chat presence typing indicators; errors are logged but not fatal.
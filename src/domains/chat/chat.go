@@ -7,6 +7,10 @@ type ListChatsRequest struct {
 	Offset   int    `json:"offset" query:"offset"`
 	Search   string `json:"search" query:"search"`
 	HasMedia bool   `json:"has_media" query:"has_media"`
+	Archived *bool  `json:"archived" query:"archived"`
+	Muted    *bool  `json:"muted" query:"muted"`
+	Unread   *bool  `json:"unread" query:"unread"`
+	SortBy   string `json:"sort_by" query:"sort_by"`
 }
 
 type ListChatsResponse struct {
@@ -26,9 +30,10 @@ type GetChatMessagesRequest struct {
 }
 
 type GetChatMessagesResponse struct {
-	Data       []MessageInfo      `json:"data"`
-	Pagination PaginationResponse `json:"pagination"`
-	ChatInfo   ChatInfo           `json:"chat_info"`
+	Data           []MessageInfo       `json:"data"`
+	Pagination     PaginationResponse  `json:"pagination"`
+	ChatInfo       ChatInfo            `json:"chat_info"`
+	PinnedMessages []PinnedMessageInfo `json:"pinned_messages"`
 }
 
 // Pin Chat operations
@@ -44,11 +49,79 @@ type PinChatResponse struct {
 	Pinned  bool   `json:"pinned"`
 }
 
+type ArchiveChatRequest struct {
+	ChatJID  string `json:"chat_jid" uri:"chat_jid"`
+	Archived bool   `json:"archived"`
+}
+
+type MuteChatRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+	Muted   bool   `json:"muted"`
+	// MuteUntil is an RFC3339 timestamp; when empty the chat is muted indefinitely
+	MuteUntil string `json:"mute_until"`
+}
+
+type MarkChatAsReadRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+	Read    bool   `json:"read"`
+}
+
+type ClearChatRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+}
+
+type DeleteChatRequest struct {
+	ChatJID string `json:"chat_jid" uri:"chat_jid"`
+}
+
+type ChatActionResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	ChatJID string `json:"chat_jid"`
+}
+
+// Disappearing timers WhatsApp accepts, in seconds
+const (
+	DisappearingTimerOff     uint32 = 0
+	DisappearingTimer24Hours uint32 = 86400
+	DisappearingTimer7Days   uint32 = 604800
+	DisappearingTimer90Days  uint32 = 7776000
+)
+
+type SetDisappearingTimerRequest struct {
+	ChatJID      string `json:"chat_jid" uri:"chat_jid"`
+	TimerSeconds uint32 `json:"timer_seconds"`
+}
+
+type SetDisappearingTimerResponse struct {
+	Status       string `json:"status"`
+	Message      string `json:"message"`
+	ChatJID      string `json:"chat_jid"`
+	TimerSeconds uint32 `json:"timer_seconds"`
+}
+
+type SetDefaultDisappearingTimerRequest struct {
+	TimerSeconds uint32 `json:"timer_seconds"`
+}
+
+type SetDefaultDisappearingTimerResponse struct {
+	Status       string `json:"status"`
+	Message      string `json:"message"`
+	TimerSeconds uint32 `json:"timer_seconds"`
+}
+
 type ChatInfo struct {
 	JID                 string `json:"jid"`
 	Name                string `json:"name"`
 	LastMessageTime     string `json:"last_message_time"`
 	EphemeralExpiration uint32 `json:"ephemeral_expiration"`
+	Archived            bool   `json:"archived"`
+	Muted               bool   `json:"muted"`
+	MutedUntil          string `json:"muted_until,omitempty"`
+	MarkedUnread        bool   `json:"marked_unread"`
+	UnreadCount         int    `json:"unread_count"`
+	LastReadMessageID   string `json:"last_read_message_id,omitempty"`
+	LastActivity        string `json:"last_activity,omitempty"`
 	CreatedAt           string `json:"created_at"`
 	UpdatedAt           string `json:"updated_at"`
 }
@@ -57,6 +130,8 @@ type MessageInfo struct {
 	ID         string `json:"id"`
 	ChatJID    string `json:"chat_jid"`
 	SenderJID  string `json:"sender_jid"`
+	SenderPN   string `json:"sender_pn"`
+	SenderLID  string `json:"sender_lid"`
 	Content    string `json:"content"`
 	Timestamp  string `json:"timestamp"`
 	IsFromMe   bool   `json:"is_from_me"`
@@ -68,6 +143,14 @@ type MessageInfo struct {
 	UpdatedAt  string `json:"updated_at"`
 }
 
+// PinnedMessageInfo is a message currently pinned to the top of the chat
+type PinnedMessageInfo struct {
+	MessageID string `json:"message_id"`
+	PinnedBy  string `json:"pinned_by"`
+	PinnedAt  string `json:"pinned_at"`
+	ExpiresAt string `json:"expires_at"`
+}
+
 type PaginationResponse struct {
 	Limit  int `json:"limit"`
 	Offset int `json:"offset"`
@@ -9,4 +9,11 @@ type IChatUsecase interface {
 	ListChats(ctx context.Context, request ListChatsRequest) (response ListChatsResponse, err error)
 	GetChatMessages(ctx context.Context, request GetChatMessagesRequest) (response GetChatMessagesResponse, err error)
 	PinChat(ctx context.Context, request PinChatRequest) (response PinChatResponse, err error)
+	ArchiveChat(ctx context.Context, request ArchiveChatRequest) (response ChatActionResponse, err error)
+	MuteChat(ctx context.Context, request MuteChatRequest) (response ChatActionResponse, err error)
+	MarkChatAsRead(ctx context.Context, request MarkChatAsReadRequest) (response ChatActionResponse, err error)
+	ClearChat(ctx context.Context, request ClearChatRequest) (response ChatActionResponse, err error)
+	DeleteChat(ctx context.Context, request DeleteChatRequest) (response ChatActionResponse, err error)
+	SetDisappearingTimer(ctx context.Context, request SetDisappearingTimerRequest) (response SetDisappearingTimerResponse, err error)
+	SetDefaultDisappearingTimer(ctx context.Context, request SetDefaultDisappearingTimerRequest) (response SetDefaultDisappearingTimerResponse, err error)
 }
@@ -8,27 +8,50 @@ type Chat struct {
 	Name                string    `db:"name"`
 	LastMessageTime     time.Time `db:"last_message_time"`
 	EphemeralExpiration uint32    `db:"ephemeral_expiration"`
+	Archived            bool      `db:"archived"`
+	MuteEndTimestamp    int64     `db:"mute_end_timestamp"` // Unix milliseconds, -1 when muted indefinitely, 0 when not muted
+	MarkedUnread        bool      `db:"marked_unread"`
+	UnreadCount         int       `db:"unread_count"`
+	LastReadMessageID   string    `db:"last_read_message_id"`
+	LastActivity        time.Time `db:"last_activity"` // Zero until a message or read is recorded
 	CreatedAt           time.Time `db:"created_at"`
 	UpdatedAt           time.Time `db:"updated_at"`
 }
 
+// IsMuted reports whether the chat is muted at the given time
+func (c *Chat) IsMuted(now time.Time) bool {
+	return c.MuteEndTimestamp == -1 || c.MuteEndTimestamp > now.UnixMilli()
+}
+
+// ChatStateUpdate changes chat-list state synced through WhatsApp app state. Nil fields are left unchanged.
+type ChatStateUpdate struct {
+	Archived         *bool
+	MuteEndTimestamp *int64
+	MarkedUnread     *bool
+	UnreadCount      *int
+}
+
 // Message represents a WhatsApp message
 type Message struct {
-	ID            string    `db:"id"`
-	ChatJID       string    `db:"chat_jid"`
-	Sender        string    `db:"sender"`
-	Content       string    `db:"content"`
-	Timestamp     time.Time `db:"timestamp"`
-	IsFromMe      bool      `db:"is_from_me"`
-	MediaType     string    `db:"media_type"`
-	Filename      string    `db:"filename"`
-	URL           string    `db:"url"`
-	MediaKey      []byte    `db:"media_key"`
-	FileSHA256    []byte    `db:"file_sha256"`
-	FileEncSHA256 []byte    `db:"file_enc_sha256"`
-	FileLength    uint64    `db:"file_length"`
-	CreatedAt     time.Time `db:"created_at"`
-	UpdatedAt     time.Time `db:"updated_at"`
+	ID              string    `db:"id"`
+	ChatJID         string    `db:"chat_jid"`
+	Sender          string    `db:"sender"`
+	SenderLID       string    `db:"sender_lid"`
+	Content         string    `db:"content"`
+	Timestamp       time.Time `db:"timestamp"`
+	IsFromMe        bool      `db:"is_from_me"`
+	MediaType       string    `db:"media_type"`
+	Filename        string    `db:"filename"`
+	URL             string    `db:"url"`
+	MediaKey        []byte    `db:"media_key"`
+	FileSHA256      []byte    `db:"file_sha256"`
+	FileEncSHA256   []byte    `db:"file_enc_sha256"`
+	FileLength      uint64    `db:"file_length"`
+	Mimetype        string    `db:"mimetype"`
+	DirectPath      string    `db:"direct_path"`
+	ForwardingScore uint32    `db:"forwarding_score"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
 }
 
 // MediaInfo represents downloadable media information
@@ -53,6 +76,7 @@ type MessageFilter struct {
 	EndTime   *time.Time
 	MediaOnly bool
 	IsFromMe  *bool
+	Sender    string
 }
 
 // ChatFilter represents query filters for chats
@@ -61,4 +85,96 @@ type ChatFilter struct {
 	Offset     int
 	SearchName string
 	HasMedia   bool
+	Archived   *bool
+	Muted      *bool
+	Unread     *bool
+	SortBy     string
+}
+
+// Chat list orderings
+const (
+	ChatSortLastMessage = "last_message"
+	ChatSortUnread      = "unread"
+)
+
+// Poll represents a poll created in a chat. Options are kept so encrypted votes, which only carry option hashes,
+// can be mapped back to option names.
+type Poll struct {
+	MessageID       string    `db:"message_id"`
+	ChatJID         string    `db:"chat_jid"`
+	Creator         string    `db:"creator"`
+	Question        string    `db:"question"`
+	Options         []string  `db:"options"`
+	SelectableCount uint32    `db:"selectable_count"`
+	CreatedAt       time.Time `db:"created_at"`
+}
+
+// PollVote represents a voter's current selection on a poll. An empty selection means the vote was withdrawn.
+type PollVote struct {
+	PollMessageID   string    `db:"poll_message_id"`
+	ChatJID         string    `db:"chat_jid"`
+	Voter           string    `db:"voter"`
+	SelectedOptions []string  `db:"selected_options"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// MessagePin is a message pinned to the top of a chat until it expires or is unpinned
+type MessagePin struct {
+	ChatJID   string    `db:"chat_jid"`
+	MessageID string    `db:"message_id"`
+	PinnedBy  string    `db:"pinned_by"`
+	PinnedAt  time.Time `db:"pinned_at"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
+
+// ModerationRules are the auto-moderation settings of a group. Messages from group admins are never moderated.
+type ModerationRules struct {
+	GroupJID           string    `db:"group_jid"`
+	Enabled            bool      `db:"enabled"`
+	BlockLinks         bool      `db:"block_links"`
+	AllowedDomains     []string  `db:"allowed_domains"`
+	BannedKeywords     []string  `db:"banned_keywords"`
+	BannedPatterns     []string  `db:"banned_patterns"`
+	FloodMaxMessages   int       `db:"flood_max_messages"`   // 0 disables the flood limit
+	FloodWindowSeconds int       `db:"flood_window_seconds"` // Window in which FloodMaxMessages are allowed
+	BlockedMediaTypes  []string  `db:"blocked_media_types"`
+	Actions            []string  `db:"actions"`
+	WarningMessage     string    `db:"warning_message"`
+	MaxStrikes         int       `db:"max_strikes"` // Strikes after which the remove action takes effect
+	UpdatedAt          time.Time `db:"updated_at"`
+}
+
+// ModerationAuditEntry records one action the auto-moderation took on a message
+type ModerationAuditEntry struct {
+	ID          int64     `db:"id"`
+	GroupJID    string    `db:"group_jid"`
+	MessageID   string    `db:"message_id"`
+	Participant string    `db:"participant"`
+	Rule        string    `db:"rule"`
+	Detail      string    `db:"detail"`
+	Action      string    `db:"action"`
+	Success     bool      `db:"success"`
+	Error       string    `db:"error"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+// JoinRequestPolicy decides on requests to join a group without an admin having to look at them
+type JoinRequestPolicy struct {
+	GroupJID        string    `db:"group_jid"`
+	Enabled         bool      `db:"enabled"`
+	AllowedPrefixes []string  `db:"allowed_prefixes"` // Phone number prefixes such as country codes, empty allows all
+	RequireContact  bool      `db:"require_contact"`  // Only approve people saved in our contacts
+	HookURL         string    `db:"hook_url"`         // External service that makes the final decision
+	FailAction      string    `db:"fail_action"`      // What happens to requests that fail a rule: reject or ignore
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// WhatsAppNumber is the cached result of checking whether a phone number is on WhatsApp
+type WhatsAppNumber struct {
+	Phone        string    `db:"phone"` // Digits in international format, as the number was checked
+	IsRegistered bool      `db:"is_registered"`
+	JID          string    `db:"jid"`
+	LID          string    `db:"lid"` // Empty until WhatsApp has told us the LID of the number
+	IsBusiness   bool      `db:"is_business"`
+	CheckedAt    time.Time `db:"checked_at"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
@@ -17,6 +18,9 @@ type IChatStorageRepository interface {
 	GetChat(jid string) (*Chat, error)
 	GetChats(filter *ChatFilter) ([]*Chat, error)
 	DeleteChat(jid string) error
+	UpdateChatState(jid string, update ChatStateUpdate) error
+	ClearChatMessages(jid string) error
+	MarkChatRead(chatJID string, messageIDs []string, readAt time.Time) error
 
 	// Message operations
 	StoreMessage(message *Message) error
@@ -25,7 +29,36 @@ type IChatStorageRepository interface {
 	GetMessages(filter *MessageFilter) ([]*Message, error)
 	SearchMessages(chatJID, searchText string, limit int) ([]*Message, error) // Database-level search
 	DeleteMessage(id, chatJID string) error
-	StoreSentMessageWithContext(ctx context.Context, messageID string, senderJID string, recipientJID string, content string, timestamp time.Time) error
+	StoreSentMessageWithContext(ctx context.Context, messageID string, senderJID string, senderLID string, recipientJID string, recipientLID string, content string, timestamp time.Time, msg *waE2E.Message) error
+
+	// Poll operations
+	StorePoll(poll *Poll) error
+	GetPoll(messageID string) (*Poll, error)
+	StorePollVote(vote *PollVote) error
+	GetPollVotes(pollMessageID, chatJID string) ([]*PollVote, error)
+
+	// Pinned message operations
+	StoreMessagePin(pin *MessagePin) error
+	DeleteMessagePin(chatJID, messageID string) error
+	GetMessagePins(chatJID string) ([]*MessagePin, error)
+
+	// Group moderation operations
+	StoreModerationRules(rules *ModerationRules) error
+	GetModerationRules(groupJID string) (*ModerationRules, error)
+	DeleteModerationRules(groupJID string) error
+	AddModerationStrike(groupJID, participant string) (int, error)
+	ResetModerationStrikes(groupJID, participant string) error
+	StoreModerationAudit(entry *ModerationAuditEntry) error
+	GetModerationAudit(groupJID string, limit, offset int) ([]*ModerationAuditEntry, error)
+
+	// Group join request policy operations
+	StoreJoinRequestPolicy(policy *JoinRequestPolicy) error
+	GetJoinRequestPolicy(groupJID string) (*JoinRequestPolicy, error)
+	DeleteJoinRequestPolicy(groupJID string) error
+
+	// WhatsApp number check cache operations
+	StoreWhatsAppNumbers(numbers []*WhatsAppNumber) error
+	GetWhatsAppNumbers(phones []string, checkedSince time.Time) ([]*WhatsAppNumber, error)
 
 	// Statistics
 	GetChatMessageCount(chatJID string) (int64, error)
@@ -0,0 +1,53 @@
+package community
+
+import "time"
+
+type CreateCommunityRequest struct {
+	Name        string `json:"name" form:"name"`
+	Description string `json:"description" form:"description"`
+}
+
+type CreateCommunityResponse struct {
+	CommunityID string `json:"community_id"`
+	Name        string `json:"name"`
+}
+
+type CommunityInfoRequest struct {
+	CommunityID string `json:"community_id" query:"community_id"`
+}
+
+type CommunityInfoResponse struct {
+	CommunityID            string    `json:"community_id"`
+	Name                   string    `json:"name"`
+	Description            string    `json:"description"`
+	OwnerJID               string    `json:"owner_jid"`
+	CreatedAt              time.Time `json:"created_at"`
+	IsJoinApprovalRequired bool      `json:"is_join_approval_required"`
+	MemberCount            int       `json:"member_count"`
+}
+
+type GetSubGroupsRequest struct {
+	CommunityID string `json:"community_id" query:"community_id"`
+}
+
+type SubGroup struct {
+	GroupID string `json:"group_id"`
+	Name    string `json:"name"`
+}
+
+type GetSubGroupsResponse struct {
+	CommunityID       string     `json:"community_id"`
+	AnnouncementGroup *SubGroup  `json:"announcement_group"`
+	SubGroups         []SubGroup `json:"sub_groups"`
+}
+
+type LinkGroupsRequest struct {
+	CommunityID string   `json:"community_id" form:"community_id"`
+	GroupIDs    []string `json:"group_ids" form:"group_ids"`
+}
+
+type LinkGroupStatus struct {
+	GroupID string `json:"group_id"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
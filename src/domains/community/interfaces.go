@@ -0,0 +1,14 @@
+package community
+
+import (
+	"context"
+)
+
+// ICommunityUsecase handles communities: parent groups that bundle linked subgroups and an announcement group
+type ICommunityUsecase interface {
+	CreateCommunity(ctx context.Context, request CreateCommunityRequest) (response CreateCommunityResponse, err error)
+	CommunityInfo(ctx context.Context, request CommunityInfoRequest) (response CommunityInfoResponse, err error)
+	GetSubGroups(ctx context.Context, request GetSubGroupsRequest) (response GetSubGroupsResponse, err error)
+	LinkGroups(ctx context.Context, request LinkGroupsRequest) (result []LinkGroupStatus, err error)
+	UnlinkGroups(ctx context.Context, request LinkGroupsRequest) (result []LinkGroupStatus, err error)
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
 )
 
 // NOTE: IGroupUsecase is now defined in interfaces.go with proper segregation
@@ -68,6 +69,44 @@ type GroupRequestParticipantsRequest struct {
 	Action       whatsmeow.ParticipantRequestChange `json:"action" form:"action"`
 }
 
+// ImportParticipantsRequest adds everyone listed in a CSV of phone numbers. The file may be the output of
+// /group/participants/export; otherwise the phone_number or phone column, or the first column, is used.
+type ImportParticipantsRequest struct {
+	GroupID string                `json:"group_id" form:"group_id"`
+	File    *multipart.FileHeader `json:"file" form:"file"`
+	// InviteMessage is the caption of the invite sent to people whose privacy settings block direct adds
+	InviteMessage string `json:"invite_message" form:"invite_message"`
+}
+
+// Outcomes of a single CSV row in a participant import
+const (
+	ImportStatusAdded         = "added"
+	ImportStatusInvited       = "invited"
+	ImportStatusAlreadyMember = "already_member"
+	ImportStatusNotOnWhatsApp = "not_on_whatsapp"
+	ImportStatusInvalid       = "invalid"
+	ImportStatusDuplicate     = "duplicate"
+	ImportStatusFailed        = "failed"
+)
+
+type ImportParticipantResult struct {
+	Row     int    `json:"row"`
+	Input   string `json:"input"`
+	Phone   string `json:"phone,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type ImportParticipantsResponse struct {
+	GroupID string                    `json:"group_id"`
+	Total   int                       `json:"total"`
+	Added   int                       `json:"added"`
+	Invited int                       `json:"invited"`
+	Skipped int                       `json:"skipped"`
+	Failed  int                       `json:"failed"`
+	Results []ImportParticipantResult `json:"results"`
+}
+
 type SetGroupPhotoRequest struct {
 	GroupID string                `json:"group_id" form:"group_id"`
 	Photo   *multipart.FileHeader `json:"photo" form:"photo"`
@@ -98,6 +137,22 @@ type SetGroupTopicRequest struct {
 	Topic   string `json:"topic" form:"topic"`
 }
 
+// SetGroupMemberAddModeRequest controls who can add members: whatsmeow's "admin_add" or "all_member_add"
+type SetGroupMemberAddModeRequest struct {
+	GroupID string                   `json:"group_id" form:"group_id"`
+	Mode    types.GroupMemberAddMode `json:"mode" form:"mode"`
+}
+
+type SetGroupJoinApprovalRequest struct {
+	GroupID      string `json:"group_id" form:"group_id"`
+	JoinApproval bool   `json:"join_approval" form:"join_approval"`
+}
+
+type SetGroupDisappearingTimerRequest struct {
+	GroupID      string `json:"group_id" form:"group_id"`
+	TimerSeconds uint32 `json:"timer_seconds" form:"timer_seconds"`
+}
+
 type GetGroupInfoFromLinkRequest struct {
 	Link string `json:"link" form:"link"`
 }
@@ -20,6 +20,7 @@ type IGroupParticipants interface {
 	GetGroupParticipants(ctx context.Context, request GetGroupParticipantsRequest) (response GetGroupParticipantsResponse, err error)
 	GetGroupRequestParticipants(ctx context.Context, request GetGroupRequestParticipantsRequest) (result []GetGroupRequestParticipantsResponse, err error)
 	ManageGroupRequestParticipants(ctx context.Context, request GroupRequestParticipantsRequest) (result []ParticipantStatus, err error)
+	ImportParticipants(ctx context.Context, request ImportParticipantsRequest) (response ImportParticipantsResponse, err error)
 }
 
 // IGroupSettings handles group settings operations
@@ -29,6 +30,9 @@ type IGroupSettings interface {
 	SetGroupLocked(ctx context.Context, request SetGroupLockedRequest) (err error)
 	SetGroupAnnounce(ctx context.Context, request SetGroupAnnounceRequest) (err error)
 	SetGroupTopic(ctx context.Context, request SetGroupTopicRequest) (err error)
+	SetGroupMemberAddMode(ctx context.Context, request SetGroupMemberAddModeRequest) (err error)
+	SetGroupJoinApproval(ctx context.Context, request SetGroupJoinApprovalRequest) (err error)
+	SetGroupDisappearingTimer(ctx context.Context, request SetGroupDisappearingTimerRequest) (err error)
 }
 
 // IGroupUsecase combines all group interfaces for backward compatibility
@@ -0,0 +1,13 @@
+package joinrequest
+
+import (
+	"context"
+)
+
+// IJoinRequestUsecase manages per-group policies that approve or reject join requests automatically
+type IJoinRequestUsecase interface {
+	SetPolicy(ctx context.Context, request SetPolicyRequest) (response PolicyResponse, err error)
+	GetPolicy(ctx context.Context, request GroupRequest) (response PolicyResponse, err error)
+	DeletePolicy(ctx context.Context, request GroupRequest) (err error)
+	ReviewPending(ctx context.Context, request GroupRequest) (response ReviewResponse, err error)
+}
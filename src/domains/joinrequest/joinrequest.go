@@ -0,0 +1,51 @@
+package joinrequest
+
+import "time"
+
+// Decisions on a join request. Ignored requests stay pending for an admin.
+const (
+	DecisionApprove = "approve"
+	DecisionReject  = "reject"
+	DecisionIgnore  = "ignore"
+)
+
+type SetPolicyRequest struct {
+	GroupID         string   `json:"group_id" form:"group_id"`
+	Enabled         bool     `json:"enabled" form:"enabled"`
+	AllowedPrefixes []string `json:"allowed_prefixes" form:"allowed_prefixes"`
+	RequireContact  bool     `json:"require_contact" form:"require_contact"`
+	HookURL         string   `json:"hook_url" form:"hook_url"`
+	FailAction      string   `json:"fail_action" form:"fail_action"`
+}
+
+type GroupRequest struct {
+	GroupID string `json:"group_id" query:"group_id"`
+}
+
+type PolicyResponse struct {
+	GroupID         string    `json:"group_id"`
+	Enabled         bool      `json:"enabled"`
+	AllowedPrefixes []string  `json:"allowed_prefixes"`
+	RequireContact  bool      `json:"require_contact"`
+	HookURL         string    `json:"hook_url"`
+	FailAction      string    `json:"fail_action"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Decision is what a policy decided on one join request and whether WhatsApp accepted it
+type Decision struct {
+	Requester    string    `json:"requester"`
+	RequesterPN  string    `json:"requester_pn"`
+	RequesterLID string    `json:"requester_lid"`
+	PhoneNumber  string    `json:"phone_number"`
+	RequestedAt  time.Time `json:"requested_at"`
+	Decision     string    `json:"decision"`
+	Reason       string    `json:"reason"`
+	Applied      bool      `json:"applied"`
+	Error        string    `json:"error,omitempty"`
+}
+
+type ReviewResponse struct {
+	GroupID   string     `json:"group_id"`
+	Decisions []Decision `json:"decisions"`
+}
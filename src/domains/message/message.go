@@ -44,6 +44,21 @@ type StarRequest struct {
 	IsStarred bool   `json:"is_starred"`
 }
 
+// Durations a message can stay pinned for, matching the choices in the WhatsApp apps
+const (
+	PinDuration24Hours = "24h"
+	PinDuration7Days   = "7d"
+	PinDuration30Days  = "30d"
+)
+
+type PinMessageRequest struct {
+	MessageID string `json:"message_id" uri:"message_id"`
+	Phone     string `json:"phone" form:"phone"`
+	Duration  string `json:"duration" form:"duration"`
+	AgentID   string `json:"agent_id,omitempty" form:"agent_id" query:"agent_id"`
+	IsPinned  bool   `json:"is_pinned"`
+}
+
 type DownloadMediaRequest struct {
 	MessageID string `json:"message_id" uri:"message_id"`
 	Phone     string `json:"phone" form:"phone"`
@@ -58,3 +73,43 @@ type DownloadMediaResponse struct {
 	FilePath  string `json:"file_path"`
 	FileSize  int64  `json:"file_size"`
 }
+
+type ForwardRequest struct {
+	MessageID string   `json:"message_id" uri:"message_id"`
+	Phones    []string `json:"phones" form:"phones"`
+	AgentID   string   `json:"agent_id,omitempty" form:"agent_id" query:"agent_id"`
+}
+
+type ForwardResult struct {
+	Phone     string `json:"phone"`
+	MessageID string `json:"message_id,omitempty"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+type ForwardResponse struct {
+	MessageID string          `json:"message_id"`
+	Sent      int             `json:"sent"`
+	Failed    int             `json:"failed"`
+	Results   []ForwardResult `json:"results"`
+}
+
+type PollResultsRequest struct {
+	MessageID string `json:"message_id" uri:"message_id"`
+	AgentID   string `json:"agent_id,omitempty" form:"agent_id" query:"agent_id"`
+}
+
+type PollOptionResult struct {
+	Option string   `json:"option"`
+	Votes  int      `json:"votes"`
+	Voters []string `json:"voters"`
+}
+
+type PollResultsResponse struct {
+	MessageID       string             `json:"message_id"`
+	ChatJID         string             `json:"chat_jid"`
+	Question        string             `json:"question"`
+	SelectableCount uint32             `json:"selectable_count"`
+	TotalVoters     int                `json:"total_voters"`
+	Options         []PollOptionResult `json:"options"`
+}
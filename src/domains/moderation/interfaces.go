@@ -0,0 +1,13 @@
+package moderation
+
+import (
+	"context"
+)
+
+// IModerationUsecase manages the auto-moderation rules of groups and their audit log
+type IModerationUsecase interface {
+	SetRules(ctx context.Context, request SetRulesRequest) (response RulesResponse, err error)
+	GetRules(ctx context.Context, request GroupRequest) (response RulesResponse, err error)
+	DeleteRules(ctx context.Context, request GroupRequest) (err error)
+	GetAuditLog(ctx context.Context, request AuditLogRequest) (response AuditLogResponse, err error)
+}
@@ -0,0 +1,96 @@
+package moderation
+
+import "time"
+
+// Actions taken on a message that breaks a rule
+const (
+	ActionDelete = "delete" // Delete the message for everyone, which needs admin rights
+	ActionWarn   = "warn"   // Reply to the message with the warning message
+	ActionRemove = "remove" // Remove the sender once they reach MaxStrikes
+	// ActionStrike only appears in the audit log, for violations counted towards the remove action
+	ActionStrike = "strike"
+)
+
+// Media types that can be forbidden in a group
+const (
+	MediaImage    = "image"
+	MediaVideo    = "video"
+	MediaAudio    = "audio"
+	MediaDocument = "document"
+	MediaSticker  = "sticker"
+	MediaContact  = "contact"
+	MediaLocation = "location"
+	MediaPoll     = "poll"
+)
+
+// Rules that a message can break, as recorded in the audit log
+const (
+	RuleLink    = "link"
+	RuleKeyword = "keyword"
+	RulePattern = "pattern"
+	RuleFlood   = "flood"
+	RuleMedia   = "media"
+)
+
+// DefaultMaxStrikes applies when the remove action is used without max_strikes
+const DefaultMaxStrikes = 3
+
+type SetRulesRequest struct {
+	GroupID            string   `json:"group_id" form:"group_id"`
+	Enabled            bool     `json:"enabled" form:"enabled"`
+	BlockLinks         bool     `json:"block_links" form:"block_links"`
+	AllowedDomains     []string `json:"allowed_domains" form:"allowed_domains"`
+	BannedKeywords     []string `json:"banned_keywords" form:"banned_keywords"`
+	BannedPatterns     []string `json:"banned_patterns" form:"banned_patterns"`
+	FloodMaxMessages   int      `json:"flood_max_messages" form:"flood_max_messages"`
+	FloodWindowSeconds int      `json:"flood_window_seconds" form:"flood_window_seconds"`
+	BlockedMediaTypes  []string `json:"blocked_media_types" form:"blocked_media_types"`
+	Actions            []string `json:"actions" form:"actions"`
+	WarningMessage     string   `json:"warning_message" form:"warning_message"`
+	MaxStrikes         int      `json:"max_strikes" form:"max_strikes"`
+}
+
+type GroupRequest struct {
+	GroupID string `json:"group_id" query:"group_id"`
+}
+
+type RulesResponse struct {
+	GroupID            string    `json:"group_id"`
+	Enabled            bool      `json:"enabled"`
+	BlockLinks         bool      `json:"block_links"`
+	AllowedDomains     []string  `json:"allowed_domains"`
+	BannedKeywords     []string  `json:"banned_keywords"`
+	BannedPatterns     []string  `json:"banned_patterns"`
+	FloodMaxMessages   int       `json:"flood_max_messages"`
+	FloodWindowSeconds int       `json:"flood_window_seconds"`
+	BlockedMediaTypes  []string  `json:"blocked_media_types"`
+	Actions            []string  `json:"actions"`
+	WarningMessage     string    `json:"warning_message"`
+	MaxStrikes         int       `json:"max_strikes"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type AuditLogRequest struct {
+	GroupID string `json:"group_id" query:"group_id"`
+	Limit   int    `json:"limit" query:"limit"`
+	Offset  int    `json:"offset" query:"offset"`
+}
+
+type AuditEntry struct {
+	ID          int64     `json:"id"`
+	MessageID   string    `json:"message_id"`
+	Participant string    `json:"participant"`
+	Rule        string    `json:"rule"`
+	Detail      string    `json:"detail"`
+	Action      string    `json:"action"`
+	Success     bool      `json:"success"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type AuditLogResponse struct {
+	GroupID string       `json:"group_id"`
+	Entries []AuditEntry `json:"entries"`
+	Limit   int          `json:"limit"`
+	Offset  int          `json:"offset"`
+}
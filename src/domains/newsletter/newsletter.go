@@ -1,11 +1,131 @@
 package newsletter
 
-import "context"
+import (
+	"context"
+	"mime/multipart"
+	"time"
+)
 
 type INewsletterUsecase interface {
 	Unfollow(ctx context.Context, request UnfollowRequest) (err error)
+	Create(ctx context.Context, request CreateRequest) (response NewsletterInfo, err error)
+	Update(ctx context.Context, request UpdateRequest) (response NewsletterInfo, err error)
+	Delete(ctx context.Context, request NewsletterRequest) (err error)
+	Follow(ctx context.Context, request FollowRequest) (response NewsletterInfo, err error)
+	Info(ctx context.Context, request InfoRequest) (response NewsletterInfo, err error)
+	Messages(ctx context.Context, request MessagesRequest) (response MessagesResponse, err error)
+	Send(ctx context.Context, request SendRequest) (response SendResponse, err error)
+	Mute(ctx context.Context, request MuteRequest) (err error)
+	Stats(ctx context.Context, request StatsRequest) (response StatsResponse, err error)
 }
 
 type UnfollowRequest struct {
 	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
 }
+
+type NewsletterRequest struct {
+	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
+}
+
+type CreateRequest struct {
+	Name        string                `json:"name" form:"name"`
+	Description string                `json:"description" form:"description"`
+	Picture     *multipart.FileHeader `json:"picture" form:"picture"`
+}
+
+// UpdateRequest changes only the fields that are given
+type UpdateRequest struct {
+	NewsletterID string                `json:"newsletter_id" form:"newsletter_id"`
+	Name         string                `json:"name" form:"name"`
+	Description  string                `json:"description" form:"description"`
+	Picture      *multipart.FileHeader `json:"picture" form:"picture"`
+}
+
+type FollowRequest struct {
+	InviteLink string `json:"invite_link" form:"invite_link"`
+}
+
+// InfoRequest looks a channel up by its ID, or by invite link for channels we do not follow yet
+type InfoRequest struct {
+	NewsletterID string `json:"newsletter_id" query:"newsletter_id"`
+	InviteLink   string `json:"invite_link" query:"invite_link"`
+}
+
+type NewsletterInfo struct {
+	NewsletterID    string    `json:"newsletter_id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	InviteLink      string    `json:"invite_link"`
+	SubscriberCount int       `json:"subscriber_count"`
+	Verified        bool      `json:"verified"`
+	State           string    `json:"state"`
+	Role            string    `json:"role,omitempty"`
+	Muted           bool      `json:"muted"`
+	PictureURL      string    `json:"picture_url,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type MessagesRequest struct {
+	NewsletterID string `json:"newsletter_id" query:"newsletter_id"`
+	Limit        int    `json:"limit" query:"limit"`
+	// Before is the server ID of a message; only older messages are returned
+	Before int `json:"before" query:"before"`
+}
+
+type Message struct {
+	ServerID       int            `json:"server_id"`
+	MessageID      string         `json:"message_id"`
+	Type           string         `json:"type"`
+	Timestamp      time.Time      `json:"timestamp"`
+	Text           string         `json:"text"`
+	MediaType      string         `json:"media_type,omitempty"`
+	ViewsCount     int            `json:"views_count"`
+	ReactionCounts map[string]int `json:"reaction_counts"`
+}
+
+type MessagesResponse struct {
+	NewsletterID string    `json:"newsletter_id"`
+	Messages     []Message `json:"messages"`
+}
+
+// SendRequest posts text, or an image or video with the text as caption, to a channel we own or administer
+type SendRequest struct {
+	NewsletterID string                `json:"newsletter_id" form:"newsletter_id"`
+	Message      string                `json:"message" form:"message"`
+	Image        *multipart.FileHeader `json:"image" form:"image"`
+	ImageURL     *string               `json:"image_url" form:"image_url"`
+	Video        *multipart.FileHeader `json:"video" form:"video"`
+	VideoURL     *string               `json:"video_url" form:"video_url"`
+}
+
+type SendResponse struct {
+	MessageID string `json:"message_id"`
+	ServerID  int    `json:"server_id"`
+	Status    string `json:"status"`
+}
+
+type MuteRequest struct {
+	NewsletterID string `json:"newsletter_id" form:"newsletter_id"`
+	Mute         bool   `json:"mute" form:"mute"`
+}
+
+type StatsRequest struct {
+	NewsletterID string `json:"newsletter_id" query:"newsletter_id"`
+	Limit        int    `json:"limit" query:"limit"`
+	// Since limits the counts to messages posted after this time, in RFC3339
+	Since string `json:"since" query:"since"`
+}
+
+type MessageStats struct {
+	ServerID       int            `json:"server_id"`
+	Timestamp      time.Time      `json:"timestamp"`
+	ViewsCount     int            `json:"views_count"`
+	ReactionCounts map[string]int `json:"reaction_counts"`
+}
+
+type StatsResponse struct {
+	NewsletterID   string         `json:"newsletter_id"`
+	TotalViews     int            `json:"total_views"`
+	TotalReactions int            `json:"total_reactions"`
+	Messages       []MessageStats `json:"messages"`
+}
@@ -0,0 +1,30 @@
+package send
+
+import "mime/multipart"
+
+const (
+	AlbumItemImage = "image"
+	AlbumItemVideo = "video"
+)
+
+// AlbumItem is one photo or video of an album. Multipart uploads reference their file by form field name in File.
+type AlbumItem struct {
+	Type    string                `json:"type"`
+	URL     *string               `json:"url,omitempty"`
+	File    string                `json:"file,omitempty"`
+	Caption string                `json:"caption,omitempty"`
+	Upload  *multipart.FileHeader `json:"-"`
+}
+
+// AlbumRequest sends several photos/videos grouped as one album. Multipart bodies carry Items as a JSON string.
+type AlbumRequest struct {
+	BaseRequest
+	Items    []AlbumItem `json:"items" form:"-"`
+	Compress bool        `json:"compress"`
+}
+
+type AlbumResponse struct {
+	AlbumID    string   `json:"album_id"`
+	MessageIDs []string `json:"message_ids"`
+	Status     string   `json:"status"`
+}
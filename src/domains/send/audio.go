@@ -6,4 +6,6 @@ type AudioRequest struct {
 	BaseRequest
 	Audio    *multipart.FileHeader `json:"audio" form:"audio"`
 	AudioURL *string               `json:"audio_url" form:"audio_url"`
+	// PTT sends the audio as a voice note: it is transcoded to OGG/Opus and gets a duration and waveform
+	PTT bool `json:"ptt,omitempty" form:"ptt"`
 }
@@ -0,0 +1,60 @@
+package send
+
+import "mime/multipart"
+
+type BatchRecipient struct {
+	Phone     string            `json:"phone"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// BatchBaseRequest mirrors BaseRequest for a list of recipients. Multipart bodies carry Recipients as a JSON string.
+type BatchBaseRequest struct {
+	Recipients  []BatchRecipient `json:"recipients" form:"-"`
+	AgentID     string           `json:"agent_id,omitempty" form:"agent_id"`
+	Duration    *int             `json:"duration,omitempty" form:"duration"`
+	IsForwarded bool             `json:"is_forwarded,omitempty" form:"is_forwarded"`
+}
+
+// ForRecipient builds the single-send BaseRequest for one recipient of the batch
+func (r BatchBaseRequest) ForRecipient(phone string) BaseRequest {
+	return BaseRequest{
+		Phone:       phone,
+		AgentID:     r.AgentID,
+		Duration:    r.Duration,
+		IsForwarded: r.IsForwarded,
+	}
+}
+
+type BatchMessageRequest struct {
+	BatchBaseRequest
+	Message string `json:"message" form:"message"`
+}
+
+type BatchImageRequest struct {
+	BatchBaseRequest
+	Caption  string                `json:"caption" form:"caption"`
+	Image    *multipart.FileHeader `json:"image" form:"image"`
+	ImageURL *string               `json:"image_url" form:"image_url"`
+	ViewOnce bool                  `json:"view_once" form:"view_once"`
+	Compress bool                  `json:"compress"`
+}
+
+type BatchFileRequest struct {
+	BatchBaseRequest
+	File    *multipart.FileHeader `json:"file" form:"file"`
+	Caption string                `json:"caption" form:"caption"`
+}
+
+type BatchResult struct {
+	Phone     string `json:"phone"`
+	MessageID string `json:"message_id,omitempty"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Total   int           `json:"total"`
+	Sent    int           `json:"sent"`
+	Failed  int           `json:"failed"`
+	Results []BatchResult `json:"results"`
+}
@@ -16,6 +16,7 @@ type IMediaSender interface {
 	SendVideo(ctx context.Context, request VideoRequest) (response GenericResponse, err error)
 	SendAudio(ctx context.Context, request AudioRequest) (response GenericResponse, err error)
 	SendSticker(ctx context.Context, request StickerRequest) (response GenericResponse, err error)
+	SendAlbum(ctx context.Context, request AlbumRequest) (response AlbumResponse, err error)
 }
 
 // IInteractionSender handles interaction message sending operations
@@ -32,10 +33,24 @@ type IPresenceSender interface {
 	SendChatPresence(ctx context.Context, request ChatPresenceRequest) (response GenericResponse, err error)
 }
 
+// IBatchSender handles sending the same message to many recipients
+type IBatchSender interface {
+	SendTextBatch(ctx context.Context, request BatchMessageRequest) (response BatchResponse, err error)
+	SendImageBatch(ctx context.Context, request BatchImageRequest) (response BatchResponse, err error)
+	SendFileBatch(ctx context.Context, request BatchFileRequest) (response BatchResponse, err error)
+}
+
 // ISendUsecase combines all sender interfaces for backward compatibility
 type ISendUsecase interface {
 	ITextSender
 	IMediaSender
 	IInteractionSender
 	IPresenceSender
+	IBatchSender
+}
+
+// ISendJobUsecase runs long sends in the background and reports their progress
+type ISendJobUsecase interface {
+	SubmitVideo(ctx context.Context, request VideoRequest, callbackURL string) (job Job, err error)
+	GetJob(ctx context.Context, agentID, jobID string) (job Job, err error)
 }
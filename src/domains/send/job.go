@@ -0,0 +1,45 @@
+package send
+
+import (
+	"context"
+	"time"
+)
+
+type JobStatus string
+
+const (
+	JobStatusQueued      JobStatus = "queued"
+	JobStatusDownloading JobStatus = "downloading"
+	JobStatusCompressing JobStatus = "compressing"
+	JobStatusUploading   JobStatus = "uploading"
+	JobStatusSent        JobStatus = "sent"
+	JobStatusFailed      JobStatus = "failed"
+)
+
+type Job struct {
+	ID          string    `json:"job_id"`
+	AgentID     string    `json:"agent_id,omitempty"`
+	Type        string    `json:"type"`
+	Status      JobStatus `json:"status"`
+	MessageID   string    `json:"message_id,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	CallbackURL string    `json:"callback_url,omitempty"`
+	// CallbackSecret signs the callback of this job. It is only returned when the job is submitted.
+	CallbackSecret string    `json:"callback_secret,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type progressContextKey struct{}
+
+// WithProgress attaches a callback that senders use to report the stage they are in.
+func WithProgress(ctx context.Context, fn func(status JobStatus)) context.Context {
+	return context.WithValue(ctx, progressContextKey{}, fn)
+}
+
+// ReportProgress notifies the progress callback stored in ctx, if any.
+func ReportProgress(ctx context.Context, status JobStatus) {
+	if fn, ok := ctx.Value(progressContextKey{}).(func(status JobStatus)); ok && fn != nil {
+		fn(status)
+	}
+}
@@ -9,4 +9,6 @@ type VideoRequest struct {
 	ViewOnce bool                  `json:"view_once" form:"view_once"`
 	Compress bool                  `json:"compress"`
 	VideoURL *string               `json:"video_url" form:"video_url"`
+	// VideoPath points to a video already stored under PathSendItems; used by async jobs once the upload is detached from the request
+	VideoPath string `json:"-" form:"-"`
 }
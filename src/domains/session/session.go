@@ -41,11 +41,15 @@ type Timestamps struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+// GetSessionResponse reports the live client next to the stored session status, which follows the login:
+// awaiting_qr or awaiting_pair_code, then authenticated, or pair_failed when linking the device failed
 type GetSessionResponse struct {
 	IsReady      bool    `json:"isReady"`
 	HasClient    bool    `json:"hasClient"`
 	SessionState string  `json:"sessionState"`
+	Status       string  `json:"status,omitempty"`
 	Qr           *QrData `json:"qr,omitempty"`
+	PairCode     string  `json:"pairCode,omitempty"`
 }
 
 type GetQRResponse struct {
@@ -53,12 +57,25 @@ type GetQRResponse struct {
 	QrUpdatedAt time.Time `json:"qrUpdatedAt"`
 }
 
+type PairCodeRequest struct {
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+// PairCodeResponse carries the code to enter on the phone under Linked devices > Link with phone number
+type PairCodeResponse struct {
+	PairCode         string    `json:"pairCode"`
+	SessionState     string    `json:"sessionState"`
+	PairCodeIssuedAt time.Time `json:"pairCodeIssuedAt"`
+}
+
 type ISessionRepository interface {
 	Upsert(user *WhatsappUser) error
 	FindOne(userID, agentID string) (*WhatsappUser, error)
 	FindByAgentID(agentID string) (*WhatsappUser, error)
 	Delete(userID, agentID string) error
 	List() ([]*WhatsappUser, error)
+	FindDeviceJID(agentID string) (string, error)
+	SetDeviceJID(agentID, deviceJID string) error
 }
 
 type ISessionUsecase interface {
@@ -67,5 +84,6 @@ type ISessionUsecase interface {
 	DeleteSession(agentID string) error
 	ReconnectSession(agentID string) (*CreateSessionResponse, error)
 	GetQR(agentID string) (*GetQRResponse, error)
+	RequestPairCode(agentID string, request PairCodeRequest) (*PairCodeResponse, error)
 	ListSessions() ([]*WhatsappUser, error)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/domains/apikey"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/metrics"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
 	"github.com/sirupsen/logrus"
 	"github.com/skip2/go-qrcode"
 	"go.mau.fi/whatsmeow"
@@ -111,6 +112,12 @@ func (u *SessionUsecase) listenAndCacheQR(ctx context.Context, client *whatsmeow
 }
 
 func (u *SessionUsecase) CreateSession(request CreateSessionRequest) (*CreateSessionResponse, error) {
+	return u.createSession(request, false)
+}
+
+// createSession links an agent to WhatsApp. A stored device that is not logged in is reset for a new QR code,
+// unless keepDevice asks to log in with it again, as a reconnect does.
+func (u *SessionUsecase) createSession(request CreateSessionRequest, keepDevice bool) (*CreateSessionResponse, error) {
 	// 1. Find active API Key if not provided
 	apiKeyStr := request.ApiKey
 	if apiKeyStr == "" {
@@ -161,9 +168,9 @@ func (u *SessionUsecase) CreateSession(request CreateSessionRequest) (*CreateSes
 	}
 
 	// If store exists but not logged in, reset the agent store to avoid invalid/expired QR attempts
-	if client.Store.ID != nil && !client.IsLoggedIn() {
+	if !keepDevice && client.Store.ID != nil && !client.IsLoggedIn() {
 		logrus.Warnf("Resetting store for agent %s before QR (store exists but not logged in)", request.AgentID)
-		if err := u.clientManager.DeleteClient(request.AgentID); err != nil {
+		if err := u.clientManager.DestroyClient(request.AgentID); err != nil {
 			logrus.Warnf("Failed to delete client for agent %s: %v", request.AgentID, err)
 		}
 		client, err = u.clientManager.CreateClient(ctx, request.AgentID)
@@ -228,12 +235,20 @@ func (u *SessionUsecase) GetSession(agentID string) (*GetSessionResponse, error)
 		}
 	}
 
-	return &GetSessionResponse{
+	response := &GetSessionResponse{
 		IsReady:      isReady,
 		HasClient:    hasClient,
 		SessionState: sessionState,
 		Qr:           u.getCachedQR(agentID),
-	}, nil
+	}
+	if user, err := u.sessionRepo.FindByAgentID(agentID); err == nil && user != nil {
+		response.Status = user.Status
+	}
+	// A pairing code only matters until the device is linked
+	if code, ok, _ := u.clientManager.GetCachedPairCode(agentID); ok && !isReady {
+		response.PairCode = code
+	}
+	return response, nil
 }
 
 func (u *SessionUsecase) DeleteSession(agentID string) error {
@@ -242,7 +257,7 @@ func (u *SessionUsecase) DeleteSession(agentID string) error {
 		return err
 	}
 
-	u.clientManager.DeleteClient(agentID)
+	u.clientManager.DestroyClient(agentID)
 
 	if user != nil {
 		_ = u.sessionRepo.Delete(user.UserID, agentID)
@@ -258,7 +273,7 @@ func (u *SessionUsecase) ReconnectSession(agentID string) (*CreateSessionRespons
 		return nil, err
 	}
 
-	// Destroy old client and recreate
+	// Drop the old client but keep its device, so a linked agent connects again without a new QR code
 	u.clientManager.DeleteClient(agentID)
 
 	req := CreateSessionRequest{
@@ -269,7 +284,7 @@ func (u *SessionUsecase) ReconnectSession(agentID string) (*CreateSessionRespons
 		EndpointUrlRun: user.EndpointUrlRun,
 	}
 
-	return u.CreateSession(req)
+	return u.createSession(req, true)
 }
 
 func (u *SessionUsecase) GetQR(agentID string) (*GetQRResponse, error) {
@@ -311,6 +326,58 @@ func (u *SessionUsecase) GetQR(agentID string) (*GetQRResponse, error) {
 	return &GetQRResponse{Qr: *qr, QrUpdatedAt: time.Now()}, nil
 }
 
+// RequestPairCode links the device of an agent by entering a code on the phone instead of scanning a QR, for
+// phones that cannot scan a QR shown on their own screen. The code stays valid while the login connection lives,
+// which WhatsApp closes once its QR codes run out after about 160 seconds; a new request reconnects.
+func (u *SessionUsecase) RequestPairCode(agentID string, request PairCodeRequest) (*PairCodeResponse, error) {
+	ctx := context.Background()
+	if err := validations.ValidateLoginWithCode(ctx, request.PhoneNumber); err != nil {
+		return nil, err
+	}
+
+	user, err := u.sessionRepo.FindByAgentID(agentID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("session not found")
+	}
+
+	client, err := u.clientManager.CreateClient(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if client.IsLoggedIn() {
+		return nil, errors.New("session already logged in")
+	}
+
+	// whatsmeow issues pairing codes on the login connection, once the first QR event shows it is established
+	if !client.IsConnected() {
+		if _, err := u.listenAndCacheQR(ctx, client, agentID); err != nil {
+			return nil, err
+		}
+	}
+
+	code, err := client.PairPhone(ctx, request.PhoneNumber, true, whatsmeow.PairClientChrome, "Chrome (Linux)")
+	if err != nil {
+		return nil, err
+	}
+	u.clientManager.CachePairCode(agentID, code)
+
+	now := time.Now()
+	user.Status = "awaiting_pair_code"
+	user.UpdatedAt = now
+	if err := u.sessionRepo.Upsert(user); err != nil {
+		logrus.Warnf("Failed to store pairing status for agent %s: %v", agentID, err)
+	}
+
+	return &PairCodeResponse{
+		PairCode:         code,
+		SessionState:     user.Status,
+		PairCodeIssuedAt: now,
+	}, nil
+}
+
 func (u *SessionUsecase) getCachedQR(agentID string) *QrData {
 	if ct, b64, ok, _ := u.clientManager.GetCachedQR(agentID); ok {
 		return &QrData{ContentType: ct, Base64: b64}
@@ -0,0 +1,11 @@
+package status
+
+import "context"
+
+// IStatusUsecase publishes to and reads from status@broadcast (WhatsApp Status / stories)
+type IStatusUsecase interface {
+	PostText(ctx context.Context, request TextRequest) (response GenericResponse, err error)
+	PostImage(ctx context.Context, request ImageRequest) (response GenericResponse, err error)
+	PostVideo(ctx context.Context, request VideoRequest) (response GenericResponse, err error)
+	ListStatuses(ctx context.Context, request ListRequest) (response ListResponse, err error)
+}
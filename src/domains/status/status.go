@@ -0,0 +1,67 @@
+package status
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+const (
+	AudienceContacts = "contacts"
+	AudienceAllow    = "allow"
+	AudienceDeny     = "deny"
+)
+
+// BaseRequest carries the fields shared by every status post. AudienceType is empty, "contacts", "allow" or "deny";
+// AudienceJIDs lists the contacts for allow/deny audiences.
+type BaseRequest struct {
+	AgentID      string   `json:"agent_id,omitempty" form:"agent_id"`
+	AudienceType string   `json:"audience_type,omitempty" form:"audience_type"`
+	AudienceJIDs []string `json:"audience_jids,omitempty" form:"audience_jids"`
+}
+
+type TextRequest struct {
+	BaseRequest
+	Text            string `json:"text" form:"text"`
+	BackgroundColor string `json:"background_color" form:"background_color"`
+	Font            *int32 `json:"font" form:"font"`
+}
+
+type ImageRequest struct {
+	BaseRequest
+	Caption  string                `json:"caption" form:"caption"`
+	Image    *multipart.FileHeader `json:"image" form:"image"`
+	ImageURL *string               `json:"image_url" form:"image_url"`
+	Compress bool                  `json:"compress"`
+}
+
+type VideoRequest struct {
+	BaseRequest
+	Caption  string                `json:"caption" form:"caption"`
+	Video    *multipart.FileHeader `json:"video" form:"video"`
+	VideoURL *string               `json:"video_url" form:"video_url"`
+	Compress bool                  `json:"compress"`
+}
+
+type GenericResponse struct {
+	MessageID string `json:"message_id"`
+	Status    string `json:"status"`
+}
+
+type ListRequest struct {
+	AgentID string `json:"agent_id,omitempty" query:"agent_id"`
+	Sender  string `json:"sender" query:"sender"`
+	Limit   int    `json:"limit" query:"limit"`
+	Offset  int    `json:"offset" query:"offset"`
+}
+
+type StatusItem struct {
+	ID        string    `json:"id"`
+	Sender    string    `json:"sender"`
+	Content   string    `json:"content"`
+	MediaType string    `json:"media_type,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type ListResponse struct {
+	Data []StatusItem `json:"data"`
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"mime/multipart"
+	"time"
 
 	"go.mau.fi/whatsmeow/types"
 )
@@ -47,6 +48,33 @@ type MyPrivacySettingResponse struct {
 	Status       string `json:"status"`
 	Profile      string `json:"profile"`
 	ReadReceipts string `json:"read_receipts"`
+	Online       string `json:"online"`
+	CallAdd      string `json:"call_add"`
+	// DefaultDisappearingSeconds is only known right after it is set, WhatsApp has no way to read it back
+	DefaultDisappearingSeconds *uint32 `json:"default_disappearing_seconds,omitempty"`
+}
+
+// Privacy setting values; each setting accepts only some of them
+const (
+	PrivacyAll              = "all"
+	PrivacyContacts         = "contacts"
+	PrivacyContactBlacklist = "contact_blacklist"
+	PrivacyNone             = "none"
+	PrivacyMatchLastSeen    = "match_last_seen"
+	PrivacyKnown            = "known"
+)
+
+// SetPrivacySettingRequest changes only the settings that are given. The fields match MyPrivacySettingResponse,
+// so the settings of one account can be copied to another as they are; status is who sees the about text.
+type SetPrivacySettingRequest struct {
+	GroupAdd                   *string `json:"group_add"`
+	LastSeen                   *string `json:"last_seen"`
+	Status                     *string `json:"status"`
+	Profile                    *string `json:"profile"`
+	ReadReceipts               *string `json:"read_receipts"`
+	Online                     *string `json:"online"`
+	CallAdd                    *string `json:"call_add"`
+	DefaultDisappearingSeconds *uint32 `json:"default_disappearing_seconds"`
 }
 
 type MyListGroupsResponse struct {
@@ -74,6 +102,48 @@ type ChangePushNameRequest struct {
 	PushName string `json:"push_name" form:"push_name"`
 }
 
+type BlockRequest struct {
+	Phone string `json:"phone" form:"phone"`
+}
+
+type BlocklistResponse struct {
+	JIDs []string `json:"jids"`
+}
+
+// LIDMappingRequest looks up the other form of a user, given as a phone number, phone number JID or LID
+type LIDMappingRequest struct {
+	JID string `json:"jid" query:"jid"`
+}
+
+type LIDMappingResponse struct {
+	PN  string `json:"pn"`
+	LID string `json:"lid"`
+}
+
+type ChangeAboutRequest struct {
+	About string `json:"about" form:"about"`
+}
+
+// Business hours modes. Only specific_hours days carry open and close times.
+const (
+	BusinessHoursSpecificHours   = "specific_hours"
+	BusinessHoursOpen24h         = "open_24h"
+	BusinessHoursAppointmentOnly = "appointment_only"
+)
+
+// UpdateBusinessProfileRequest changes the business profile of our own account. Fields that are left out stay
+// as they are; websites, categories and business hours replace the current ones when given, and an empty
+// websites list removes them.
+type UpdateBusinessProfileRequest struct {
+	Description           *string                      `json:"description"`
+	Address               *string                      `json:"address"`
+	Email                 *string                      `json:"email"`
+	Websites              []string                     `json:"websites"`
+	CategoryIDs           []string                     `json:"category_ids"`
+	BusinessHoursTimeZone string                       `json:"business_hours_timezone"`
+	BusinessHours         []BusinessProfileHoursConfig `json:"business_hours"`
+}
+
 type CheckRequest struct {
 	Phone string `json:"phone" query:"phone"`
 }
@@ -82,6 +152,44 @@ type CheckResponse struct {
 	IsOnWhatsApp bool `json:"is_on_whatsapp"`
 }
 
+// MaxBulkCheckNumbers is how many phone numbers one bulk check accepts
+const MaxBulkCheckNumbers = 10000
+
+// BulkCheckRequest checks many phone numbers at once, given as a list or as a CSV file in the format group
+// participant imports use
+type BulkCheckRequest struct {
+	Phones []string              `json:"phones" form:"phones"`
+	File   *multipart.FileHeader `json:"file" form:"file"`
+}
+
+// Outcomes of a single number in a bulk check
+const (
+	CheckStatusRegistered    = "registered"
+	CheckStatusNotRegistered = "not_registered"
+	CheckStatusInvalid       = "invalid"
+	CheckStatusFailed        = "failed"
+)
+
+type BulkCheckResult struct {
+	Input      string     `json:"input"`
+	Phone      string     `json:"phone,omitempty"`
+	Status     string     `json:"status"`
+	JID        string     `json:"jid,omitempty"`
+	LID        string     `json:"lid,omitempty"`
+	IsBusiness bool       `json:"is_business"`
+	CheckedAt  *time.Time `json:"checked_at,omitempty"`
+	Message    string     `json:"message,omitempty"`
+}
+
+type BulkCheckResponse struct {
+	Total         int               `json:"total"`
+	Registered    int               `json:"registered"`
+	NotRegistered int               `json:"not_registered"`
+	Invalid       int               `json:"invalid"`
+	Failed        int               `json:"failed"`
+	Results       []BulkCheckResult `json:"results"`
+}
+
 type BusinessProfileRequest struct {
 	Phone string `json:"phone" query:"phone"`
 }
@@ -8,7 +8,9 @@ import (
 type IUserInfo interface {
 	Info(ctx context.Context, request InfoRequest) (response InfoResponse, err error)
 	IsOnWhatsApp(ctx context.Context, request CheckRequest) (response CheckResponse, err error)
+	CheckBulk(ctx context.Context, request BulkCheckRequest) (response BulkCheckResponse, err error)
 	BusinessProfile(ctx context.Context, request BusinessProfileRequest) (response BusinessProfileResponse, err error)
+	LIDMapping(ctx context.Context, request LIDMappingRequest) (response LIDMappingResponse, err error)
 }
 
 // IUserProfile handles user profile operations
@@ -16,6 +18,8 @@ type IUserProfile interface {
 	Avatar(ctx context.Context, request AvatarRequest) (response AvatarResponse, err error)
 	ChangeAvatar(ctx context.Context, request ChangeAvatarRequest) (err error)
 	ChangePushName(ctx context.Context, request ChangePushNameRequest) (err error)
+	ChangeAbout(ctx context.Context, request ChangeAboutRequest) (err error)
+	UpdateBusinessProfile(ctx context.Context, request UpdateBusinessProfileRequest) (response BusinessProfileResponse, err error)
 }
 
 // IUserListing handles user listing operations
@@ -28,6 +32,14 @@ type IUserListing interface {
 // IUserPrivacy handles user privacy operations
 type IUserPrivacy interface {
 	MyPrivacySetting(ctx context.Context) (response MyPrivacySettingResponse, err error)
+	SetPrivacySetting(ctx context.Context, request SetPrivacySettingRequest) (response MyPrivacySettingResponse, err error)
+}
+
+// IUserBlocklist handles blocking and unblocking users
+type IUserBlocklist interface {
+	Blocklist(ctx context.Context) (response BlocklistResponse, err error)
+	Block(ctx context.Context, request BlockRequest) (response BlocklistResponse, err error)
+	Unblock(ctx context.Context, request BlockRequest) (response BlocklistResponse, err error)
 }
 
 // IUserUsecase combines all user interfaces for backward compatibility
@@ -36,4 +48,5 @@ type IUserUsecase interface {
 	IUserProfile
 	IUserListing
 	IUserPrivacy
+	IUserBlocklist
 }
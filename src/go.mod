@@ -24,6 +24,7 @@ require (
 	github.com/stretchr/testify v1.11.1
 	github.com/valyala/fasthttp v1.51.0
 	go.mau.fi/libsignal v0.2.1
+	go.mau.fi/util v0.9.3
 	go.mau.fi/whatsmeow v0.0.0-20251116104239-3aca43070cd4
 	golang.org/x/image v0.33.0
 	golang.org/x/time v0.5.0
@@ -70,7 +71,6 @@ require (
 	github.com/vektah/gqlparser/v2 v2.5.27 // indirect
 	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
-	go.mau.fi/util v0.9.3 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/crypto v0.44.0 // indirect
 	golang.org/x/exp v0.0.0-20251113190631-e25ba8c21ef6 // indirect
@@ -3,6 +3,7 @@ package chatstorage
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/proto/waE2E"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
@@ -93,7 +95,8 @@ func (r *SQLiteRepository) StoreChat(chat *domainChatStorage.Chat) error {
 // GetChat retrieves a chat by JID
 func (r *SQLiteRepository) GetChat(jid string) (*domainChatStorage.Chat, error) {
 	query := `
-		SELECT jid, name, last_message_time, ephemeral_expiration, created_at, updated_at
+		SELECT jid, name, last_message_time, ephemeral_expiration, archived, mute_end_timestamp, marked_unread,
+			unread_count, last_read_message_id, last_activity, created_at, updated_at
 		FROM chats
 		WHERE jid = ?
 	`
@@ -110,9 +113,10 @@ func (r *SQLiteRepository) GetChat(jid string) (*domainChatStorage.Chat, error)
 // This is more efficient than searching through all chats
 func (r *SQLiteRepository) GetMessageByID(id string) (*domainChatStorage.Message, error) {
 	query := `
-		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
+		SELECT id, chat_jid, sender, sender_lid, content, timestamp, is_from_me,
 			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
+			file_enc_sha256, file_length, mimetype, direct_path, forwarding_score,
+			created_at, updated_at
 		FROM messages
 		WHERE id = ?
 		LIMIT 1
@@ -132,7 +136,8 @@ func (r *SQLiteRepository) GetChats(filter *domainChatStorage.ChatFilter) ([]*do
 	var args []any
 
 	query := `
-		SELECT c.jid, c.name, c.last_message_time, c.ephemeral_expiration, c.created_at, c.updated_at
+		SELECT c.jid, c.name, c.last_message_time, c.ephemeral_expiration, c.archived, c.mute_end_timestamp,
+			c.marked_unread, c.unread_count, c.last_read_message_id, c.last_activity, c.created_at, c.updated_at
 		FROM chats c
 	`
 
@@ -146,11 +151,38 @@ func (r *SQLiteRepository) GetChats(filter *domainChatStorage.ChatFilter) ([]*do
 		conditions = append(conditions, "m.media_type != ''")
 	}
 
+	if filter.Archived != nil {
+		conditions = append(conditions, "c.archived = ?")
+		args = append(args, *filter.Archived)
+	}
+
+	if filter.Muted != nil {
+		muted := "(c.mute_end_timestamp = -1 OR c.mute_end_timestamp > ?)"
+		if !*filter.Muted {
+			muted = "NOT " + muted
+		}
+		conditions = append(conditions, muted)
+		args = append(args, time.Now().UnixMilli())
+	}
+
+	if filter.Unread != nil {
+		unread := "(c.unread_count > 0 OR c.marked_unread = ?)"
+		if !*filter.Unread {
+			unread = "NOT " + unread
+		}
+		conditions = append(conditions, unread)
+		args = append(args, true)
+	}
+
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY c.last_message_time DESC"
+	if filter.SortBy == domainChatStorage.ChatSortUnread {
+		query += " ORDER BY c.unread_count DESC, c.marked_unread DESC, c.last_message_time DESC"
+	} else {
+		query += " ORDER BY c.last_message_time DESC"
+	}
 
 	// Safely add LIMIT and OFFSET using parameterized values
 	if filter.Limit > 0 {
@@ -193,6 +225,11 @@ func (r *SQLiteRepository) DeleteChat(jid string) error {
 	}
 	defer tx.Rollback()
 
+	// Pins point at messages that are about to disappear
+	if _, err = r.txExec(tx, "DELETE FROM message_pins WHERE chat_jid = ?", jid); err != nil {
+		return err
+	}
+
 	// Delete messages first (foreign key constraint)
 	_, err = r.txExec(tx, "DELETE FROM messages WHERE chat_jid = ?", jid)
 	if err != nil {
@@ -208,6 +245,216 @@ func (r *SQLiteRepository) DeleteChat(jid string) error {
 	return tx.Commit()
 }
 
+// mergeLIDChat moves a direct chat stored under the LID of a user into the chat of their phone number JID. Chats
+// were kept under whichever form a message arrived in before the phone number JID was preferred, and a LID whose
+// phone number is still unknown keeps its own chat until the mapping is learned. The phone number chat must exist.
+func (r *SQLiteRepository) mergeLIDChat(lidJID, pnJID string) error {
+	if lidJID == "" || lidJID == pnJID {
+		return nil
+	}
+	lidChat, err := r.GetChat(lidJID)
+	if err != nil || lidChat == nil {
+		return err
+	}
+	pnChat, err := r.GetChat(pnJID)
+	if err != nil {
+		return err
+	}
+	if pnChat == nil {
+		return fmt.Errorf("chat %s to merge %s into does not exist", pnJID, lidJID)
+	}
+	merged := mergeChatState(pnChat, lidChat)
+	var lastActivity any
+	if !merged.LastActivity.IsZero() {
+		lastActivity = merged.LastActivity
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Rows already present under the phone number JID win, the rest of the LID chat is moved over
+	moves := []struct{ table, key string }{
+		{"messages", "id"},
+		{"polls", "message_id"},
+		{"message_pins", "message_id"},
+	}
+	for _, move := range moves {
+		query := fmt.Sprintf(`UPDATE %[1]s SET chat_jid = ? WHERE chat_jid = ?
+			AND %[2]s NOT IN (SELECT %[2]s FROM %[1]s WHERE chat_jid = ?)`, move.table, move.key)
+		if _, err := r.txExec(tx, query, pnJID, lidJID, pnJID); err != nil {
+			return err
+		}
+	}
+	if _, err := r.txExec(tx, `UPDATE poll_votes SET chat_jid = ? WHERE chat_jid = ?
+		AND NOT EXISTS (SELECT 1 FROM poll_votes v WHERE v.chat_jid = ?
+			AND v.poll_message_id = poll_votes.poll_message_id AND v.voter = poll_votes.voter)`,
+		pnJID, lidJID, pnJID); err != nil {
+		return err
+	}
+	for _, table := range []string{"message_pins", "poll_votes", "polls", "messages"} {
+		if _, err := r.txExec(tx, fmt.Sprintf("DELETE FROM %s WHERE chat_jid = ?", table), lidJID); err != nil {
+			return err
+		}
+	}
+	if _, err := r.txExec(tx, `
+		UPDATE chats SET
+			name = ?, last_message_time = ?, ephemeral_expiration = ?, archived = ?, mute_end_timestamp = ?,
+			marked_unread = ?, unread_count = ?, last_read_message_id = ?, last_activity = ?, updated_at = ?
+		WHERE jid = ?`,
+		merged.Name, merged.LastMessageTime, merged.EphemeralExpiration, merged.Archived, merged.MuteEndTimestamp,
+		merged.MarkedUnread, merged.UnreadCount, merged.LastReadMessageID, lastActivity, time.Now(), pnJID,
+	); err != nil {
+		return err
+	}
+	if _, err := r.txExec(tx, "DELETE FROM chats WHERE jid = ?", lidJID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// mergeChatState combines the chat-list state of the LID chat of a user into their phone number chat. Flags set on
+// either chat stay set, the longer mute wins, unread messages add up, and the read position and timer come from
+// whichever chat has them.
+func mergeChatState(pn, lid *domainChatStorage.Chat) *domainChatStorage.Chat {
+	merged := *pn
+	if merged.Name == "" {
+		merged.Name = lid.Name
+	}
+	if lid.LastMessageTime.After(merged.LastMessageTime) {
+		merged.LastMessageTime = lid.LastMessageTime
+	}
+	if merged.EphemeralExpiration == 0 {
+		merged.EphemeralExpiration = lid.EphemeralExpiration
+	}
+	merged.Archived = merged.Archived || lid.Archived
+	if merged.MuteEndTimestamp != -1 && (lid.MuteEndTimestamp == -1 || lid.MuteEndTimestamp > merged.MuteEndTimestamp) {
+		merged.MuteEndTimestamp = lid.MuteEndTimestamp
+	}
+	merged.MarkedUnread = merged.MarkedUnread || lid.MarkedUnread
+	merged.UnreadCount += lid.UnreadCount
+	if lid.LastActivity.After(merged.LastActivity) {
+		merged.LastActivity = lid.LastActivity
+		if lid.LastReadMessageID != "" {
+			merged.LastReadMessageID = lid.LastReadMessageID
+		}
+	}
+	if merged.LastReadMessageID == "" {
+		merged.LastReadMessageID = lid.LastReadMessageID
+	}
+	return &merged
+}
+
+// UpdateChatState applies app-state changes such as archive, mute and mark-unread to a chat.
+// Chats that have no stored messages yet are created so the state is not lost.
+func (r *SQLiteRepository) UpdateChatState(jid string, update domainChatStorage.ChatStateUpdate) error {
+	var sets []string
+	var args []any
+	if update.Archived != nil {
+		sets = append(sets, "archived = ?")
+		args = append(args, *update.Archived)
+	}
+	if update.MuteEndTimestamp != nil {
+		sets = append(sets, "mute_end_timestamp = ?")
+		args = append(args, *update.MuteEndTimestamp)
+	}
+	if update.MarkedUnread != nil {
+		sets = append(sets, "marked_unread = ?")
+		args = append(args, *update.MarkedUnread)
+	}
+	if update.UnreadCount != nil {
+		sets = append(sets, "unread_count = ?")
+		args = append(args, *update.UnreadCount)
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	existing, err := r.GetChat(jid)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		name := jid
+		if parsed, err := types.ParseJID(jid); err == nil {
+			name = r.GetChatNameWithPushName(parsed, jid, "", "")
+		}
+		if err := r.StoreChat(&domainChatStorage.Chat{JID: jid, Name: name}); err != nil {
+			return err
+		}
+	}
+
+	sets = append(sets, "updated_at = ?")
+	args = append(args, time.Now(), jid)
+	_, err = r.exec("UPDATE chats SET "+strings.Join(sets, ", ")+" WHERE jid = ?", args...)
+	return err
+}
+
+// MarkChatRead records that messages of a chat were read, here or on another device. The newest stored message
+// among messageIDs becomes the last read message, and incoming messages after it stay counted as unread. When none
+// of them is stored, everything received before readAt counts as read.
+func (r *SQLiteRepository) MarkChatRead(chatJID string, messageIDs []string, readAt time.Time) error {
+	lastReadID := ""
+	cutoff := readAt
+	if len(messageIDs) > 0 {
+		lastReadID = messageIDs[len(messageIDs)-1]
+
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(messageIDs)), ", ")
+		args := []any{chatJID}
+		for _, id := range messageIDs {
+			args = append(args, id)
+		}
+		var newestID string
+		var newestTS time.Time
+		err := r.queryRow(
+			"SELECT id, timestamp FROM messages WHERE chat_jid = ? AND id IN ("+placeholders+") ORDER BY timestamp DESC LIMIT 1",
+			args...,
+		).Scan(&newestID, &newestTS)
+		switch {
+		case err == nil:
+			lastReadID, cutoff = newestID, newestTS
+		case err != sql.ErrNoRows:
+			return err
+		}
+	}
+
+	_, err := r.exec(`
+		UPDATE chats SET
+			unread_count = (SELECT COUNT(*) FROM messages WHERE chat_jid = ? AND is_from_me = ? AND timestamp > ?),
+			marked_unread = ?,
+			last_read_message_id = CASE WHEN ? = '' THEN last_read_message_id ELSE ? END,
+			last_activity = ?,
+			updated_at = ?
+		WHERE jid = ?
+	`, chatJID, false, cutoff, false, lastReadID, lastReadID, readAt, time.Now(), chatJID)
+	return err
+}
+
+// recordIncomingActivity counts a newly stored message towards the chat's unread counter when it was not sent by us
+func (r *SQLiteRepository) recordIncomingActivity(chatJID string, fromMe bool, at time.Time) error {
+	unreadDelta := 1
+	if fromMe {
+		unreadDelta = 0
+	}
+	_, err := r.exec(
+		"UPDATE chats SET unread_count = unread_count + ?, last_activity = ? WHERE jid = ?",
+		unreadDelta, at, chatJID,
+	)
+	return err
+}
+
+// ClearChatMessages deletes all messages of a chat while keeping the chat itself
+func (r *SQLiteRepository) ClearChatMessages(jid string) error {
+	if _, err := r.exec("DELETE FROM message_pins WHERE chat_jid = ?", jid); err != nil {
+		return err
+	}
+	_, err := r.exec("DELETE FROM messages WHERE chat_jid = ?", jid)
+	return err
+}
+
 // StoreMessage creates or updates a message
 func (r *SQLiteRepository) StoreMessage(message *domainChatStorage.Message) error {
 	now := time.Now()
@@ -222,12 +469,14 @@ func (r *SQLiteRepository) StoreMessage(message *domainChatStorage.Message) erro
 
 	query := `
 		INSERT INTO messages (
-			id, chat_jid, sender, content, timestamp, is_from_me, 
+			id, chat_jid, sender, sender_lid, content, timestamp, is_from_me, 
 			media_type, filename, url, media_key, file_sha256, 
-			file_enc_sha256, file_length, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			file_enc_sha256, file_length, mimetype, direct_path, forwarding_score,
+			created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id, chat_jid) DO UPDATE SET
 			sender = excluded.sender,
+			sender_lid = COALESCE(NULLIF(excluded.sender_lid, ''), messages.sender_lid),
 			content = excluded.content,
 			timestamp = excluded.timestamp,
 			is_from_me = excluded.is_from_me,
@@ -238,14 +487,18 @@ func (r *SQLiteRepository) StoreMessage(message *domainChatStorage.Message) erro
 			file_sha256 = excluded.file_sha256,
 			file_enc_sha256 = excluded.file_enc_sha256,
 			file_length = excluded.file_length,
+			mimetype = excluded.mimetype,
+			direct_path = excluded.direct_path,
+			forwarding_score = excluded.forwarding_score,
 			updated_at = excluded.updated_at
 	`
 
 	_, err := r.exec(query,
-		message.ID, message.ChatJID, message.Sender, message.Content,
+		message.ID, message.ChatJID, message.Sender, message.SenderLID, message.Content,
 		message.Timestamp, message.IsFromMe, message.MediaType, message.Filename,
 		message.URL, message.MediaKey, message.FileSHA256, message.FileEncSHA256,
-		message.FileLength, message.CreatedAt, message.UpdatedAt,
+		message.FileLength, message.Mimetype, message.DirectPath, message.ForwardingScore,
+		message.CreatedAt, message.UpdatedAt,
 	)
 
 	return err
@@ -266,12 +519,14 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 	// Prepare the statement once for better performance
 	stmt, err := tx.Prepare(r.rebind(`
 		INSERT INTO messages (
-			id, chat_jid, sender, content, timestamp, is_from_me, 
+			id, chat_jid, sender, sender_lid, content, timestamp, is_from_me, 
 			media_type, filename, url, media_key, file_sha256, 
-			file_enc_sha256, file_length, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			file_enc_sha256, file_length, mimetype, direct_path, forwarding_score,
+			created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id, chat_jid) DO UPDATE SET
 			sender = excluded.sender,
+			sender_lid = COALESCE(NULLIF(excluded.sender_lid, ''), messages.sender_lid),
 			content = excluded.content,
 			timestamp = excluded.timestamp,
 			is_from_me = excluded.is_from_me,
@@ -282,6 +537,9 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 			file_sha256 = excluded.file_sha256,
 			file_enc_sha256 = excluded.file_enc_sha256,
 			file_length = excluded.file_length,
+			mimetype = excluded.mimetype,
+			direct_path = excluded.direct_path,
+			forwarding_score = excluded.forwarding_score,
 			updated_at = excluded.updated_at
 	`))
 	if err != nil {
@@ -300,10 +558,11 @@ func (r *SQLiteRepository) StoreMessagesBatch(messages []*domainChatStorage.Mess
 		message.UpdatedAt = now
 
 		_, err = stmt.Exec(
-			message.ID, message.ChatJID, message.Sender, message.Content,
+			message.ID, message.ChatJID, message.Sender, message.SenderLID, message.Content,
 			message.Timestamp, message.IsFromMe, message.MediaType, message.Filename,
 			message.URL, message.MediaKey, message.FileSHA256, message.FileEncSHA256,
-			message.FileLength, message.CreatedAt, message.UpdatedAt,
+			message.FileLength, message.Mimetype, message.DirectPath, message.ForwardingScore,
+			message.CreatedAt, message.UpdatedAt,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to store message %s: %w", message.ID, err)
@@ -340,10 +599,17 @@ func (r *SQLiteRepository) GetMessages(filter *domainChatStorage.MessageFilter)
 		args = append(args, *filter.IsFromMe)
 	}
 
+	if filter.Sender != "" {
+		// A sender can be given in either form
+		conditions = append(conditions, "(sender = ? OR sender_lid = ?)")
+		args = append(args, filter.Sender, filter.Sender)
+	}
+
 	query := `
-		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
+		SELECT id, chat_jid, sender, sender_lid, content, timestamp, is_from_me,
 			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
+			file_enc_sha256, file_length, mimetype, direct_path, forwarding_score,
+			created_at, updated_at
 		FROM messages
 		WHERE ` + strings.Join(conditions, " AND ") + `
 		ORDER BY timestamp DESC
@@ -401,9 +667,10 @@ func (r *SQLiteRepository) SearchMessages(chatJID, searchText string, limit int)
 	args = append(args, "%"+strings.ToLower(searchText)+"%")
 
 	query := `
-		SELECT id, chat_jid, sender, content, timestamp, is_from_me,
+		SELECT id, chat_jid, sender, sender_lid, content, timestamp, is_from_me,
 			media_type, filename, url, media_key, file_sha256,
-			file_enc_sha256, file_length, created_at, updated_at
+			file_enc_sha256, file_length, mimetype, direct_path, forwarding_score,
+			created_at, updated_at
 		FROM messages
 		WHERE ` + strings.Join(conditions, " AND ") + `
 		ORDER BY timestamp DESC
@@ -458,10 +725,11 @@ func (r *SQLiteRepository) getCount(query string, args ...any) (int64, error) {
 func (r *SQLiteRepository) scanMessage(scanner interface{ Scan(...any) error }) (*domainChatStorage.Message, error) {
 	message := &domainChatStorage.Message{}
 	err := scanner.Scan(
-		&message.ID, &message.ChatJID, &message.Sender, &message.Content,
+		&message.ID, &message.ChatJID, &message.Sender, &message.SenderLID, &message.Content,
 		&message.Timestamp, &message.IsFromMe, &message.MediaType, &message.Filename,
 		&message.URL, &message.MediaKey, &message.FileSHA256, &message.FileEncSHA256,
-		&message.FileLength, &message.CreatedAt, &message.UpdatedAt,
+		&message.FileLength, &message.Mimetype, &message.DirectPath, &message.ForwardingScore,
+		&message.CreatedAt, &message.UpdatedAt,
 	)
 	return message, err
 }
@@ -469,13 +737,482 @@ func (r *SQLiteRepository) scanMessage(scanner interface{ Scan(...any) error })
 // scanChat is a private helper for scanning chat rows
 func (r *SQLiteRepository) scanChat(scanner interface{ Scan(...any) error }) (*domainChatStorage.Chat, error) {
 	chat := &domainChatStorage.Chat{}
+	var lastActivity sql.NullTime
 	err := scanner.Scan(
 		&chat.JID, &chat.Name, &chat.LastMessageTime, &chat.EphemeralExpiration,
+		&chat.Archived, &chat.MuteEndTimestamp, &chat.MarkedUnread,
+		&chat.UnreadCount, &chat.LastReadMessageID, &lastActivity,
 		&chat.CreatedAt, &chat.UpdatedAt,
 	)
+	chat.LastActivity = lastActivity.Time
 	return chat, err
 }
 
+// StorePoll creates or updates a poll definition
+func (r *SQLiteRepository) StorePoll(poll *domainChatStorage.Poll) error {
+	options, err := json.Marshal(poll.Options)
+	if err != nil {
+		return fmt.Errorf("failed to encode poll options: %w", err)
+	}
+	if poll.CreatedAt.IsZero() {
+		poll.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO polls (message_id, chat_jid, creator, question, options, selectable_count, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(message_id, chat_jid) DO UPDATE SET
+			question = excluded.question,
+			options = excluded.options,
+			selectable_count = excluded.selectable_count
+	`
+
+	_, err = r.exec(query, poll.MessageID, poll.ChatJID, poll.Creator, poll.Question, string(options), poll.SelectableCount, poll.CreatedAt)
+	return err
+}
+
+// GetPoll retrieves a poll by the ID of its creation message
+func (r *SQLiteRepository) GetPoll(messageID string) (*domainChatStorage.Poll, error) {
+	query := `
+		SELECT message_id, chat_jid, creator, question, options, selectable_count, created_at
+		FROM polls
+		WHERE message_id = ?
+		LIMIT 1
+	`
+
+	poll := &domainChatStorage.Poll{}
+	var options string
+	err := r.queryRow(query, messageID).Scan(
+		&poll.MessageID, &poll.ChatJID, &poll.Creator, &poll.Question,
+		&options, &poll.SelectableCount, &poll.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(options), &poll.Options); err != nil {
+		return nil, fmt.Errorf("failed to decode poll options: %w", err)
+	}
+	return poll, nil
+}
+
+// StorePollVote records a voter's current selection, replacing their previous vote.
+// Votes can arrive out of order, so an older vote never overwrites a newer one.
+func (r *SQLiteRepository) StorePollVote(vote *domainChatStorage.PollVote) error {
+	selected, err := json.Marshal(vote.SelectedOptions)
+	if err != nil {
+		return fmt.Errorf("failed to encode poll vote: %w", err)
+	}
+
+	query := `
+		INSERT INTO poll_votes (poll_message_id, chat_jid, voter, selected_options, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(poll_message_id, chat_jid, voter) DO UPDATE SET
+			selected_options = excluded.selected_options,
+			updated_at = excluded.updated_at
+		WHERE excluded.updated_at >= poll_votes.updated_at
+	`
+
+	_, err = r.exec(query, vote.PollMessageID, vote.ChatJID, vote.Voter, string(selected), vote.UpdatedAt)
+	return err
+}
+
+// StoreMessagePin records a pinned message, replacing an earlier pin of the same message
+func (r *SQLiteRepository) StoreMessagePin(pin *domainChatStorage.MessagePin) error {
+	query := `
+		INSERT INTO message_pins (chat_jid, message_id, pinned_by, pinned_at, expires_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(chat_jid, message_id) DO UPDATE SET
+			pinned_by = excluded.pinned_by,
+			pinned_at = excluded.pinned_at,
+			expires_at = excluded.expires_at
+	`
+
+	_, err := r.exec(query, pin.ChatJID, pin.MessageID, pin.PinnedBy, pin.PinnedAt, pin.ExpiresAt)
+	return err
+}
+
+// DeleteMessagePin removes a pin after the message was unpinned
+func (r *SQLiteRepository) DeleteMessagePin(chatJID, messageID string) error {
+	_, err := r.exec("DELETE FROM message_pins WHERE chat_jid = ? AND message_id = ?", chatJID, messageID)
+	return err
+}
+
+// GetMessagePins returns the pins of a chat that have not expired yet, newest first
+func (r *SQLiteRepository) GetMessagePins(chatJID string) ([]*domainChatStorage.MessagePin, error) {
+	query := `
+		SELECT chat_jid, message_id, pinned_by, pinned_at, expires_at
+		FROM message_pins
+		WHERE chat_jid = ? AND expires_at > ?
+		ORDER BY pinned_at DESC
+	`
+
+	rows, err := r.query(query, chatJID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pins []*domainChatStorage.MessagePin
+	for rows.Next() {
+		pin := &domainChatStorage.MessagePin{}
+		if err := rows.Scan(&pin.ChatJID, &pin.MessageID, &pin.PinnedBy, &pin.PinnedAt, &pin.ExpiresAt); err != nil {
+			return nil, err
+		}
+		pins = append(pins, pin)
+	}
+
+	return pins, rows.Err()
+}
+
+// GetPollVotes returns the current vote of every voter on a poll
+func (r *SQLiteRepository) GetPollVotes(pollMessageID, chatJID string) ([]*domainChatStorage.PollVote, error) {
+	query := `
+		SELECT poll_message_id, chat_jid, voter, selected_options, updated_at
+		FROM poll_votes
+		WHERE poll_message_id = ? AND chat_jid = ?
+		ORDER BY updated_at ASC
+	`
+
+	rows, err := r.query(query, pollMessageID, chatJID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var votes []*domainChatStorage.PollVote
+	for rows.Next() {
+		vote := &domainChatStorage.PollVote{}
+		var selected string
+		if err := rows.Scan(&vote.PollMessageID, &vote.ChatJID, &vote.Voter, &selected, &vote.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(selected), &vote.SelectedOptions); err != nil {
+			return nil, fmt.Errorf("failed to decode poll vote: %w", err)
+		}
+		votes = append(votes, vote)
+	}
+
+	return votes, rows.Err()
+}
+
+// StoreModerationRules creates or replaces the auto-moderation rules of a group
+func (r *SQLiteRepository) StoreModerationRules(rules *domainChatStorage.ModerationRules) error {
+	lists := make([]string, 0, 5)
+	for _, list := range [][]string{rules.AllowedDomains, rules.BannedKeywords, rules.BannedPatterns, rules.BlockedMediaTypes, rules.Actions} {
+		encoded, err := json.Marshal(list)
+		if err != nil {
+			return fmt.Errorf("failed to encode moderation rules: %w", err)
+		}
+		lists = append(lists, string(encoded))
+	}
+	if rules.UpdatedAt.IsZero() {
+		rules.UpdatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO group_moderation_rules (
+			group_jid, enabled, block_links, allowed_domains, banned_keywords, banned_patterns,
+			flood_max_messages, flood_window_seconds, blocked_media_types, actions, warning_message, max_strikes, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(group_jid) DO UPDATE SET
+			enabled = excluded.enabled,
+			block_links = excluded.block_links,
+			allowed_domains = excluded.allowed_domains,
+			banned_keywords = excluded.banned_keywords,
+			banned_patterns = excluded.banned_patterns,
+			flood_max_messages = excluded.flood_max_messages,
+			flood_window_seconds = excluded.flood_window_seconds,
+			blocked_media_types = excluded.blocked_media_types,
+			actions = excluded.actions,
+			warning_message = excluded.warning_message,
+			max_strikes = excluded.max_strikes,
+			updated_at = excluded.updated_at
+	`
+
+	_, err := r.exec(query,
+		rules.GroupJID, rules.Enabled, rules.BlockLinks, lists[0], lists[1], lists[2],
+		rules.FloodMaxMessages, rules.FloodWindowSeconds, lists[3], lists[4], rules.WarningMessage, rules.MaxStrikes, rules.UpdatedAt,
+	)
+	return err
+}
+
+// GetModerationRules returns the auto-moderation rules of a group, or nil when it has none
+func (r *SQLiteRepository) GetModerationRules(groupJID string) (*domainChatStorage.ModerationRules, error) {
+	query := `
+		SELECT group_jid, enabled, block_links, allowed_domains, banned_keywords, banned_patterns,
+			flood_max_messages, flood_window_seconds, blocked_media_types, actions, warning_message, max_strikes, updated_at
+		FROM group_moderation_rules
+		WHERE group_jid = ?
+	`
+
+	rules := &domainChatStorage.ModerationRules{}
+	var allowedDomains, bannedKeywords, bannedPatterns, blockedMediaTypes, actions string
+	err := r.queryRow(query, groupJID).Scan(
+		&rules.GroupJID, &rules.Enabled, &rules.BlockLinks, &allowedDomains, &bannedKeywords, &bannedPatterns,
+		&rules.FloodMaxMessages, &rules.FloodWindowSeconds, &blockedMediaTypes, &actions, &rules.WarningMessage, &rules.MaxStrikes, &rules.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lists := []struct {
+		encoded string
+		target  *[]string
+	}{
+		{allowedDomains, &rules.AllowedDomains},
+		{bannedKeywords, &rules.BannedKeywords},
+		{bannedPatterns, &rules.BannedPatterns},
+		{blockedMediaTypes, &rules.BlockedMediaTypes},
+		{actions, &rules.Actions},
+	}
+	for _, list := range lists {
+		if err := json.Unmarshal([]byte(list.encoded), list.target); err != nil {
+			return nil, fmt.Errorf("failed to decode moderation rules: %w", err)
+		}
+	}
+	return rules, nil
+}
+
+// DeleteModerationRules turns auto-moderation off for a group and forgets its strikes. The audit log is kept.
+func (r *SQLiteRepository) DeleteModerationRules(groupJID string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"group_moderation_rules", "moderation_strikes"} {
+		if _, err = r.txExec(tx, "DELETE FROM "+table+" WHERE group_jid = ?", groupJID); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", table, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// AddModerationStrike counts a rule violation of a participant and returns their strikes so far
+func (r *SQLiteRepository) AddModerationStrike(groupJID, participant string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO moderation_strikes (group_jid, participant, strikes, updated_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT(group_jid, participant) DO UPDATE SET
+			strikes = moderation_strikes.strikes + 1,
+			updated_at = excluded.updated_at
+	`
+	if _, err = r.txExec(tx, query, groupJID, participant, time.Now()); err != nil {
+		return 0, err
+	}
+
+	var strikes int
+	err = r.txQueryRow(tx, "SELECT strikes FROM moderation_strikes WHERE group_jid = ? AND participant = ?", groupJID, participant).Scan(&strikes)
+	if err != nil {
+		return 0, err
+	}
+
+	return strikes, tx.Commit()
+}
+
+// ResetModerationStrikes clears the strikes of a participant, for example after they were removed
+func (r *SQLiteRepository) ResetModerationStrikes(groupJID, participant string) error {
+	_, err := r.exec("DELETE FROM moderation_strikes WHERE group_jid = ? AND participant = ?", groupJID, participant)
+	return err
+}
+
+// StoreModerationAudit appends an entry to the moderation audit log
+func (r *SQLiteRepository) StoreModerationAudit(entry *domainChatStorage.ModerationAuditEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO moderation_audit_log (group_jid, message_id, participant, rule, detail, action, success, error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.exec(query, entry.GroupJID, entry.MessageID, entry.Participant, entry.Rule, entry.Detail,
+		entry.Action, entry.Success, entry.Error, entry.CreatedAt)
+	return err
+}
+
+// GetModerationAudit returns the moderation audit log of a group, newest first
+func (r *SQLiteRepository) GetModerationAudit(groupJID string, limit, offset int) ([]*domainChatStorage.ModerationAuditEntry, error) {
+	query := `
+		SELECT id, group_jid, message_id, participant, rule, detail, action, success, error, created_at
+		FROM moderation_audit_log
+		WHERE group_jid = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+
+	rows, err := r.query(query, groupJID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domainChatStorage.ModerationAuditEntry
+	for rows.Next() {
+		entry := &domainChatStorage.ModerationAuditEntry{}
+		if err := rows.Scan(&entry.ID, &entry.GroupJID, &entry.MessageID, &entry.Participant, &entry.Rule,
+			&entry.Detail, &entry.Action, &entry.Success, &entry.Error, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// StoreJoinRequestPolicy creates or replaces the join request policy of a group
+func (r *SQLiteRepository) StoreJoinRequestPolicy(policy *domainChatStorage.JoinRequestPolicy) error {
+	prefixes, err := json.Marshal(policy.AllowedPrefixes)
+	if err != nil {
+		return fmt.Errorf("failed to encode allowed prefixes: %w", err)
+	}
+	if policy.UpdatedAt.IsZero() {
+		policy.UpdatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO group_join_policies (group_jid, enabled, allowed_prefixes, require_contact, hook_url, fail_action, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(group_jid) DO UPDATE SET
+			enabled = excluded.enabled,
+			allowed_prefixes = excluded.allowed_prefixes,
+			require_contact = excluded.require_contact,
+			hook_url = excluded.hook_url,
+			fail_action = excluded.fail_action,
+			updated_at = excluded.updated_at
+	`
+
+	_, err = r.exec(query, policy.GroupJID, policy.Enabled, string(prefixes), policy.RequireContact,
+		policy.HookURL, policy.FailAction, policy.UpdatedAt)
+	return err
+}
+
+// GetJoinRequestPolicy returns the join request policy of a group, or nil when it has none
+func (r *SQLiteRepository) GetJoinRequestPolicy(groupJID string) (*domainChatStorage.JoinRequestPolicy, error) {
+	query := `
+		SELECT group_jid, enabled, allowed_prefixes, require_contact, hook_url, fail_action, updated_at
+		FROM group_join_policies
+		WHERE group_jid = ?
+	`
+
+	policy := &domainChatStorage.JoinRequestPolicy{}
+	var prefixes string
+	err := r.queryRow(query, groupJID).Scan(
+		&policy.GroupJID, &policy.Enabled, &prefixes, &policy.RequireContact,
+		&policy.HookURL, &policy.FailAction, &policy.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(prefixes), &policy.AllowedPrefixes); err != nil {
+		return nil, fmt.Errorf("failed to decode allowed prefixes: %w", err)
+	}
+	return policy, nil
+}
+
+// DeleteJoinRequestPolicy stops automatic decisions on join requests of a group
+func (r *SQLiteRepository) DeleteJoinRequestPolicy(groupJID string) error {
+	_, err := r.exec("DELETE FROM group_join_policies WHERE group_jid = ?", groupJID)
+	return err
+}
+
+// whatsAppNumberLookupChunk keeps the number of query parameters below what SQLite allows in one statement
+const whatsAppNumberLookupChunk = 500
+
+// StoreWhatsAppNumbers creates or refreshes cached number checks
+func (r *SQLiteRepository) StoreWhatsAppNumbers(numbers []*domainChatStorage.WhatsAppNumber) error {
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO whatsapp_numbers (phone, is_registered, jid, lid, is_business, checked_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(phone) DO UPDATE SET
+			is_registered = excluded.is_registered,
+			jid = excluded.jid,
+			lid = excluded.lid,
+			is_business = excluded.is_business,
+			checked_at = excluded.checked_at
+	`
+	for _, number := range numbers {
+		if number.CheckedAt.IsZero() {
+			number.CheckedAt = time.Now()
+		}
+		if _, err = r.txExec(tx, query, number.Phone, number.IsRegistered, number.JID, number.LID,
+			number.IsBusiness, number.CheckedAt); err != nil {
+			return fmt.Errorf("failed to store number %s: %w", number.Phone, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetWhatsAppNumbers returns the cached checks of the given phone numbers made since the given time. Numbers
+// without a recent enough check are left out.
+func (r *SQLiteRepository) GetWhatsAppNumbers(phones []string, checkedSince time.Time) ([]*domainChatStorage.WhatsAppNumber, error) {
+	var numbers []*domainChatStorage.WhatsAppNumber
+	for start := 0; start < len(phones); start += whatsAppNumberLookupChunk {
+		chunk := phones[start:min(start+whatsAppNumberLookupChunk, len(phones))]
+
+		args := make([]any, 0, len(chunk)+1)
+		args = append(args, checkedSince)
+		for _, phone := range chunk {
+			args = append(args, phone)
+		}
+		query := `
+			SELECT phone, is_registered, jid, lid, is_business, checked_at
+			FROM whatsapp_numbers
+			WHERE checked_at >= ? AND phone IN (` + strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ") + `)
+		`
+
+		rows, err := r.query(query, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			number := &domainChatStorage.WhatsAppNumber{}
+			if err := rows.Scan(&number.Phone, &number.IsRegistered, &number.JID, &number.LID,
+				&number.IsBusiness, &number.CheckedAt); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			numbers = append(numbers, number)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return numbers, nil
+}
+
 // GetChatMessageCount returns the number of messages in a chat
 func (r *SQLiteRepository) GetChatMessageCount(chatJID string) (int64, error) {
 	return r.getCount("SELECT COUNT(*) FROM messages WHERE chat_jid = ?", chatJID)
@@ -500,6 +1237,13 @@ func (r *SQLiteRepository) TruncateAllChats() error {
 	}
 	defer tx.Rollback()
 
+	// Poll and pin data belongs to the chats being removed
+	for _, table := range []string{"poll_votes", "polls", "message_pins"} {
+		if _, err = r.txExec(tx, "DELETE FROM "+table); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", table, err)
+		}
+	}
+
 	// Delete messages first (foreign key constraint)
 	_, err = r.txExec(tx, "DELETE FROM messages")
 	if err != nil {
@@ -558,13 +1302,20 @@ func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Messag
 		return nil
 	}
 
-	// Extract chat and sender information
-	chatJID := evt.Info.Chat.String()
-	// Store the full sender JID (user@server) to ensure consistency between received and sent messages
+	// Extract chat and sender information. A direct chat addressed by LID is kept under the phone number JID
+	// when it is known, so the conversation does not split between the two forms.
+	chat := utils.MessageChatJID(evt.Info.MessageSource)
+	chatJID := chat.String()
+	// Store the full sender JID (user@server) to ensure consistency between received and sent messages,
+	// preferring the phone number form and keeping the LID alongside
+	senderJIDs := utils.MessageSenderJIDs(evt.Info.MessageSource)
 	sender := evt.Info.Sender.String()
+	if pn := senderJIDs.PN; !pn.IsEmpty() && evt.Info.Sender.Server == types.HiddenUserServer {
+		sender = pn.String()
+	}
 
 	// Get appropriate chat name using pushname if available
-	chatName := r.GetChatNameWithPushName(evt.Info.Chat, chatJID, evt.Info.Sender.User, evt.Info.PushName)
+	chatName := r.GetChatNameWithPushName(chat, chatJID, evt.Info.Sender.User, evt.Info.PushName)
 
 	// Get existing chat to preserve ephemeral_expiration if needed
 	existingChat, err := r.GetChat(chatJID)
@@ -576,7 +1327,7 @@ func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Messag
 	ephemeralExpiration := utils.ExtractEphemeralExpiration(evt.Message)
 
 	// Create or update chat
-	chat := &domainChatStorage.Chat{
+	storedChat := &domainChatStorage.Chat{
 		JID:             chatJID,
 		Name:            chatName,
 		LastMessageTime: evt.Info.Timestamp,
@@ -584,16 +1335,21 @@ func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Messag
 
 	// Set ephemeral expiration: use incoming message value if > 0, otherwise preserve existing
 	if ephemeralExpiration > 0 {
-		chat.EphemeralExpiration = ephemeralExpiration
+		storedChat.EphemeralExpiration = ephemeralExpiration
 	} else if existingChat != nil {
 		// Preserve existing ephemeral_expiration if incoming message doesn't have one
-		chat.EphemeralExpiration = existingChat.EphemeralExpiration
+		storedChat.EphemeralExpiration = existingChat.EphemeralExpiration
 	}
 
 	// Store or update the chat
-	if err := r.StoreChat(chat); err != nil {
+	if err := r.StoreChat(storedChat); err != nil {
 		return fmt.Errorf("failed to store chat: %w", err)
 	}
+	if evt.Info.Chat.Server == types.HiddenUserServer && chat != evt.Info.Chat {
+		if err := r.mergeLIDChat(evt.Info.Chat.String(), chatJID); err != nil {
+			return fmt.Errorf("failed to merge LID chat: %w", err)
+		}
+	}
 
 	// Extract message content and media info
 	content := utils.ExtractMessageTextFromProto(evt.Message)
@@ -610,6 +1366,7 @@ func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Messag
 		ID:            evt.Info.ID,
 		ChatJID:       chatJID,
 		Sender:        sender,
+		SenderLID:     senderJIDs.LID.String(),
 		Content:       content,
 		Timestamp:     evt.Info.Timestamp,
 		IsFromMe:      evt.Info.IsFromMe,
@@ -621,9 +1378,42 @@ func (r *SQLiteRepository) CreateMessage(ctx context.Context, evt *events.Messag
 		FileEncSHA256: fileEncSHA256,
 		FileLength:    fileLength,
 	}
+	message.Mimetype, message.DirectPath = utils.ExtractMediaDetails(evt.Message)
+	message.ForwardingScore = utils.ExtractForwardingScore(evt.Message)
+
+	// Keep poll definitions so votes on them can be decrypted into option names
+	if poll := utils.ExtractPollCreation(evt.Message); poll != nil {
+		options := make([]string, 0, len(poll.GetOptions()))
+		for _, option := range poll.GetOptions() {
+			options = append(options, option.GetOptionName())
+		}
+		if err := r.StorePoll(&domainChatStorage.Poll{
+			MessageID:       evt.Info.ID,
+			ChatJID:         chatJID,
+			Creator:         sender,
+			Question:        poll.GetName(),
+			Options:         options,
+			SelectableCount: poll.GetSelectableOptionsCount(),
+			CreatedAt:       evt.Info.Timestamp,
+		}); err != nil {
+			return fmt.Errorf("failed to store poll: %w", err)
+		}
+	}
+
+	// Redelivered messages must not be counted twice
+	var known int
+	if err := r.queryRow("SELECT COUNT(*) FROM messages WHERE id = ? AND chat_jid = ?", evt.Info.ID, chatJID).Scan(&known); err != nil {
+		return fmt.Errorf("failed to check existing message: %w", err)
+	}
 
 	// Store the message
-	return r.StoreMessage(message)
+	if err := r.StoreMessage(message); err != nil {
+		return err
+	}
+	if known > 0 {
+		return nil
+	}
+	return r.recordIncomingActivity(chatJID, evt.Info.IsFromMe, evt.Info.Timestamp)
 }
 
 // GetStorageStatistics returns current storage statistics for logging purposes
@@ -674,8 +1464,11 @@ func (r *SQLiteRepository) TruncateAllDataWithLogging(logPrefix string) error {
 	return nil
 }
 
-// StoreSentMessageWithContext stores a message that was sent by the user with context cancellation support
-func (r *SQLiteRepository) StoreSentMessageWithContext(ctx context.Context, messageID string, senderJID string, recipientJID string, content string, timestamp time.Time) error {
+// StoreSentMessageWithContext stores a message that was sent by the user with context cancellation support.
+// recipientJID is the chat to store the message under, which callers resolve to the phone number JID of a user
+// when it is known; recipientLID is the LID of that user, if any, and lets an older chat kept under the LID be
+// merged into it. The media reference of msg is kept so sent media can be forwarded later.
+func (r *SQLiteRepository) StoreSentMessageWithContext(ctx context.Context, messageID string, senderJID string, senderLID string, recipientJID string, recipientLID string, content string, timestamp time.Time, msg *waE2E.Message) error {
 	// Check if context is already cancelled before starting
 	select {
 	case <-ctx.Done():
@@ -722,6 +1515,9 @@ func (r *SQLiteRepository) StoreSentMessageWithContext(ctx context.Context, mess
 	if err := r.StoreChat(chat); err != nil {
 		return fmt.Errorf("failed to store chat: %w", err)
 	}
+	if err := r.mergeLIDChat(recipientLID, chatJID); err != nil {
+		return fmt.Errorf("failed to merge LID chat: %w", err)
+	}
 
 	// Check context one more time before storing message
 	select {
@@ -735,10 +1531,15 @@ func (r *SQLiteRepository) StoreSentMessageWithContext(ctx context.Context, mess
 		ID:        messageID,
 		ChatJID:   chatJID,
 		Sender:    senderJID,
+		SenderLID: senderLID,
 		Content:   content,
 		Timestamp: timestamp,
 		IsFromMe:  true,
 	}
+	message.MediaType, message.Filename, message.URL, message.MediaKey,
+		message.FileSHA256, message.FileEncSHA256, message.FileLength = utils.ExtractMediaInfo(msg)
+	message.Mimetype, message.DirectPath = utils.ExtractMediaDetails(msg)
+	message.ForwardingScore = utils.ExtractForwardingScore(msg)
 
 	return r.StoreMessage(message)
 }
@@ -855,6 +1656,120 @@ func (r *SQLiteRepository) getMigrations() []string {
 			`
 			CREATE INDEX IF NOT EXISTS idx_messages_id ON messages(id);
 			`,
+			`
+			CREATE TABLE IF NOT EXISTS polls (
+				message_id TEXT NOT NULL,
+				chat_jid TEXT NOT NULL,
+				creator TEXT NOT NULL,
+				question TEXT NOT NULL,
+				options TEXT NOT NULL,
+				selectable_count INTEGER DEFAULT 0,
+				created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (message_id, chat_jid)
+			);
+
+			CREATE TABLE IF NOT EXISTS poll_votes (
+				poll_message_id TEXT NOT NULL,
+				chat_jid TEXT NOT NULL,
+				voter TEXT NOT NULL,
+				selected_options TEXT NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (poll_message_id, chat_jid, voter)
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_polls_message_id ON polls(message_id);
+			`,
+			`
+			ALTER TABLE chats ADD COLUMN IF NOT EXISTS archived BOOLEAN DEFAULT FALSE;
+			ALTER TABLE chats ADD COLUMN IF NOT EXISTS mute_end_timestamp BIGINT DEFAULT 0;
+			ALTER TABLE chats ADD COLUMN IF NOT EXISTS marked_unread BOOLEAN DEFAULT FALSE;
+			`,
+			`
+			ALTER TABLE chats ADD COLUMN IF NOT EXISTS unread_count INTEGER DEFAULT 0;
+			ALTER TABLE chats ADD COLUMN IF NOT EXISTS last_read_message_id TEXT DEFAULT '';
+			ALTER TABLE chats ADD COLUMN IF NOT EXISTS last_activity TIMESTAMPTZ;
+			`,
+			`
+			CREATE TABLE IF NOT EXISTS message_pins (
+				chat_jid TEXT NOT NULL,
+				message_id TEXT NOT NULL,
+				pinned_by TEXT NOT NULL,
+				pinned_at TIMESTAMPTZ NOT NULL,
+				expires_at TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (chat_jid, message_id)
+			);
+			`,
+			`
+			CREATE TABLE IF NOT EXISTS group_moderation_rules (
+				group_jid TEXT PRIMARY KEY,
+				enabled BOOLEAN DEFAULT FALSE,
+				block_links BOOLEAN DEFAULT FALSE,
+				allowed_domains TEXT NOT NULL,
+				banned_keywords TEXT NOT NULL,
+				banned_patterns TEXT NOT NULL,
+				flood_max_messages INTEGER DEFAULT 0,
+				flood_window_seconds INTEGER DEFAULT 0,
+				blocked_media_types TEXT NOT NULL,
+				actions TEXT NOT NULL,
+				warning_message TEXT DEFAULT '',
+				max_strikes INTEGER DEFAULT 0,
+				updated_at TIMESTAMPTZ NOT NULL
+			);
+
+			CREATE TABLE IF NOT EXISTS moderation_strikes (
+				group_jid TEXT NOT NULL,
+				participant TEXT NOT NULL,
+				strikes INTEGER DEFAULT 0,
+				updated_at TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (group_jid, participant)
+			);
+
+			CREATE TABLE IF NOT EXISTS moderation_audit_log (
+				id BIGSERIAL PRIMARY KEY,
+				group_jid TEXT NOT NULL,
+				message_id TEXT NOT NULL,
+				participant TEXT NOT NULL,
+				rule TEXT NOT NULL,
+				detail TEXT DEFAULT '',
+				action TEXT NOT NULL,
+				success BOOLEAN DEFAULT FALSE,
+				error TEXT DEFAULT '',
+				created_at TIMESTAMPTZ NOT NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_moderation_audit_group ON moderation_audit_log(group_jid, created_at);
+			`,
+			`
+			CREATE TABLE IF NOT EXISTS group_join_policies (
+				group_jid TEXT PRIMARY KEY,
+				enabled BOOLEAN DEFAULT FALSE,
+				allowed_prefixes TEXT NOT NULL,
+				require_contact BOOLEAN DEFAULT FALSE,
+				hook_url TEXT DEFAULT '',
+				fail_action TEXT NOT NULL,
+				updated_at TIMESTAMPTZ NOT NULL
+			);
+			`,
+			`
+			CREATE TABLE IF NOT EXISTS whatsapp_numbers (
+				phone TEXT PRIMARY KEY,
+				is_registered BOOLEAN DEFAULT FALSE,
+				jid TEXT DEFAULT '',
+				lid TEXT DEFAULT '',
+				is_business BOOLEAN DEFAULT FALSE,
+				checked_at TIMESTAMPTZ NOT NULL
+			);
+			`,
+			`
+			ALTER TABLE messages ADD COLUMN IF NOT EXISTS sender_lid TEXT NOT NULL DEFAULT '';
+			CREATE INDEX IF NOT EXISTS idx_messages_sender_lid ON messages(sender_lid);
+			`,
+			`
+			ALTER TABLE messages ADD COLUMN IF NOT EXISTS mimetype TEXT NOT NULL DEFAULT '';
+			ALTER TABLE messages ADD COLUMN IF NOT EXISTS direct_path TEXT NOT NULL DEFAULT '';
+			ALTER TABLE messages ADD COLUMN IF NOT EXISTS forwarding_score INTEGER NOT NULL DEFAULT 0;
+			`,
+			legacySentMediaMigration,
 		}
 	}
 
@@ -899,5 +1814,136 @@ func (r *SQLiteRepository) getMigrations() []string {
 		`
 		CREATE INDEX IF NOT EXISTS idx_messages_id ON messages(id);
 		`,
+		`
+		CREATE TABLE IF NOT EXISTS polls (
+			message_id TEXT NOT NULL,
+			chat_jid TEXT NOT NULL,
+			creator TEXT NOT NULL,
+			question TEXT NOT NULL,
+			options TEXT NOT NULL,
+			selectable_count INTEGER DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (message_id, chat_jid)
+		);
+
+		CREATE TABLE IF NOT EXISTS poll_votes (
+			poll_message_id TEXT NOT NULL,
+			chat_jid TEXT NOT NULL,
+			voter TEXT NOT NULL,
+			selected_options TEXT NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (poll_message_id, chat_jid, voter)
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_polls_message_id ON polls(message_id);
+		`,
+		`
+		ALTER TABLE chats ADD COLUMN archived BOOLEAN DEFAULT FALSE;
+		ALTER TABLE chats ADD COLUMN mute_end_timestamp INTEGER DEFAULT 0;
+		ALTER TABLE chats ADD COLUMN marked_unread BOOLEAN DEFAULT FALSE;
+		`,
+		`
+		ALTER TABLE chats ADD COLUMN unread_count INTEGER DEFAULT 0;
+		ALTER TABLE chats ADD COLUMN last_read_message_id TEXT DEFAULT '';
+		ALTER TABLE chats ADD COLUMN last_activity TIMESTAMP;
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS message_pins (
+			chat_jid TEXT NOT NULL,
+			message_id TEXT NOT NULL,
+			pinned_by TEXT NOT NULL,
+			pinned_at TIMESTAMP NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (chat_jid, message_id)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS group_moderation_rules (
+			group_jid TEXT PRIMARY KEY,
+			enabled BOOLEAN DEFAULT FALSE,
+			block_links BOOLEAN DEFAULT FALSE,
+			allowed_domains TEXT NOT NULL,
+			banned_keywords TEXT NOT NULL,
+			banned_patterns TEXT NOT NULL,
+			flood_max_messages INTEGER DEFAULT 0,
+			flood_window_seconds INTEGER DEFAULT 0,
+			blocked_media_types TEXT NOT NULL,
+			actions TEXT NOT NULL,
+			warning_message TEXT DEFAULT '',
+			max_strikes INTEGER DEFAULT 0,
+			updated_at TIMESTAMP NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS moderation_strikes (
+			group_jid TEXT NOT NULL,
+			participant TEXT NOT NULL,
+			strikes INTEGER DEFAULT 0,
+			updated_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (group_jid, participant)
+		);
+
+		CREATE TABLE IF NOT EXISTS moderation_audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			group_jid TEXT NOT NULL,
+			message_id TEXT NOT NULL,
+			participant TEXT NOT NULL,
+			rule TEXT NOT NULL,
+			detail TEXT DEFAULT '',
+			action TEXT NOT NULL,
+			success BOOLEAN DEFAULT FALSE,
+			error TEXT DEFAULT '',
+			created_at TIMESTAMP NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_moderation_audit_group ON moderation_audit_log(group_jid, created_at);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS group_join_policies (
+			group_jid TEXT PRIMARY KEY,
+			enabled BOOLEAN DEFAULT FALSE,
+			allowed_prefixes TEXT NOT NULL,
+			require_contact BOOLEAN DEFAULT FALSE,
+			hook_url TEXT DEFAULT '',
+			fail_action TEXT NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS whatsapp_numbers (
+			phone TEXT PRIMARY KEY,
+			is_registered BOOLEAN DEFAULT FALSE,
+			jid TEXT DEFAULT '',
+			lid TEXT DEFAULT '',
+			is_business BOOLEAN DEFAULT FALSE,
+			checked_at TIMESTAMP NOT NULL
+		);
+		`,
+		`
+		ALTER TABLE messages ADD COLUMN sender_lid TEXT NOT NULL DEFAULT '';
+		CREATE INDEX IF NOT EXISTS idx_messages_sender_lid ON messages(sender_lid);
+		`,
+		`
+		ALTER TABLE messages ADD COLUMN mimetype TEXT NOT NULL DEFAULT '';
+		ALTER TABLE messages ADD COLUMN direct_path TEXT NOT NULL DEFAULT '';
+		ALTER TABLE messages ADD COLUMN forwarding_score INTEGER NOT NULL DEFAULT 0;
+		`,
+		legacySentMediaMigration,
 	}
 }
+
+// legacySentMediaMigration gives media sent before its reference was stored the media type its summary names.
+// Those rows only kept the summary text (e.g. "🖼️ Image"), so they read as media without a reference instead of
+// text that could be forwarded.
+const legacySentMediaMigration = `
+	UPDATE messages SET media_type = CASE
+		WHEN content LIKE '🖼️ %' THEN 'image'
+		WHEN content LIKE '🎥 %' THEN 'video'
+		WHEN content LIKE '🎵 %' OR content LIKE '🎤 %' THEN 'audio'
+		WHEN content LIKE '🎨 %' THEN 'sticker'
+		ELSE 'document'
+	END
+	WHERE is_from_me = TRUE AND COALESCE(media_type, '') = '' AND COALESCE(url, '') = '' AND (
+		content LIKE '🖼️ %' OR content LIKE '🎥 %' OR content LIKE '🎵 %' OR
+		content LIKE '🎤 %' OR content LIKE '🎨 %' OR content LIKE '📄 %'
+	);
+`
@@ -0,0 +1,164 @@
+package chatstorage
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestRepository(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "chatstorage.db")+"?_foreign_keys=on")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	repo := NewStorageRepository(db, false).(*SQLiteRepository)
+	if err := repo.InitializeSchema(); err != nil {
+		t.Fatalf("InitializeSchema() error = %v", err)
+	}
+	return repo
+}
+
+func storeTestChat(t *testing.T, repo *SQLiteRepository, jid string) {
+	t.Helper()
+	if err := repo.StoreChat(&domainChatStorage.Chat{JID: jid, Name: jid, LastMessageTime: time.Now()}); err != nil {
+		t.Fatalf("StoreChat(%s) error = %v", jid, err)
+	}
+}
+
+func TestStoreMessagesKeepSenderLID(t *testing.T) {
+	repo := newTestRepository(t)
+	chatJID := "6281234567890@s.whatsapp.net"
+	storeTestChat(t, repo, chatJID)
+
+	now := time.Now()
+	if err := repo.StoreMessage(&domainChatStorage.Message{
+		ID: "3EB0A", ChatJID: chatJID, Sender: chatJID, SenderLID: "123456789012345@lid", Content: "hi", Timestamp: now,
+	}); err != nil {
+		t.Fatalf("StoreMessage() error = %v", err)
+	}
+	if err := repo.StoreMessagesBatch([]*domainChatStorage.Message{{
+		ID: "3EB0B", ChatJID: chatJID, Sender: chatJID, SenderLID: "123456789012345@lid", Content: "again", Timestamp: now.Add(time.Second),
+	}}); err != nil {
+		t.Fatalf("StoreMessagesBatch() error = %v", err)
+	}
+
+	messages, err := repo.GetMessages(&domainChatStorage.MessageFilter{ChatJID: chatJID, Limit: 10})
+	if err != nil {
+		t.Fatalf("GetMessages() error = %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("GetMessages() returned %d messages, want 2", len(messages))
+	}
+	for _, message := range messages {
+		if message.SenderLID != "123456789012345@lid" {
+			t.Errorf("message %s sender_lid = %q, want the stored LID", message.ID, message.SenderLID)
+		}
+	}
+
+	// A later write without the LID keeps the one already stored
+	if err := repo.StoreMessage(&domainChatStorage.Message{
+		ID: "3EB0A", ChatJID: chatJID, Sender: chatJID, Content: "hi", Timestamp: now,
+	}); err != nil {
+		t.Fatalf("StoreMessage() error = %v", err)
+	}
+	if message, err := repo.GetMessageByID("3EB0A"); err != nil || message.SenderLID != "123456789012345@lid" {
+		t.Errorf("GetMessageByID() = %+v, %v; want the stored LID kept", message, err)
+	}
+}
+
+func TestMergeLIDChat(t *testing.T) {
+	repo := newTestRepository(t)
+	lidJID, pnJID := "123456789012345@lid", "6281234567890@s.whatsapp.net"
+	storeTestChat(t, repo, lidJID)
+	storeTestChat(t, repo, pnJID)
+
+	now := time.Now()
+	for _, message := range []*domainChatStorage.Message{
+		{ID: "3EB0A", ChatJID: lidJID, Sender: lidJID, Content: "only under the LID", Timestamp: now},
+		{ID: "3EB0B", ChatJID: lidJID, Sender: lidJID, Content: "stored twice", Timestamp: now},
+		{ID: "3EB0B", ChatJID: pnJID, Sender: pnJID, Content: "stored twice", Timestamp: now},
+	} {
+		if err := repo.StoreMessage(message); err != nil {
+			t.Fatalf("StoreMessage(%s) error = %v", message.ID, err)
+		}
+	}
+	archived, muteEnd, lidUnread, pnUnread := true, int64(-1), 2, 1
+	if err := repo.UpdateChatState(lidJID, domainChatStorage.ChatStateUpdate{
+		Archived: &archived, MuteEndTimestamp: &muteEnd, UnreadCount: &lidUnread,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateChatState(pnJID, domainChatStorage.ChatStateUpdate{UnreadCount: &pnUnread}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.exec("UPDATE chats SET ephemeral_expiration = 86400 WHERE jid = ?", lidJID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.mergeLIDChat(lidJID, pnJID); err != nil {
+		t.Fatalf("mergeLIDChat() error = %v", err)
+	}
+
+	if chat, err := repo.GetChat(lidJID); err != nil || chat != nil {
+		t.Errorf("GetChat(lid) = %+v, %v; want the LID chat removed", chat, err)
+	}
+	chat, err := repo.GetChat(pnJID)
+	if err != nil || chat == nil {
+		t.Fatalf("GetChat(pn) = %+v, %v", chat, err)
+	}
+	if !chat.Archived || chat.MuteEndTimestamp != -1 || chat.UnreadCount != 3 || chat.EphemeralExpiration != 86400 {
+		t.Errorf("merged chat = %+v, want the archive, mute, unread and timer state of the LID chat", chat)
+	}
+
+	messages, err := repo.GetMessages(&domainChatStorage.MessageFilter{ChatJID: pnJID, Limit: 10})
+	if err != nil {
+		t.Fatalf("GetMessages() error = %v", err)
+	}
+	if len(messages) != 2 {
+		t.Errorf("GetMessages() returned %d messages, want the LID message moved next to the existing one", len(messages))
+	}
+
+	// Nothing is left to merge the second time
+	if err := repo.mergeLIDChat(lidJID, pnJID); err != nil {
+		t.Errorf("mergeLIDChat() second run error = %v", err)
+	}
+}
+
+func TestLegacySentMediaMigration(t *testing.T) {
+	repo := newTestRepository(t)
+	chatJID := "6281234567890@s.whatsapp.net"
+	storeTestChat(t, repo, chatJID)
+
+	now := time.Now()
+	for _, message := range []*domainChatStorage.Message{
+		{ID: "3EB0A", ChatJID: chatJID, Sender: chatJID, Content: "🖼️ Image", IsFromMe: true, Timestamp: now},
+		{ID: "3EB0B", ChatJID: chatJID, Sender: chatJID, Content: "🎤 Voice note", IsFromMe: true, Timestamp: now},
+		{ID: "3EB0C", ChatJID: chatJID, Sender: chatJID, Content: "📄 Invoice ready", Timestamp: now},
+		{ID: "3EB0D", ChatJID: chatJID, Sender: chatJID, Content: "hello", IsFromMe: true, Timestamp: now},
+	} {
+		if err := repo.StoreMessage(message); err != nil {
+			t.Fatalf("StoreMessage(%s) error = %v", message.ID, err)
+		}
+	}
+
+	if _, err := repo.exec(legacySentMediaMigration); err != nil {
+		t.Fatalf("migration error = %v", err)
+	}
+
+	for id, want := range map[string]string{"3EB0A": "image", "3EB0B": "audio", "3EB0C": "", "3EB0D": ""} {
+		message, err := repo.GetMessageByID(id)
+		if err != nil || message == nil {
+			t.Fatalf("GetMessageByID(%s) = %+v, %v", id, message, err)
+		}
+		if message.MediaType != want {
+			t.Errorf("message %s media_type = %q, want %q", id, message.MediaType, want)
+		}
+	}
+}
@@ -107,6 +107,31 @@ func Migrate(db *sql.DB, driverName string) error {
 		}
 	}
 
+	// Links an agent to its device in the shared device store (DB_AGENTS_URI)
+	if err := addColumnIfMissing(db, driverName, "whatsapp_user", "device_jid", "VARCHAR(255)"); err != nil {
+		return err
+	}
+
 	logrus.Info("Database migrations completed successfully.")
 	return nil
 }
+
+// addColumnIfMissing adds a column to a table created by an earlier version. SQLite has no ADD COLUMN IF NOT EXISTS,
+// so the column list is checked first there.
+func addColumnIfMissing(db *sql.DB, driverName, table, column, definition string) error {
+	query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s", table, column, definition)
+	if driverName != "postgres" {
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&count); err != nil {
+			return fmt.Errorf("failed to inspect table %s: %w", table, err)
+		}
+		if count > 0 {
+			return nil
+		}
+		query = fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)
+	}
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to execute migration query: %s, error: %w", query, err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/domains/session"
@@ -127,3 +128,24 @@ func (r *SessionRepository) List() ([]*session.WhatsappUser, error) {
 	}
 	return result, nil
 }
+
+// FindDeviceJID returns the JID of the device an agent is linked to in the shared device store, or an empty string
+// when the agent has not linked a device yet.
+func (r *SessionRepository) FindDeviceJID(agentID string) (string, error) {
+	var deviceJID sql.NullString
+	err := r.db.QueryRow(`SELECT device_jid FROM whatsapp_user WHERE agent_id = $1 LIMIT 1`, agentID).Scan(&deviceJID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return deviceJID.String, nil
+}
+
+// SetDeviceJID links an agent to a device in the shared device store. An empty deviceJID removes the link.
+func (r *SessionRepository) SetDeviceJID(agentID, deviceJID string) error {
+	value := sql.NullString{String: deviceJID, Valid: deviceJID != ""}
+	_, err := r.db.Exec(`UPDATE whatsapp_user SET device_jid = $1, updated_at = $2 WHERE agent_id = $3`, value, time.Now(), agentID)
+	return err
+}
@@ -0,0 +1,142 @@
+package whatsapp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// blocklists caches the blocked users of each client, so sends can be checked without a round trip to WhatsApp.
+// A client without an entry has not loaded its blocklist yet.
+var blocklists = struct {
+	sync.RWMutex
+	byClient map[*whatsmeow.Client]map[types.JID]struct{}
+}{byClient: make(map[*whatsmeow.Client]map[types.JID]struct{})}
+
+// StoreBlocklist replaces the cached blocklist of a client with a list fetched from or returned by WhatsApp
+func StoreBlocklist(client *whatsmeow.Client, blocklist *types.Blocklist) {
+	if client == nil || blocklist == nil {
+		return
+	}
+	jids := make(map[types.JID]struct{}, len(blocklist.JIDs))
+	for _, jid := range blocklist.JIDs {
+		jids[jid.ToNonAD()] = struct{}{}
+	}
+
+	blocklists.Lock()
+	blocklists.byClient[client] = jids
+	blocklists.Unlock()
+}
+
+// forgetBlocklist drops the cached blocklist of a client, so the next check loads it again
+func forgetBlocklist(client *whatsmeow.Client) {
+	blocklists.Lock()
+	delete(blocklists.byClient, client)
+	blocklists.Unlock()
+}
+
+// IsBlocked reports whether we blocked the given user. WhatsApp may list a user by phone number or by LID, so the
+// counterpart of the JID is checked as well.
+func IsBlocked(ctx context.Context, client *whatsmeow.Client, jid types.JID) (bool, error) {
+	blocklists.RLock()
+	jids, loaded := blocklists.byClient[client]
+	blocklists.RUnlock()
+
+	if !loaded {
+		blocklist, err := client.GetBlocklist(ctx)
+		if err != nil {
+			return false, err
+		}
+		StoreBlocklist(client, blocklist)
+		blocklists.RLock()
+		jids = blocklists.byClient[client]
+		blocklists.RUnlock()
+	}
+
+	jid = jid.ToNonAD()
+	if _, blocked := jids[jid]; blocked {
+		return true, nil
+	}
+
+	// The blocklist may hold the other form of the same user
+	pair := ResolveJID(ctx, client, jid)
+	counterpart := pair.LID
+	if jid == pair.LID {
+		counterpart = pair.PN
+	}
+	if counterpart.IsEmpty() {
+		return false, nil
+	}
+	_, blocked := jids[counterpart]
+	return blocked, nil
+}
+
+// applyBlocklistChanges updates a loaded blocklist with the changes of an event. It returns false when the
+// cache could not be updated in place and has to be loaded again.
+func applyBlocklistChanges(jids map[types.JID]struct{}, evt *events.Blocklist) bool {
+	// Without the modify action the event only says the list changed somewhere, not how
+	if evt.Action != events.BlocklistActionModify {
+		return false
+	}
+	for _, change := range evt.Changes {
+		switch change.Action {
+		case events.BlocklistChangeActionBlock:
+			jids[change.JID.ToNonAD()] = struct{}{}
+		case events.BlocklistChangeActionUnblock:
+			delete(jids, change.JID.ToNonAD())
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// handleBlocklist keeps the cached blocklist in step with blocks and unblocks made from any of our devices and
+// forwards them to webhooks
+func handleBlocklist(ctx context.Context, agentID string, evt *events.Blocklist, client *whatsmeow.Client) {
+	log.Infof("Blocklist changed (action: %q, %d changes)", evt.Action, len(evt.Changes))
+
+	blocklists.Lock()
+	jids, loaded := blocklists.byClient[client]
+	updated := loaded && applyBlocklistChanges(jids, evt)
+	blocklists.Unlock()
+	if loaded && !updated {
+		forgetBlocklist(client)
+	}
+
+	payload := createBlocklistPayload(evt, time.Now())
+	go func() {
+		if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "blocklist event", agentID); err != nil {
+			log.Errorf("Failed to forward blocklist change to webhook: %v", err)
+		}
+	}()
+}
+
+// createBlocklistPayload creates a webhook payload for blocklist changes
+func createBlocklistPayload(evt *events.Blocklist, at time.Time) map[string]any {
+	changes := make([]map[string]any, 0, len(evt.Changes))
+	for _, change := range evt.Changes {
+		changes = append(changes, map[string]any{
+			"jid":    change.JID.ToNonAD().String(),
+			"action": string(change.Action),
+		})
+	}
+
+	action := string(evt.Action)
+	if action == "" {
+		action = "refresh"
+	}
+
+	return map[string]any{
+		"event":     "user.blocklist",
+		"timestamp": at.Format(time.RFC3339),
+		"payload": map[string]any{
+			"action":  action,
+			"changes": changes,
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestApplyBlocklistChanges(t *testing.T) {
+	alice := types.NewJID("6281234567890", types.DefaultUserServer)
+	bob := types.NewJID("447700900123", types.DefaultUserServer)
+
+	jids := map[types.JID]struct{}{alice: {}}
+	ok := applyBlocklistChanges(jids, &events.Blocklist{
+		Action: events.BlocklistActionModify,
+		Changes: []events.BlocklistChange{
+			{JID: alice, Action: events.BlocklistChangeActionUnblock},
+			{JID: bob, Action: events.BlocklistChangeActionBlock},
+		},
+	})
+	if !ok {
+		t.Fatal("applyBlocklistChanges() = false for a modify event")
+	}
+	if _, blocked := jids[alice]; blocked {
+		t.Error("unblocked user is still in the blocklist")
+	}
+	if _, blocked := jids[bob]; !blocked {
+		t.Error("blocked user is missing from the blocklist")
+	}
+
+	if applyBlocklistChanges(jids, &events.Blocklist{}) {
+		t.Error("applyBlocklistChanges() = true for an event without changes to apply")
+	}
+}
+
+func TestIsBlockedUsesCachedBlocklist(t *testing.T) {
+	client := &whatsmeow.Client{}
+	blocked := types.NewJID("6281234567890", types.DefaultUserServer)
+	StoreBlocklist(client, &types.Blocklist{JIDs: []types.JID{blocked}})
+	defer forgetBlocklist(client)
+
+	ctx := context.Background()
+	if ok, err := IsBlocked(ctx, client, types.NewADJID(blocked.User, 0, 3)); err != nil || !ok {
+		t.Errorf("IsBlocked() of a device of a blocked user = (%v, %v), want true", ok, err)
+	}
+	if ok, err := IsBlocked(ctx, client, types.NewJID("447700900123", types.DefaultUserServer)); err != nil || ok {
+		t.Errorf("IsBlocked() of another user = (%v, %v), want false", ok, err)
+	}
+}
+
+func TestDeleteClientForgetsBlocklist(t *testing.T) {
+	defer func(path string) { config.PathStorages = path }(config.PathStorages)
+	config.PathStorages = t.TempDir()
+	cm := NewClientManager(nil)
+
+	client := whatsmeow.NewClient(nil, nil)
+	cm.clients["agent-1"] = client
+	StoreBlocklist(client, &types.Blocklist{})
+
+	if err := cm.DeleteClient("agent-1"); err != nil {
+		t.Fatalf("DeleteClient() error = %v", err)
+	}
+	blocklists.RLock()
+	_, cached := blocklists.byClient[client]
+	blocklists.RUnlock()
+	if cached {
+		t.Error("the blocklist of a deleted client is still cached")
+	}
+}
+
+func TestCreateBlocklistPayload(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	payload := createBlocklistPayload(&events.Blocklist{
+		Action: events.BlocklistActionModify,
+		Changes: []events.BlocklistChange{
+			{JID: types.NewJID("6281234567890", types.DefaultUserServer), Action: events.BlocklistChangeActionBlock},
+		},
+	}, at)
+
+	if payload["event"] != "user.blocklist" || payload["timestamp"] != "2025-01-02T03:04:05Z" {
+		t.Fatalf("unexpected envelope: %v", payload)
+	}
+	body := payload["payload"].(map[string]any)
+	if body["action"] != "modify" {
+		t.Errorf("action = %v, want modify", body["action"])
+	}
+	changes := body["changes"].([]map[string]any)
+	if len(changes) != 1 || changes[0]["jid"] != "6281234567890@s.whatsapp.net" || changes[0]["action"] != "block" {
+		t.Errorf("changes = %v", changes)
+	}
+
+	refresh := createBlocklistPayload(&events.Blocklist{}, at)["payload"].(map[string]any)
+	if refresh["action"] != "refresh" {
+		t.Errorf("action of an event without changes = %v, want refresh", refresh["action"])
+	}
+}
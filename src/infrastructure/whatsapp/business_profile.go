@@ -0,0 +1,92 @@
+package whatsapp
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow"
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// BusinessProfileUpdate holds the business profile fields to change; nil fields are left as they are and an empty
+// Websites removes all websites. Business hours times are in the form WhatsApp stores them, which
+// FormatBusinessHourTime renders.
+type BusinessProfileUpdate struct {
+	Description           *string
+	Address               *string
+	Email                 *string
+	Websites              []string
+	CategoryIDs           []string
+	BusinessHoursTimeZone string
+	BusinessHours         []types.BusinessHoursConfig
+}
+
+// UpdateBusinessProfile changes the business profile of our own account. whatsmeow can read business profiles
+// but not edit them, so this sends the delta mutation WhatsApp Business clients use.
+func UpdateBusinessProfile(ctx context.Context, client *whatsmeow.Client, update BusinessProfileUpdate) error {
+	_, err := client.DangerousInternals().SendIQ(ctx, whatsmeow.DangerousInfoQuery{
+		Namespace: "w:biz",
+		Type:      "set",
+		To:        types.ServerJID,
+		Content:   []waBinary.Node{buildBusinessProfileNode(update)},
+	})
+	return err
+}
+
+func buildBusinessProfileNode(update BusinessProfileUpdate) waBinary.Node {
+	var content []waBinary.Node
+	for _, field := range []struct {
+		tag   string
+		value *string
+	}{
+		{"description", update.Description},
+		{"address", update.Address},
+		{"email", update.Email},
+	} {
+		if field.value != nil {
+			content = append(content, waBinary.Node{Tag: field.tag, Content: []byte(*field.value)})
+		}
+	}
+
+	for _, website := range update.Websites {
+		content = append(content, waBinary.Node{Tag: "website", Content: []byte(website)})
+	}
+	// A delta without website nodes keeps the current websites; an empty one removes them
+	if update.Websites != nil && len(update.Websites) == 0 {
+		content = append(content, waBinary.Node{Tag: "website", Content: []byte{}})
+	}
+
+	if update.CategoryIDs != nil {
+		categories := make([]waBinary.Node, 0, len(update.CategoryIDs))
+		for _, id := range update.CategoryIDs {
+			categories = append(categories, waBinary.Node{Tag: "category", Attrs: waBinary.Attrs{"id": id}})
+		}
+		content = append(content, waBinary.Node{Tag: "categories", Content: categories})
+	}
+
+	if update.BusinessHours != nil {
+		configs := make([]waBinary.Node, 0, len(update.BusinessHours))
+		for _, hours := range update.BusinessHours {
+			attrs := waBinary.Attrs{"day_of_week": hours.DayOfWeek, "mode": hours.Mode}
+			// Only days with specific hours carry times; open all day and appointment only do not
+			if hours.OpenTime != "" {
+				attrs["open_time"] = hours.OpenTime
+			}
+			if hours.CloseTime != "" {
+				attrs["close_time"] = hours.CloseTime
+			}
+			configs = append(configs, waBinary.Node{Tag: "business_hours_config", Attrs: attrs})
+		}
+		content = append(content, waBinary.Node{
+			Tag:     "business_hours",
+			Attrs:   waBinary.Attrs{"timezone": update.BusinessHoursTimeZone},
+			Content: configs,
+		})
+	}
+
+	return waBinary.Node{
+		Tag:     "business_profile",
+		Attrs:   waBinary.Attrs{"v": "3", "mutation_type": "delta"},
+		Content: content,
+	}
+}
@@ -0,0 +1,74 @@
+package whatsapp
+
+import (
+	"testing"
+
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestBuildBusinessProfileNode(t *testing.T) {
+	address := "Jl. Sudirman 1, Jakarta"
+	node := buildBusinessProfileNode(BusinessProfileUpdate{
+		Address:               &address,
+		Websites:              []string{"https://example.com"},
+		CategoryIDs:           []string{"133436743388217"},
+		BusinessHoursTimeZone: "Asia/Jakarta",
+		BusinessHours: []types.BusinessHoursConfig{
+			{DayOfWeek: "mon", Mode: "specific_hours", OpenTime: "900", CloseTime: "1700"},
+			{DayOfWeek: "sun", Mode: "open_24h"},
+		},
+	})
+
+	if node.Tag != "business_profile" || node.Attrs["mutation_type"] != "delta" {
+		t.Fatalf("unexpected root node: %v", node)
+	}
+	children := node.GetChildren()
+	tags := make([]string, 0, len(children))
+	for _, child := range children {
+		tags = append(tags, child.Tag)
+	}
+	if len(tags) != 4 || tags[0] != "address" || tags[1] != "website" || tags[2] != "categories" || tags[3] != "business_hours" {
+		t.Fatalf("children = %v, want address, website, categories and business_hours", tags)
+	}
+	if got, _ := children[0].Content.([]byte); string(got) != address {
+		t.Errorf("address = %q, want %q", got, address)
+	}
+	if id := children[2].GetChildren()[0].Attrs["id"]; id != "133436743388217" {
+		t.Errorf("category id = %v", id)
+	}
+
+	hours := children[3]
+	if hours.Attrs["timezone"] != "Asia/Jakarta" {
+		t.Errorf("timezone = %v", hours.Attrs["timezone"])
+	}
+	configs := hours.GetChildren()
+	if len(configs) != 2 {
+		t.Fatalf("got %d business hours configs, want 2", len(configs))
+	}
+	want := waBinary.Attrs{"day_of_week": "mon", "mode": "specific_hours", "open_time": "900", "close_time": "1700"}
+	for key, value := range want {
+		if configs[0].Attrs[key] != value {
+			t.Errorf("%s = %v, want %v", key, configs[0].Attrs[key], value)
+		}
+	}
+	if _, ok := configs[1].Attrs["open_time"]; ok {
+		t.Error("open_24h day should not carry an open time")
+	}
+}
+
+func TestBuildBusinessProfileNodeClearsWebsites(t *testing.T) {
+	node := buildBusinessProfileNode(BusinessProfileUpdate{Websites: []string{}})
+	children := node.GetChildren()
+	if len(children) != 1 || children[0].Tag != "website" {
+		t.Fatalf("children = %v, want a single website node", children)
+	}
+	if got, _ := children[0].Content.([]byte); len(got) != 0 {
+		t.Errorf("website = %q, want it empty", got)
+	}
+
+	node = buildBusinessProfileNode(BusinessProfileUpdate{})
+	if children := node.GetChildren(); len(children) != 0 {
+		t.Errorf("children = %v, want websites left alone when not given", children)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 	waLog "go.mau.fi/whatsmeow/util/log"
 )
@@ -24,7 +25,18 @@ type ClientManager struct {
 	mu          sync.RWMutex
 	chatStorage domainChatStorage.IChatStorageRepository
 
-	qrCache map[string]cachedQR
+	qrCache       map[string]cachedQR
+	pairCodeCache map[string]cachedPairCode
+
+	// Shared device store for all agents, nil when every agent keeps a dedicated SQLite file
+	deviceStore   *sqlstore.Container
+	deviceMapping DeviceMapping
+}
+
+// DeviceMapping records which device of the shared device store belongs to which agent
+type DeviceMapping interface {
+	FindDeviceJID(agentID string) (string, error)
+	SetDeviceJID(agentID, deviceJID string) error
 }
 
 type cachedQR struct {
@@ -33,15 +45,30 @@ type cachedQR struct {
 	updatedAt   int64 // unix seconds
 }
 
+type cachedPairCode struct {
+	code      string
+	updatedAt int64 // unix seconds
+}
+
 func NewClientManager(chatStorage domainChatStorage.IChatStorageRepository) *ClientManager {
 	return &ClientManager{
-		clients:     make(map[string]*whatsmeow.Client),
-		dbs:         make(map[string]*sqlstore.Container),
-		chatStorage: chatStorage,
-		qrCache:     make(map[string]cachedQR),
+		clients:       make(map[string]*whatsmeow.Client),
+		dbs:           make(map[string]*sqlstore.Container),
+		chatStorage:   chatStorage,
+		qrCache:       make(map[string]cachedQR),
+		pairCodeCache: make(map[string]cachedPairCode),
 	}
 }
 
+// UseSharedDeviceStore keeps the devices of all agents in one store instead of a SQLite file per agent, so sessions
+// survive moving to another container. The mapping decides which device an agent loads.
+func (cm *ClientManager) UseSharedDeviceStore(container *sqlstore.Container, mapping DeviceMapping) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.deviceStore = container
+	cm.deviceMapping = mapping
+}
+
 func (cm *ClientManager) GetClient(agentID string) *whatsmeow.Client {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
@@ -56,38 +83,16 @@ func (cm *ClientManager) CreateClient(ctx context.Context, agentID string) (*wha
 		return client, nil
 	}
 
-	// Use separate DB for each agent to ensure isolation.
-	// IMPORTANT: Even if main DB is Postgres, sharing the same store across multiple
-	// agents will cause "stream replaced" conflicts. To avoid that, we keep a dedicated
-	// SQLite store per agent.
-	dbPath := fmt.Sprintf("file:%s/whatsapp-%s.db?_foreign_keys=on&_journal_mode=WAL", config.PathStorages, agentID)
-
-	dbLog := waLog.Stdout("Database", config.WhatsappLogLevel, true)
-	storeContainer, err := sqlstore.New(ctx, "sqlite3", dbPath, dbLog)
-	if err != nil {
-		return nil, err
+	var device *store.Device
+	var err error
+	if cm.deviceStore != nil {
+		device, err = cm.sharedDevice(ctx, agentID)
+	} else {
+		device, err = cm.agentStoreDevice(ctx, agentID)
 	}
-
-	cm.dbs[agentID] = storeContainer
-
-	// Get or Create Device
-	// If SQLite (separate DB), GetFirstDevice is fine because there's only one.
-	// If Postgres (shared DB), we have a problem: GetFirstDevice returns *any* device.
-	// We need a way to link AgentID <-> DeviceID (JID).
-
-	// TEMPORARY SOLUTION:
-	// For this implementation, I will assume SQLite (Separate Files) is the primary mode for Multi-Agent
-	// because it maps 1:1 with the "Session File" concept.
-	// If Postgres is used, this code might need a mapping table "AgentDevice" or add "device_jid" to "whatsapp_user".
-
-	device, err := storeContainer.GetFirstDevice(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if device == nil {
-		// Create new device
-		device = storeContainer.NewDevice()
-	}
 
 	// Configure device properties
 	osName := fmt.Sprintf("%s %s", config.AppOs, config.AppVersion)
@@ -111,12 +116,22 @@ func (cm *ClientManager) CreateClient(ctx context.Context, agentID string) (*wha
 			}
 
 			// Reset the client + per-agent DB so the next QR attempt starts clean
-			if err := cm.DeleteClient(agentID); err != nil {
+			if err := cm.DestroyClient(agentID); err != nil {
 				logrus.Warnf("Failed to reset client after pair error for agent %s: %v", agentID, err)
 			}
+			if statusUpdater != nil {
+				statusUpdater(agentID, "pair_failed")
+			}
 			return
 		}
 
+		// Link the agent to its new device so the next start loads it from the shared store
+		if pairSuccess, ok := rawEvt.(*events.PairSuccess); ok && cm.deviceMapping != nil {
+			if err := cm.deviceMapping.SetDeviceJID(agentID, pairSuccess.ID.String()); err != nil {
+				logrus.Errorf("Failed to record device %s for agent %s: %v", pairSuccess.ID, agentID, err)
+			}
+		}
+
 		// include agentID to avoid cross-agent conflicts and enable per-agent hooks
 		handler(ctx, rawEvt, cm.chatStorage, agentID, client)
 	})
@@ -125,33 +140,132 @@ func (cm *ClientManager) CreateClient(ctx context.Context, agentID string) (*wha
 	return client, nil
 }
 
+// agentStoreDevice loads the device from the dedicated SQLite store of an agent. The store only ever holds the
+// device of that agent, so its first device is the one to use.
+func (cm *ClientManager) agentStoreDevice(ctx context.Context, agentID string) (*store.Device, error) {
+	dbLog := waLog.Stdout("Database", config.WhatsappLogLevel, true)
+	storeContainer, err := sqlstore.New(ctx, "sqlite3", agentStoreURI(agentID), dbLog)
+	if err != nil {
+		return nil, err
+	}
+	cm.dbs[agentID] = storeContainer
+
+	device, err := storeContainer.GetFirstDevice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if device == nil {
+		device = storeContainer.NewDevice()
+	}
+	return device, nil
+}
+
+// sharedDevice loads the device linked to an agent from the shared device store, or starts a new one when the
+// agent has not linked a device yet or the device was removed after a logout
+func (cm *ClientManager) sharedDevice(ctx context.Context, agentID string) (*store.Device, error) {
+	deviceJID, err := cm.deviceMapping.FindDeviceJID(agentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find device of agent %s: %w", agentID, err)
+	}
+	if deviceJID != "" {
+		jid, err := types.ParseJID(deviceJID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid device JID %q for agent %s: %w", deviceJID, agentID, err)
+		}
+		device, err := cm.deviceStore.GetDevice(ctx, jid)
+		if err != nil {
+			return nil, err
+		}
+		if device != nil {
+			return device, nil
+		}
+	}
+	return cm.deviceStore.NewDevice(), nil
+}
+
+// deleteSharedDevice removes the device of an agent from the shared device store and unlinks it
+func (cm *ClientManager) deleteSharedDevice(agentID string, client *whatsmeow.Client) error {
+	ctx := context.Background()
+	var device *store.Device
+	if client != nil {
+		device = client.Store
+	} else if deviceJID, err := cm.deviceMapping.FindDeviceJID(agentID); err != nil {
+		return err
+	} else if jid, err := types.ParseJID(deviceJID); err == nil && deviceJID != "" {
+		if device, err = cm.deviceStore.GetDevice(ctx, jid); err != nil {
+			return err
+		}
+	}
+
+	if device != nil && device.ID != nil {
+		if err := device.Delete(ctx); err != nil {
+			return fmt.Errorf("failed to delete device of agent %s: %w", agentID, err)
+		}
+	}
+	return cm.deviceMapping.SetDeviceJID(agentID, "")
+}
+
+// agentStoreURI is the dedicated SQLite store of an agent when no shared device store is configured
+func agentStoreURI(agentID string) string {
+	return fmt.Sprintf("file:%s?_foreign_keys=on&_journal_mode=WAL", agentStorePath(agentID))
+}
+
+func agentStorePath(agentID string) string {
+	return filepath.Join(config.PathStorages, fmt.Sprintf("whatsapp-%s.db", agentID))
+}
+
+// DeleteClient disconnects an agent and forgets its client. The device is kept, so the next CreateClient logs the
+// agent in again without a new QR code.
 func (cm *ClientManager) DeleteClient(agentID string) error {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	if client, ok := cm.clients[agentID]; ok {
-		client.Disconnect()
-		delete(cm.clients, agentID)
-	}
+	cm.dropClient(agentID)
+	return nil
+}
 
-	if db, ok := cm.dbs[agentID]; ok {
-		db.Close()
-		delete(cm.dbs, agentID)
+// DestroyClient disconnects an agent and removes its device as well, so the next login starts from a new QR code
+func (cm *ClientManager) DestroyClient(agentID string) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	client := cm.dropClient(agentID)
+	if cm.deviceStore != nil {
+		return cm.deleteSharedDevice(agentID, client)
 	}
 
 	// Remove DB file if SQLite
 	if !isPostgres(config.DBURI) {
-		dbFile := filepath.Join(config.PathStorages, fmt.Sprintf("whatsapp-%s.db", agentID))
+		dbFile := agentStorePath(agentID)
 		_ = os.Remove(dbFile)
 		_ = os.Remove(dbFile + "-shm")
 		_ = os.Remove(dbFile + "-wal")
 	}
 
-	delete(cm.qrCache, agentID)
-
 	return nil
 }
 
+// dropClient disconnects the client of an agent and removes it, its store and its cached login codes from the
+// manager. It returns the dropped client, if there was one. The caller holds the lock.
+func (cm *ClientManager) dropClient(agentID string) *whatsmeow.Client {
+	client, ok := cm.clients[agentID]
+	if ok {
+		client.Disconnect()
+		delete(cm.clients, agentID)
+		// The blocklist cache is keyed by client, so a dropped client would otherwise stay referenced
+		forgetBlocklist(client)
+	}
+
+	if db, ok := cm.dbs[agentID]; ok {
+		db.Close()
+		delete(cm.dbs, agentID)
+	}
+
+	delete(cm.qrCache, agentID)
+	delete(cm.pairCodeCache, agentID)
+	return client
+}
+
 // CacheQR stores the latest QR code for an agent.
 func (cm *ClientManager) CacheQR(agentID string, contentType, base64data string) {
 	cm.mu.Lock()
@@ -170,6 +284,24 @@ func (cm *ClientManager) GetCachedQR(agentID string) (contentType, base64data st
 	return qr.contentType, qr.base64, true, time.Unix(qr.updatedAt, 0)
 }
 
+// CachePairCode stores the latest pairing code issued for an agent.
+func (cm *ClientManager) CachePairCode(agentID, code string) {
+	cm.mu.Lock()
+	cm.pairCodeCache[agentID] = cachedPairCode{code: code, updatedAt: time.Now().Unix()}
+	cm.mu.Unlock()
+}
+
+// GetCachedPairCode returns the cached pairing code if present.
+func (cm *ClientManager) GetCachedPairCode(agentID string) (code string, ok bool, updatedAt time.Time) {
+	cm.mu.RLock()
+	pairCode, exists := cm.pairCodeCache[agentID]
+	cm.mu.RUnlock()
+	if !exists {
+		return "", false, time.Time{}
+	}
+	return pairCode.code, true, time.Unix(pairCode.updatedAt, 0)
+}
+
 func isPostgres(uri string) bool {
 	return len(uri) > 8 && (uri[:9] == "postgres:" || uri[:11] == "postgresql:")
 }
@@ -0,0 +1,103 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"go.mau.fi/whatsmeow/proto/waAdv"
+	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestClientManagerPairCodeCache(t *testing.T) {
+	defer func(path string) { config.PathStorages = path }(config.PathStorages)
+	config.PathStorages = t.TempDir()
+	cm := NewClientManager(nil)
+
+	if _, ok, _ := cm.GetCachedPairCode("agent-1"); ok {
+		t.Fatal("GetCachedPairCode() found a code before one was issued")
+	}
+
+	cm.CachePairCode("agent-1", "ABCD1234")
+	if code, ok, issuedAt := cm.GetCachedPairCode("agent-1"); !ok || code != "ABCD1234" || issuedAt.IsZero() {
+		t.Errorf("GetCachedPairCode() = %q, %v, %v; want the issued code", code, ok, issuedAt)
+	}
+	if _, ok, _ := cm.GetCachedPairCode("agent-2"); ok {
+		t.Error("GetCachedPairCode() returned the code of another agent")
+	}
+
+	// Deleting the client starts the next login from scratch
+	if err := cm.DeleteClient("agent-1"); err != nil {
+		t.Fatalf("DeleteClient() error = %v", err)
+	}
+	if _, ok, _ := cm.GetCachedPairCode("agent-1"); ok {
+		t.Error("GetCachedPairCode() kept the code after the client was deleted")
+	}
+}
+
+type fakeDeviceMapping map[string]string
+
+func (m fakeDeviceMapping) FindDeviceJID(agentID string) (string, error) { return m[agentID], nil }
+
+func (m fakeDeviceMapping) SetDeviceJID(agentID, deviceJID string) error {
+	m[agentID] = deviceJID
+	return nil
+}
+
+func TestClientManagerSharedDeviceStore(t *testing.T) {
+	ctx := context.Background()
+	container := sqlstore.NewWithDB(openTestStore(t, "shared.db"), "sqlite3", nil)
+	if err := container.Upgrade(ctx); err != nil {
+		t.Fatal(err)
+	}
+	deviceJID := types.NewADJID("6281234567890", 0, 12)
+	device := container.NewDevice()
+	device.ID = &deviceJID
+	device.Account = &waAdv.ADVSignedDeviceIdentity{
+		Details:             []byte{1},
+		AccountSignatureKey: make([]byte, 32),
+		AccountSignature:    make([]byte, 64),
+		DeviceSignature:     make([]byte, 64),
+	}
+	if err := device.Save(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mapping := fakeDeviceMapping{"agent-1": deviceJID.String()}
+	cm := NewClientManager(nil)
+	cm.UseSharedDeviceStore(container, mapping)
+
+	client, err := cm.CreateClient(ctx, "agent-1")
+	if err != nil {
+		t.Fatalf("CreateClient() error = %v", err)
+	}
+	if client.Store.ID == nil || *client.Store.ID != deviceJID {
+		t.Errorf("CreateClient() loaded device %v; want the mapped device %v", client.Store.ID, deviceJID)
+	}
+	if client, err := cm.CreateClient(ctx, "agent-2"); err != nil || client.Store.ID != nil {
+		t.Errorf("CreateClient() for an unmapped agent = %v, %v; want a new device", client.Store.ID, err)
+	}
+
+	// Deleting the client keeps the device, so the agent logs in with it again
+	if err := cm.DeleteClient("agent-1"); err != nil {
+		t.Fatalf("DeleteClient() error = %v", err)
+	}
+	if mapping["agent-1"] != deviceJID.String() {
+		t.Errorf("DeleteClient() changed the device mapping to %q", mapping["agent-1"])
+	}
+	if client, err := cm.CreateClient(ctx, "agent-1"); err != nil || client.Store.ID == nil || *client.Store.ID != deviceJID {
+		t.Fatalf("CreateClient() after DeleteClient() = %v, %v; want the mapped device", client, err)
+	}
+
+	// Destroying the client removes the device and the link, like removing the SQLite file of the agent
+	if err := cm.DestroyClient("agent-1"); err != nil {
+		t.Fatalf("DestroyClient() error = %v", err)
+	}
+	if mapping["agent-1"] != "" {
+		t.Errorf("DestroyClient() kept the device mapping %q", mapping["agent-1"])
+	}
+	if device, err := container.GetDevice(ctx, deviceJID); err != nil || device != nil {
+		t.Errorf("GetDevice() after DestroyClient() = %v, %v; want no device", device, err)
+	}
+}
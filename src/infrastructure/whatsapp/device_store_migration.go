@@ -0,0 +1,130 @@
+package whatsapp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// deviceTables lists the whatsmeow tables holding per-device state with the column naming the device, in an order
+// that satisfies their foreign keys
+var deviceTables = []struct {
+	name      string
+	deviceCol string
+}{
+	{"whatsmeow_identity_keys", "our_jid"},
+	{"whatsmeow_pre_keys", "jid"},
+	{"whatsmeow_sessions", "our_jid"},
+	{"whatsmeow_sender_keys", "our_jid"},
+	{"whatsmeow_app_state_sync_keys", "jid"},
+	{"whatsmeow_app_state_version", "jid"},
+	{"whatsmeow_app_state_mutation_macs", "jid"},
+	{"whatsmeow_contacts", "our_jid"},
+	{"whatsmeow_chat_settings", "our_jid"},
+	{"whatsmeow_message_secrets", "our_jid"},
+	{"whatsmeow_privacy_tokens", "our_jid"},
+	{"whatsmeow_event_buffer", "our_jid"},
+}
+
+// MigrateAgentStore copies the dedicated SQLite store of an agent into the shared device store and returns the JID of
+// the copied device, to be recorded as the device of the agent. The JID is empty when the agent never linked a
+// device. The SQLite file is left untouched.
+func MigrateAgentStore(ctx context.Context, agentID string, dst *sql.DB, dstDialect string) (types.JID, error) {
+	if _, err := os.Stat(agentStorePath(agentID)); err != nil {
+		return types.EmptyJID, err
+	}
+	src, err := sql.Open("sqlite3", agentStoreURI(agentID))
+	if err != nil {
+		return types.EmptyJID, err
+	}
+	defer src.Close()
+
+	return migrateDeviceStore(ctx, src, "sqlite3", dst, dstDialect)
+}
+
+// migrateDeviceStore copies the device of a single-device store to dst, along with its keys, sessions, app state and
+// the LID mappings it learned. Rows already in dst are kept, so an interrupted migration can simply be run again.
+func migrateDeviceStore(ctx context.Context, src *sql.DB, srcDialect string, dst *sql.DB, dstDialect string) (types.JID, error) {
+	srcContainer := sqlstore.NewWithDB(src, srcDialect, nil)
+	if err := srcContainer.Upgrade(ctx); err != nil {
+		return types.EmptyJID, fmt.Errorf("failed to upgrade source store: %w", err)
+	}
+	dstContainer := sqlstore.NewWithDB(dst, dstDialect, nil)
+	if err := dstContainer.Upgrade(ctx); err != nil {
+		return types.EmptyJID, fmt.Errorf("failed to upgrade destination store: %w", err)
+	}
+
+	device, err := srcContainer.GetFirstDevice(ctx)
+	if err != nil {
+		return types.EmptyJID, err
+	}
+	if device == nil || device.ID == nil {
+		return types.EmptyJID, nil
+	}
+	if err := dstContainer.PutDevice(ctx, device); err != nil {
+		return types.EmptyJID, fmt.Errorf("failed to copy device %s: %w", device.ID, err)
+	}
+
+	tx, err := dst.BeginTx(ctx, nil)
+	if err != nil {
+		return types.EmptyJID, err
+	}
+	defer tx.Rollback()
+
+	for _, table := range deviceTables {
+		query := fmt.Sprintf("SELECT * FROM %s WHERE %s = $1", table.name, table.deviceCol)
+		if err := copyRows(ctx, src, tx, table.name, query, device.ID.String()); err != nil {
+			return types.EmptyJID, err
+		}
+	}
+	// The LID mapping is shared by all devices of a store
+	if err := copyRows(ctx, src, tx, "whatsmeow_lid_map", "SELECT * FROM whatsmeow_lid_map"); err != nil {
+		return types.EmptyJID, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return types.EmptyJID, err
+	}
+	return *device.ID, nil
+}
+
+// copyRows inserts the rows a query returns from src into a table of dst. Columns are matched by name because
+// upgraded and freshly created stores order them differently.
+func copyRows(ctx context.Context, src *sql.DB, dst *sql.Tx, table, query string, args ...any) error {
+	rows, err := src.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT DO NOTHING",
+		table, strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return fmt.Errorf("failed to read %s: %w", table, err)
+		}
+		if _, err := dst.ExecContext(ctx, insert, values...); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", table, err)
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,81 @@
+package whatsapp
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"go.mau.fi/whatsmeow/proto/waAdv"
+	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func openTestStore(t *testing.T, name string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), name)+"?_foreign_keys=on")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrateDeviceStore(t *testing.T) {
+	ctx := context.Background()
+	src := openTestStore(t, "agent.db")
+	dst := openTestStore(t, "shared.db")
+
+	// A store that never linked a device has nothing to migrate
+	if jid, err := migrateDeviceStore(ctx, src, "sqlite3", dst, "sqlite3"); err != nil || !jid.IsEmpty() {
+		t.Fatalf("migrateDeviceStore() on an empty store = %v, %v; want an empty JID", jid, err)
+	}
+
+	srcContainer := sqlstore.NewWithDB(src, "sqlite3", nil)
+	deviceJID := types.NewADJID("6281234567890", 0, 12)
+	device := srcContainer.NewDevice()
+	device.ID = &deviceJID
+	device.PushName = "Agent"
+	device.Account = &waAdv.ADVSignedDeviceIdentity{
+		Details:             []byte{1},
+		AccountSignatureKey: make([]byte, 32),
+		AccountSignature:    make([]byte, 64),
+		DeviceSignature:     make([]byte, 64),
+	}
+	if err := device.Save(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := device.Sessions.PutSession(ctx, "6289876543210.0:1", []byte("session")); err != nil {
+		t.Fatal(err)
+	}
+	if err := device.LIDs.PutLIDMapping(ctx, types.NewJID("123456789", types.HiddenUserServer), types.NewJID("6289876543210", types.DefaultUserServer)); err != nil {
+		t.Fatal(err)
+	}
+
+	jid, err := migrateDeviceStore(ctx, src, "sqlite3", dst, "sqlite3")
+	if err != nil {
+		t.Fatalf("migrateDeviceStore() error = %v", err)
+	}
+	if jid != deviceJID {
+		t.Fatalf("migrateDeviceStore() = %v; want %v", jid, deviceJID)
+	}
+	// Running it again keeps the copied rows instead of failing
+	if _, err := migrateDeviceStore(ctx, src, "sqlite3", dst, "sqlite3"); err != nil {
+		t.Fatalf("migrateDeviceStore() second run error = %v", err)
+	}
+
+	migrated, err := sqlstore.NewWithDB(dst, "sqlite3", nil).GetDevice(ctx, deviceJID)
+	if err != nil || migrated == nil {
+		t.Fatalf("GetDevice() = %v, %v; want the migrated device", migrated, err)
+	}
+	if migrated.PushName != "Agent" || *migrated.IdentityKey.Priv != *device.IdentityKey.Priv {
+		t.Errorf("migrated device = %q; want the source device keys and push name", migrated.PushName)
+	}
+	if session, err := migrated.Sessions.GetSession(ctx, "6289876543210.0:1"); err != nil || string(session) != "session" {
+		t.Errorf("GetSession() = %q, %v; want the source session", session, err)
+	}
+	if pn, err := migrated.LIDs.GetPNForLID(ctx, types.NewJID("123456789", types.HiddenUserServer)); err != nil || pn.User != "6289876543210" {
+		t.Errorf("GetPNForLID() = %v, %v; want the source mapping", pn, err)
+	}
+}
@@ -0,0 +1,67 @@
+package whatsapp
+
+import (
+	"context"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// handleChatStateEvent keeps the chat-list state in storage in line with archive, mute, mark-unread, clear and
+// delete actions made on other devices. These arrive as app state mutations, including during the full sync
+// right after pairing.
+func handleChatStateEvent(_ context.Context, rawEvt any, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+	if chatStorageRepo == nil {
+		return
+	}
+
+	switch evt := rawEvt.(type) {
+	case *events.Archive:
+		updateChatState(chatStorageRepo, evt.JID, domainChatStorage.ChatStateUpdate{
+			Archived: proto.Bool(evt.Action.GetArchived()),
+		})
+	case *events.Mute:
+		updateChatState(chatStorageRepo, evt.JID, domainChatStorage.ChatStateUpdate{
+			MuteEndTimestamp: proto.Int64(muteEndTimestamp(evt.Action.GetMuted(), evt.Action.GetMuteEndTimestamp())),
+		})
+	case *events.MarkChatAsRead:
+		if !evt.Action.GetRead() {
+			updateChatState(chatStorageRepo, evt.JID, domainChatStorage.ChatStateUpdate{MarkedUnread: proto.Bool(true)})
+			return
+		}
+		var readIDs []string
+		for _, message := range evt.Action.GetMessageRange().GetMessages() {
+			readIDs = append(readIDs, message.GetKey().GetID())
+		}
+		if err := chatStorageRepo.MarkChatRead(evt.JID.String(), readIDs, evt.Timestamp); err != nil {
+			log.Errorf("Failed to store read state of chat %s: %v", evt.JID, err)
+		}
+	case *events.ClearChat:
+		if err := chatStorageRepo.ClearChatMessages(evt.JID.String()); err != nil {
+			log.Errorf("Failed to clear messages of chat %s: %v", evt.JID, err)
+		}
+	case *events.DeleteChat:
+		if err := chatStorageRepo.DeleteChat(evt.JID.String()); err != nil {
+			log.Errorf("Failed to delete chat %s: %v", evt.JID, err)
+		}
+	}
+}
+
+func updateChatState(chatStorageRepo domainChatStorage.IChatStorageRepository, jid types.JID, update domainChatStorage.ChatStateUpdate) {
+	if err := chatStorageRepo.UpdateChatState(jid.String(), update); err != nil {
+		log.Errorf("Failed to update state of chat %s: %v", jid, err)
+	}
+}
+
+// muteEndTimestamp normalizes a mute action to the stored representation: -1 for muted indefinitely, 0 for not muted
+func muteEndTimestamp(muted bool, endTimestamp int64) int64 {
+	if !muted {
+		return 0
+	}
+	if endTimestamp <= 0 {
+		return -1
+	}
+	return endTimestamp
+}
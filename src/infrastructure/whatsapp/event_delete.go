@@ -5,12 +5,14 @@ import (
 	"time"
 
 	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/types/events"
 )
 
 // forwardDeleteToWebhook sends a delete event to webhook
-func forwardDeleteToWebhook(ctx context.Context, agentID string, evt *events.DeleteForMe, message *domainChatStorage.Message) error {
-	payload, err := createDeletePayload(ctx, evt, message)
+func forwardDeleteToWebhook(ctx context.Context, agentID string, evt *events.DeleteForMe, message *domainChatStorage.Message, client *whatsmeow.Client) error {
+	payload, err := createDeletePayload(ctx, evt, message, ResolveJID(ctx, client, evt.SenderJID))
 	if err != nil {
 		return err
 	}
@@ -18,8 +20,9 @@ func forwardDeleteToWebhook(ctx context.Context, agentID string, evt *events.Del
 	return forwardPayloadToConfiguredWebhooks(ctx, payload, "delete event", agentID)
 }
 
-// createDeletePayload creates a webhook payload for delete events
-func createDeletePayload(_ context.Context, evt *events.DeleteForMe, message *domainChatStorage.Message) (map[string]any, error) {
+// createDeletePayload creates a webhook payload for delete events. sender holds both forms of the sender, so from
+// is the phone number JID whenever it is known.
+func createDeletePayload(_ context.Context, evt *events.DeleteForMe, message *domainChatStorage.Message, sender utils.JIDPair) (map[string]any, error) {
 	body := make(map[string]any)
 
 	// Basic delete event information
@@ -45,7 +48,12 @@ func createDeletePayload(_ context.Context, evt *events.DeleteForMe, message *do
 	// Parse sender JID for proper formatting
 	if evt.SenderJID.Server != "" {
 		body["from"] = evt.SenderJID.String()
+		if pn := sender.PN; !pn.IsEmpty() {
+			body["from"] = pn.String()
+		}
 	}
+	body["sender_pn"] = sender.PN.String()
+	body["sender_lid"] = sender.LID.String()
 
 	return body, nil
 }
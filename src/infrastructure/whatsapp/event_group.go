@@ -6,13 +6,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
 
-// createGroupInfoPayload creates a webhook payload for group information events
-func createGroupInfoPayload(evt *events.GroupInfo, actionType string, jids []types.JID) map[string]any {
+// createGroupInfoPayload creates a webhook payload for group information events. participants holds both forms
+// of each affected user, in the order of jids.
+func createGroupInfoPayload(evt *events.GroupInfo, actionType string, jids []types.JID, participants []utils.JIDPair) map[string]any {
 	body := make(map[string]any)
 
 	// Create payload structure matching the expected format
@@ -24,6 +27,11 @@ func createGroupInfoPayload(evt *events.GroupInfo, actionType string, jids []typ
 	// Add action type and affected users
 	payload["type"] = actionType
 	payload["jids"] = jidsToStrings(jids)
+	forms := make([]map[string]string, len(participants))
+	for i, participant := range participants {
+		forms[i] = jidPairPayload(participant)
+	}
+	payload["participants"] = forms
 
 	// Wrap in payload structure
 	body["payload"] = payload
@@ -49,7 +57,7 @@ func jidsToStrings(jids []types.JID) []string {
 }
 
 // forwardGroupInfoToWebhook forwards group information events to the configured webhook URLs
-func forwardGroupInfoToWebhook(ctx context.Context, agentID string, evt *events.GroupInfo) error {
+func forwardGroupInfoToWebhook(ctx context.Context, agentID string, evt *events.GroupInfo, client *whatsmeow.Client) error {
 	urls, secret := webhookResolver(agentID)
 	valid := make([]string, 0, len(urls))
 	for _, u := range urls {
@@ -73,7 +81,7 @@ func forwardGroupInfoToWebhook(ctx context.Context, agentID string, evt *events.
 
 	for _, action := range actions {
 		if len(action.jids) > 0 {
-			payload := createGroupInfoPayload(evt, action.actionType, action.jids)
+			payload := createGroupInfoPayload(evt, action.actionType, action.jids, resolveJIDs(ctx, client, action.jids))
 
 			// Collect errors from all webhook URLs instead of failing fast
 			var errors []error
@@ -0,0 +1,328 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainJoinRequest "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/joinrequest"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// joinRequestNotification is the group notification WhatsApp sends for a new join request. whatsmeow does not
+// parse it, so it arrives as an unknown change of a GroupInfo event.
+const joinRequestNotification = "created_membership_requests"
+
+// joinRequestChunkSize is how many requests are approved or rejected per request to WhatsApp
+const joinRequestChunkSize = 50
+
+var (
+	// joinRequestLocks lets only one review per group run at a time
+	joinRequestLocks sync.Map // group JID -> *sync.Mutex
+	// ignoredJoinRequests remembers requests a policy left pending, so they are not decided again on every new
+	// request to the group
+	ignoredJoinRequests sync.Map // group JID -> map[string]bool
+	// joinRequestHookClient asks the external decision hook of a policy. The hook URL comes from the API, so it
+	// only reaches public addresses like the callbacks of async sends.
+	joinRequestHookClient = callbackClient
+)
+
+// handleJoinRequests reviews the pending join requests of a group with a policy as soon as a new one arrives
+func handleJoinRequests(ctx context.Context, agentID string, evt *events.GroupInfo, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
+	if client == nil || chatStorageRepo == nil || !hasJoinRequest(evt) {
+		return
+	}
+
+	policy, err := chatStorageRepo.GetJoinRequestPolicy(evt.JID.String())
+	if err != nil {
+		log.Errorf("Failed to get join request policy of %s: %v", evt.JID, err)
+		return
+	}
+	if policy == nil || !policy.Enabled {
+		return
+	}
+
+	go func() {
+		if _, err := ReviewJoinRequests(ctx, agentID, client, policy, true); err != nil {
+			log.Errorf("Failed to review join requests of %s: %v", evt.JID, err)
+		}
+	}()
+}
+
+func hasJoinRequest(evt *events.GroupInfo) bool {
+	for _, change := range evt.UnknownChanges {
+		if change != nil && change.Tag == joinRequestNotification {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewJoinRequests decides on the pending join requests of a group under its policy, applies the decisions and
+// sends each of them to the webhooks. With onlyNew, requests the policy already left pending are skipped.
+func ReviewJoinRequests(ctx context.Context, agentID string, client *whatsmeow.Client, policy *domainChatStorage.JoinRequestPolicy, onlyNew bool) ([]domainJoinRequest.Decision, error) {
+	groupJID, err := types.ParseJID(policy.GroupJID)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, _ := joinRequestLocks.LoadOrStore(policy.GroupJID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	pending, err := client.GetGroupRequestParticipants(ctx, groupJID)
+	if err != nil {
+		return nil, err
+	}
+
+	previouslyIgnored := map[string]bool{}
+	if stored, ok := ignoredJoinRequests.Load(policy.GroupJID); ok {
+		previouslyIgnored = stored.(map[string]bool)
+	}
+	stillIgnored := make(map[string]bool)
+
+	decisions := make([]domainJoinRequest.Decision, 0, len(pending))
+	requesters := make([]types.JID, 0, len(pending))
+	for _, request := range pending {
+		key := request.JID.String() + "|" + strconv.FormatInt(request.RequestedAt.Unix(), 10)
+		if onlyNew && previouslyIgnored[key] {
+			stillIgnored[key] = true
+			continue
+		}
+
+		decision := decideJoinRequest(ctx, client, policy, request)
+		if decision.Decision == domainJoinRequest.DecisionIgnore {
+			stillIgnored[key] = true
+		}
+		decisions = append(decisions, decision)
+		requesters = append(requesters, request.JID)
+	}
+	ignoredJoinRequests.Store(policy.GroupJID, stillIgnored)
+
+	applyJoinRequestDecisions(ctx, client, groupJID, requesters, decisions, domainJoinRequest.DecisionApprove, whatsmeow.ParticipantChangeApprove)
+	applyJoinRequestDecisions(ctx, client, groupJID, requesters, decisions, domainJoinRequest.DecisionReject, whatsmeow.ParticipantChangeReject)
+
+	if len(decisions) > 0 {
+		// A review started from the API must not lose its webhooks when the request ends
+		ctx := context.WithoutCancel(ctx)
+		go func() {
+			for _, decision := range decisions {
+				payload := createJoinRequestPayload(policy.GroupJID, decision, time.Now())
+				if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "group join request decision", agentID); err != nil {
+					log.Errorf("Failed to forward join request decision to webhook: %v", err)
+				}
+			}
+		}()
+	}
+
+	return decisions, nil
+}
+
+// decideJoinRequest applies the local rules of a policy first and leaves the final say to the external hook
+func decideJoinRequest(ctx context.Context, client *whatsmeow.Client, policy *domainChatStorage.JoinRequestPolicy, request types.GroupParticipantRequest) domainJoinRequest.Decision {
+	requesterJIDs := ResolveJID(ctx, client, request.JID)
+	requester := preferredJID(requesterJIDs, request.JID)
+	decision := domainJoinRequest.Decision{
+		Requester:    request.JID.ToNonAD().String(),
+		RequesterPN:  requesterJIDs.PN.String(),
+		RequesterLID: requesterJIDs.LID.String(),
+		RequestedAt:  request.RequestedAt,
+	}
+	if requester.Server == types.DefaultUserServer {
+		decision.PhoneNumber = requester.User
+	}
+
+	inContacts := false
+	if policy.RequireContact || policy.HookURL != "" {
+		inContacts = isSavedContact(ctx, client, requester)
+	}
+
+	if reason, ok := checkJoinRequestRules(policy, decision.PhoneNumber, inContacts); !ok {
+		decision.Decision = policy.FailAction
+		decision.Reason = reason
+		return decision
+	}
+
+	if policy.HookURL == "" {
+		decision.Decision = domainJoinRequest.DecisionApprove
+		decision.Reason = "passed all rules"
+		return decision
+	}
+
+	verdict, reason, err := askJoinRequestHook(ctx, policy.HookURL, map[string]any{
+		"group_id":      policy.GroupJID,
+		"requester":     decision.Requester,
+		"requester_pn":  decision.RequesterPN,
+		"requester_lid": decision.RequesterLID,
+		"phone_number":  decision.PhoneNumber,
+		"requested_at":  request.RequestedAt.Format(time.RFC3339),
+		"in_contacts":   inContacts,
+	})
+	if err != nil {
+		// Without an answer the request waits for an admin rather than being decided blindly
+		decision.Decision = domainJoinRequest.DecisionIgnore
+		decision.Reason = fmt.Sprintf("decision hook failed: %v", err)
+		return decision
+	}
+	decision.Decision = verdict
+	decision.Reason = reason
+	if decision.Reason == "" {
+		decision.Reason = "decided by hook"
+	}
+	return decision
+}
+
+// checkJoinRequestRules checks the phone prefix allowlist and the contacts rule of a policy
+func checkJoinRequestRules(policy *domainChatStorage.JoinRequestPolicy, phone string, inContacts bool) (reason string, ok bool) {
+	if len(policy.AllowedPrefixes) > 0 {
+		if phone == "" {
+			return "phone number of the requester is unknown", false
+		}
+		allowed := false
+		for _, prefix := range policy.AllowedPrefixes {
+			if strings.HasPrefix(phone, prefix) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return "phone number prefix is not allowed", false
+		}
+	}
+
+	if policy.RequireContact && !inContacts {
+		return "requester is not in contacts", false
+	}
+
+	return "", true
+}
+
+// isSavedContact reports whether a user is in our address book, not just someone whose push name we know
+func isSavedContact(ctx context.Context, client *whatsmeow.Client, user types.JID) bool {
+	if client.Store == nil || client.Store.Contacts == nil {
+		return false
+	}
+	contact, err := client.Store.Contacts.GetContact(ctx, user)
+	if err != nil {
+		log.Warnf("Failed to look up contact %s: %v", user, err)
+		return false
+	}
+	return contact.Found && (contact.FullName != "" || contact.FirstName != "")
+}
+
+// askJoinRequestHook posts a join request to the external decision hook, signed like webhooks are. The hook
+// answers with {"decision": "approve" | "reject" | "ignore", "reason": "..."}.
+func askJoinRequestHook(ctx context.Context, url string, request map[string]any) (decision, reason string, err error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", "", err
+	}
+	signature, err := utils.GetMessageDigestOrSignature(body, []byte(config.WhatsappWebhookSecret))
+	if err != nil {
+		return "", "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Hub-Signature-256", fmt.Sprintf("sha256=%s", signature))
+
+	resp, err := joinRequestHookClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", "", fmt.Errorf("hook returned status %d", resp.StatusCode)
+	}
+
+	var answer struct {
+		Decision string `json:"decision"`
+		Reason   string `json:"reason"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&answer); err != nil {
+		return "", "", fmt.Errorf("invalid hook response: %w", err)
+	}
+	switch answer.Decision {
+	case domainJoinRequest.DecisionApprove, domainJoinRequest.DecisionReject, domainJoinRequest.DecisionIgnore:
+		return answer.Decision, answer.Reason, nil
+	default:
+		return "", "", fmt.Errorf("hook returned unknown decision %q", answer.Decision)
+	}
+}
+
+// applyJoinRequestDecisions sends all decisions of one kind to WhatsApp and records per request whether it worked
+func applyJoinRequestDecisions(ctx context.Context, client *whatsmeow.Client, groupJID types.JID, requesters []types.JID, decisions []domainJoinRequest.Decision, kind string, action whatsmeow.ParticipantRequestChange) {
+	var indexes []int
+	for i := range decisions {
+		if decisions[i].Decision == kind {
+			indexes = append(indexes, i)
+		}
+	}
+
+	for start := 0; start < len(indexes); start += joinRequestChunkSize {
+		chunk := indexes[start:min(start+joinRequestChunkSize, len(indexes))]
+		jids := make([]types.JID, len(chunk))
+		for i, index := range chunk {
+			jids[i] = requesters[index]
+		}
+
+		results, err := client.UpdateGroupRequestParticipants(ctx, groupJID, jids, action)
+		failed := make(map[string]int)
+		for _, result := range results {
+			if result.Error != 0 {
+				failed[result.JID.User] = result.Error
+				failed[result.PhoneNumber.User] = result.Error
+			}
+		}
+
+		for i, index := range chunk {
+			switch code, refused := failed[jids[i].User]; {
+			case err != nil:
+				decisions[index].Error = err.Error()
+			case refused:
+				decisions[index].Error = fmt.Sprintf("WhatsApp refused the %s with code %d", kind, code)
+			default:
+				decisions[index].Applied = true
+			}
+		}
+	}
+}
+
+// createJoinRequestPayload creates a webhook payload for a decision on a join request
+func createJoinRequestPayload(groupJID string, decision domainJoinRequest.Decision, at time.Time) map[string]any {
+	body := map[string]any{
+		"chat_id":       groupJID,
+		"requester":     decision.Requester,
+		"requester_pn":  decision.RequesterPN,
+		"requester_lid": decision.RequesterLID,
+		"phone_number":  decision.PhoneNumber,
+		"requested_at":  decision.RequestedAt.Format(time.RFC3339),
+		"decision":      decision.Decision,
+		"reason":        decision.Reason,
+		"applied":       decision.Applied,
+	}
+	if decision.Error != "" {
+		body["error"] = decision.Error
+	}
+
+	return map[string]any{
+		"event":     "group.join_request",
+		"timestamp": at.Format(time.RFC3339),
+		"payload":   body,
+	}
+}
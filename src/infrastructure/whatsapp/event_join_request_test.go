@@ -0,0 +1,126 @@
+package whatsapp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainJoinRequest "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/joinrequest"
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func TestCheckJoinRequestRules(t *testing.T) {
+	policy := &domainChatStorage.JoinRequestPolicy{
+		AllowedPrefixes: []string{"62", "44"},
+		RequireContact:  true,
+	}
+
+	tests := []struct {
+		name       string
+		phone      string
+		inContacts bool
+		wantOK     bool
+		wantReason string
+	}{
+		{name: "allowed prefix in contacts", phone: "6281234567890", inContacts: true, wantOK: true},
+		{name: "other prefix", phone: "15551234567", inContacts: true, wantReason: "phone number prefix is not allowed"},
+		{name: "unknown phone", phone: "", inContacts: true, wantReason: "phone number of the requester is unknown"},
+		{name: "not in contacts", phone: "447700900123", wantReason: "requester is not in contacts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, ok := checkJoinRequestRules(policy, tt.phone, tt.inContacts)
+			if ok != tt.wantOK || reason != tt.wantReason {
+				t.Errorf("checkJoinRequestRules() = (%q, %v), want (%q, %v)", reason, ok, tt.wantReason, tt.wantOK)
+			}
+		})
+	}
+
+	if _, ok := checkJoinRequestRules(&domainChatStorage.JoinRequestPolicy{}, "", false); !ok {
+		t.Error("a policy without rules should pass every request")
+	}
+}
+
+func TestAskJoinRequestHook(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantDecision string
+		wantErr      bool
+	}{
+		{name: "approve", status: http.StatusOK, body: `{"decision":"approve","reason":"known customer"}`, wantDecision: domainJoinRequest.DecisionApprove},
+		{name: "unknown decision", status: http.StatusOK, body: `{"decision":"maybe"}`, wantErr: true},
+		{name: "server error", status: http.StatusInternalServerError, body: `{}`, wantErr: true},
+		{name: "invalid body", status: http.StatusOK, body: `not json`, wantErr: true},
+	}
+	// The test server listens on loopback, which the real hook client refuses
+	originalClient := joinRequestHookClient
+	joinRequestHookClient = &http.Client{Timeout: 10 * time.Second}
+	defer func() { joinRequestHookClient = originalClient }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-Hub-Signature-256") == "" {
+					t.Error("hook request is not signed")
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			decision, _, err := askJoinRequestHook(context.Background(), server.URL, map[string]any{"group_id": "120363025246125486@g.us"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("askJoinRequestHook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if decision != tt.wantDecision {
+				t.Errorf("askJoinRequestHook() decision = %q, want %q", decision, tt.wantDecision)
+			}
+		})
+	}
+}
+
+func TestHasJoinRequest(t *testing.T) {
+	evt := &events.GroupInfo{UnknownChanges: []*waBinary.Node{{Tag: "revoked_membership_requests"}}}
+	if hasJoinRequest(evt) {
+		t.Error("revoked requests should not trigger a review")
+	}
+
+	evt.UnknownChanges = append(evt.UnknownChanges, &waBinary.Node{Tag: joinRequestNotification})
+	if !hasJoinRequest(evt) {
+		t.Error("new join request was not detected")
+	}
+}
+
+func TestCreateJoinRequestPayload(t *testing.T) {
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	payload := createJoinRequestPayload("120363025246125486@g.us", domainJoinRequest.Decision{
+		Requester:    "123456789012345@lid",
+		RequesterPN:  "6281234567890@s.whatsapp.net",
+		RequesterLID: "123456789012345@lid",
+		PhoneNumber:  "6281234567890",
+		RequestedAt:  at.Add(-time.Minute),
+		Decision:     domainJoinRequest.DecisionReject,
+		Reason:       "requester is not in contacts",
+		Error:        "WhatsApp refused the reject with code 404",
+	}, at)
+
+	if payload["event"] != "group.join_request" {
+		t.Errorf("event = %v", payload["event"])
+	}
+	body := payload["payload"].(map[string]any)
+	if body["decision"] != domainJoinRequest.DecisionReject || body["applied"] != false {
+		t.Errorf("payload = %v", body)
+	}
+	if body["requester_pn"] != "6281234567890@s.whatsapp.net" || body["requester_lid"] != "123456789012345@lid" {
+		t.Errorf("requester forms = %v, %v", body["requester_pn"], body["requester_lid"])
+	}
+	if body["error"] != "WhatsApp refused the reject with code 404" {
+		t.Errorf("error = %v", body["error"])
+	}
+}
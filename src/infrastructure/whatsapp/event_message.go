@@ -40,6 +40,10 @@ func createMessagePayload(ctx context.Context, evt *events.Message, client *what
 	body["sender_id"] = evt.Info.Sender.User
 	body["chat_id"] = evt.Info.Chat.User
 
+	sender := completeJIDPair(ctx, client, utils.MessageSenderJIDs(evt.Info.MessageSource))
+	body["sender_pn"] = sender.PN.String()
+	body["sender_lid"] = sender.LID.String()
+
 	if from := evt.Info.SourceString(); from != "" {
 		body["from"] = from
 
@@ -51,16 +55,11 @@ func createMessagePayload(ctx context.Context, evt *events.Message, client *what
 
 		if strings.HasSuffix(from_user, "@lid") {
 			body["from_lid"] = from_user
-			lid, err := types.ParseJID(from_user)
-			if err != nil {
-				logrus.Errorf("Error when parse jid: %v", err)
-			} else if client.Store != nil && client.Store.LIDs != nil {
-				if pn, err := client.Store.LIDs.GetPNForLID(ctx, lid); err == nil && !pn.IsEmpty() {
-					if from_group != "" {
-						body["from"] = fmt.Sprintf("%s in %s", pn.String(), from_group)
-					} else {
-						body["from"] = pn.String()
-					}
+			if !sender.PN.IsEmpty() {
+				if from_group != "" {
+					body["from"] = fmt.Sprintf("%s in %s", sender.PN.String(), from_group)
+				} else {
+					body["from"] = sender.PN.String()
 				}
 			}
 		}
@@ -75,10 +74,8 @@ func createMessagePayload(ctx context.Context, evt *events.Message, client *what
 			lid, err := types.ParseJID(tag[1:] + "@lid")
 			if err != nil {
 				logrus.Errorf("Error when parse jid: %v", err)
-			} else if client.Store != nil && client.Store.LIDs != nil {
-				if pn, err := client.Store.LIDs.GetPNForLID(ctx, lid); err == nil && !pn.IsEmpty() {
-					message.Text = strings.Replace(message.Text, tag, fmt.Sprintf("@%s", pn.User), -1)
-				}
+			} else if pn := ResolveJID(ctx, client, lid).PN; !pn.IsEmpty() {
+				message.Text = strings.Replace(message.Text, tag, fmt.Sprintf("@%s", pn.User), -1)
 			}
 		}
 		body["message"] = message
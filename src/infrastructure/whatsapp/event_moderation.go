@@ -0,0 +1,368 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// moderationLinkRegex finds links with a scheme, starting with www., or a bare domain followed by a path (bit.ly/x)
+var moderationLinkRegex = regexp.MustCompile(`(?i)\b(?:https?://\S+|www\.\S+|[a-z0-9][a-z0-9-]*(?:\.[a-z0-9-]+)*\.[a-z]{2,}/\S*)`)
+
+// moderationPatterns caches compiled banned patterns, which are checked on every group message
+var moderationPatterns sync.Map
+
+type moderationViolation struct {
+	Rule   string
+	Detail string
+}
+
+// handleGroupModeration checks an incoming group message against the moderation rules of the group. The checks
+// only use the message itself; actions that need WhatsApp run in the background.
+func handleGroupModeration(ctx context.Context, agentID string, evt *events.Message, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
+	if client == nil || evt.Info.IsFromMe || evt.Info.Chat.Server != types.GroupServer {
+		return
+	}
+
+	rules, err := chatStorageRepo.GetModerationRules(evt.Info.Chat.String())
+	if err != nil {
+		log.Errorf("Failed to get moderation rules of %s: %v", evt.Info.Chat, err)
+		return
+	}
+	if rules == nil || !rules.Enabled {
+		return
+	}
+
+	text := utils.ExtractMessageTextFromProto(evt.Message)
+	mediaType := moderationMediaType(evt.Message)
+	if text == "" && mediaType == "" {
+		// Reactions, edits, revokes and other protocol messages
+		return
+	}
+
+	floodCount := 0
+	if rules.FloodMaxMessages > 0 {
+		key := strings.Join([]string{agentID, evt.Info.Chat.String(), evt.Info.Sender.ToNonAD().String()}, "|")
+		floodCount = moderationFlood.record(key, time.Now(), time.Duration(rules.FloodWindowSeconds)*time.Second)
+	}
+
+	violation := evaluateModeration(rules, text, mediaType, moderationLinks(text, evt.Message), floodCount)
+	if violation == nil {
+		return
+	}
+
+	go enforceModeration(ctx, evt, rules, violation, chatStorageRepo, client)
+}
+
+// evaluateModeration returns the first rule the message breaks, or nil
+func evaluateModeration(rules *domainChatStorage.ModerationRules, text, mediaType string, links []string, floodCount int) *moderationViolation {
+	if mediaType != "" && slices.Contains(rules.BlockedMediaTypes, mediaType) {
+		return &moderationViolation{Rule: domainModeration.RuleMedia, Detail: mediaType}
+	}
+
+	lowerText := strings.ToLower(text)
+	for _, keyword := range rules.BannedKeywords {
+		if containsWord(lowerText, strings.ToLower(keyword)) {
+			return &moderationViolation{Rule: domainModeration.RuleKeyword, Detail: keyword}
+		}
+	}
+
+	for _, pattern := range rules.BannedPatterns {
+		if re := compileModerationPattern(pattern); re != nil && re.MatchString(text) {
+			return &moderationViolation{Rule: domainModeration.RulePattern, Detail: pattern}
+		}
+	}
+
+	if rules.BlockLinks {
+		for _, link := range links {
+			if host := linkHost(link); host != "" && !domainAllowed(host, rules.AllowedDomains) {
+				return &moderationViolation{Rule: domainModeration.RuleLink, Detail: host}
+			}
+		}
+	}
+
+	if rules.FloodMaxMessages > 0 && floodCount > rules.FloodMaxMessages {
+		return &moderationViolation{
+			Rule:   domainModeration.RuleFlood,
+			Detail: fmt.Sprintf("%d messages in %ds", floodCount, rules.FloodWindowSeconds),
+		}
+	}
+
+	return nil
+}
+
+// enforceModeration applies the configured actions to a message that broke a rule and writes each of them to the
+// audit log. Group admins are exempt, which needs the participant list, so it is only fetched for violations.
+func enforceModeration(ctx context.Context, evt *events.Message, rules *domainChatStorage.ModerationRules, violation *moderationViolation, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
+	chat := evt.Info.Chat
+	sender := evt.Info.Sender.ToNonAD()
+	participant := preferPhoneJID(ctx, client, evt.Info.Sender)
+
+	groupInfo, err := client.GetGroupInfo(ctx, chat)
+	if err != nil {
+		log.Warnf("Failed to get group info of %s for moderation: %v", chat, err)
+		return
+	}
+	if isGroupAdmin(groupInfo, sender, participant) {
+		return
+	}
+
+	audit := func(action, detail string, actionErr error) {
+		entry := &domainChatStorage.ModerationAuditEntry{
+			GroupJID:    chat.String(),
+			MessageID:   evt.Info.ID,
+			Participant: participant.String(),
+			Rule:        violation.Rule,
+			Detail:      detail,
+			Action:      action,
+			Success:     actionErr == nil,
+		}
+		if actionErr != nil {
+			entry.Error = actionErr.Error()
+			log.Warnf("Moderation %s of message %s in %s failed: %v", action, evt.Info.ID, chat, actionErr)
+		}
+		if err := chatStorageRepo.StoreModerationAudit(entry); err != nil {
+			log.Errorf("Failed to store moderation audit entry for %s: %v", chat, err)
+		}
+	}
+
+	removeEnabled := slices.Contains(rules.Actions, domainModeration.ActionRemove)
+	strikes := 0
+	if removeEnabled {
+		strikes, err = chatStorageRepo.AddModerationStrike(chat.String(), participant.String())
+		audit(domainModeration.ActionStrike, fmt.Sprintf("%s (strike %d of %d)", violation.Detail, strikes, rules.MaxStrikes), err)
+		if err != nil {
+			removeEnabled = false
+		}
+	}
+
+	deleteEnabled := slices.Contains(rules.Actions, domainModeration.ActionDelete)
+	if deleteEnabled {
+		_, err := client.SendMessage(ctx, chat, client.BuildRevoke(chat, sender, evt.Info.ID))
+		audit(domainModeration.ActionDelete, violation.Detail, err)
+	}
+
+	if slices.Contains(rules.Actions, domainModeration.ActionWarn) {
+		warning := buildModerationWarning(rules, violation, sender, strikes, removeEnabled)
+		if !deleteEnabled {
+			// Quote the message unless it is gone already
+			warning.ExtendedTextMessage.ContextInfo.StanzaID = proto.String(evt.Info.ID)
+			warning.ExtendedTextMessage.ContextInfo.Participant = proto.String(sender.String())
+			warning.ExtendedTextMessage.ContextInfo.QuotedMessage = evt.Message
+		}
+		_, err := client.SendMessage(ctx, chat, warning)
+		audit(domainModeration.ActionWarn, violation.Detail, err)
+	}
+
+	if removeEnabled && strikes >= rules.MaxStrikes {
+		err := removeModeratedParticipant(ctx, client, chat, sender)
+		audit(domainModeration.ActionRemove, fmt.Sprintf("%s (strike %d of %d)", violation.Detail, strikes, rules.MaxStrikes), err)
+		if err == nil {
+			if err := chatStorageRepo.ResetModerationStrikes(chat.String(), participant.String()); err != nil {
+				log.Errorf("Failed to reset moderation strikes of %s in %s: %v", participant, chat, err)
+			}
+		}
+	}
+}
+
+func buildModerationWarning(rules *domainChatStorage.ModerationRules, violation *moderationViolation, sender types.JID, strikes int, removeEnabled bool) *waE2E.Message {
+	text := rules.WarningMessage
+	if text == "" {
+		text = fmt.Sprintf("your message was against the group rules (%s).", violation.Rule)
+	}
+	text = fmt.Sprintf("@%s %s", sender.User, text)
+	if removeEnabled {
+		text += fmt.Sprintf(" Strike %d of %d.", strikes, rules.MaxStrikes)
+	}
+
+	return &waE2E.Message{
+		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text: proto.String(text),
+			ContextInfo: &waE2E.ContextInfo{
+				MentionedJID: []string{sender.String()},
+			},
+		},
+	}
+}
+
+func removeModeratedParticipant(ctx context.Context, client *whatsmeow.Client, chat, sender types.JID) error {
+	result, err := client.UpdateGroupParticipants(ctx, chat, []types.JID{sender}, whatsmeow.ParticipantChangeRemove)
+	if err != nil {
+		return err
+	}
+	for _, participant := range result {
+		if participant.Error != 0 {
+			return fmt.Errorf("WhatsApp refused the removal with code %d", participant.Error)
+		}
+	}
+	return nil
+}
+
+func isGroupAdmin(groupInfo *types.GroupInfo, jids ...types.JID) bool {
+	for _, member := range groupInfo.Participants {
+		if !member.IsAdmin && !member.IsSuperAdmin {
+			continue
+		}
+		for _, jid := range jids {
+			if jid.User != "" && (member.JID.User == jid.User || member.PhoneNumber.User == jid.User || member.LID.User == jid.User) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// moderationMediaType names the kind of media a message carries, as used in blocked_media_types
+func moderationMediaType(msg *waE2E.Message) string {
+	switch {
+	case msg.GetImageMessage() != nil:
+		return domainModeration.MediaImage
+	case msg.GetVideoMessage() != nil, msg.GetPtvMessage() != nil:
+		return domainModeration.MediaVideo
+	case msg.GetAudioMessage() != nil:
+		return domainModeration.MediaAudio
+	case msg.GetDocumentMessage() != nil:
+		return domainModeration.MediaDocument
+	case msg.GetStickerMessage() != nil:
+		return domainModeration.MediaSticker
+	case msg.GetContactMessage() != nil, msg.GetContactsArrayMessage() != nil:
+		return domainModeration.MediaContact
+	case msg.GetLocationMessage() != nil, msg.GetLiveLocationMessage() != nil:
+		return domainModeration.MediaLocation
+	case utils.ExtractPollCreation(msg) != nil:
+		return domainModeration.MediaPoll
+	}
+	return ""
+}
+
+// moderationLinks returns the links in a message. WhatsApp also reports the link it built a preview for, which
+// catches bare domains such as example.com.
+func moderationLinks(text string, msg *waE2E.Message) []string {
+	links := moderationLinkRegex.FindAllString(text, -1)
+	if matched := msg.GetExtendedTextMessage().GetMatchedText(); matched != "" {
+		links = append(links, matched)
+	}
+	return links
+}
+
+// linkHost returns the lowercase host of a link without www.
+func linkHost(link string) string {
+	host := strings.ToLower(link)
+	if _, rest, found := strings.Cut(host, "://"); found {
+		host = rest
+	}
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	host, _, _ = strings.Cut(host, ":")
+	host = strings.TrimRight(host, ".,;!)]'\"")
+	return strings.TrimPrefix(host, "www.")
+}
+
+// domainAllowed reports whether host is one of the allowed domains or a subdomain of one
+func domainAllowed(host string, allowed []string) bool {
+	for _, domain := range allowed {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWord reports whether word occurs in text as a whole word, so "ass" does not match "class"
+func containsWord(text, word string) bool {
+	if word == "" {
+		return false
+	}
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(word)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_')
+}
+
+func compileModerationPattern(pattern string) *regexp.Regexp {
+	if cached, ok := moderationPatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		// Patterns are validated when saved, so this only happens for rules stored by hand
+		log.Warnf("Ignoring invalid moderation pattern %q: %v", pattern, err)
+		return nil
+	}
+	moderationPatterns.Store(pattern, re)
+	return re
+}
+
+// moderationFlood counts recent messages per participant for the flood limit. It lives in memory, so a restart
+// starts every count from zero.
+var moderationFlood = &floodTracker{seen: make(map[string][]time.Time)}
+
+// floodTrackerMaxWindow is the longest flood window that can be configured; older entries are never needed
+const floodTrackerMaxWindow = time.Hour
+
+type floodTracker struct {
+	mu        sync.Mutex
+	seen      map[string][]time.Time
+	lastSweep time.Time
+}
+
+// record adds a message sent at now and returns how many messages the same key sent within window
+func (t *floodTracker) record(key string, now time.Time, window time.Duration) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cutoff := now.Add(-window)
+	recent := t.seen[key][:0]
+	for _, at := range t.seen[key] {
+		if at.After(cutoff) {
+			recent = append(recent, at)
+		}
+	}
+	recent = append(recent, now)
+	t.seen[key] = recent
+
+	// Forget participants that went quiet, so the map does not grow with every sender ever seen
+	if now.Sub(t.lastSweep) > floodTrackerMaxWindow {
+		for k, times := range t.seen {
+			if len(times) == 0 || now.Sub(times[len(times)-1]) > floodTrackerMaxWindow {
+				delete(t.seen, k)
+			}
+		}
+		t.lastSweep = now
+	}
+
+	return len(recent)
+}
@@ -0,0 +1,134 @@
+package whatsapp
+
+import (
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEvaluateModeration(t *testing.T) {
+	rules := &domainChatStorage.ModerationRules{
+		Enabled:            true,
+		BlockLinks:         true,
+		AllowedDomains:     []string{"example.com"},
+		BannedKeywords:     []string{"casino"},
+		BannedPatterns:     []string{`(?i)free\s+money`},
+		FloodMaxMessages:   3,
+		FloodWindowSeconds: 10,
+		BlockedMediaTypes:  []string{domainModeration.MediaSticker},
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		mediaType string
+		flood     int
+		wantRule  string
+	}{
+		{name: "clean message", text: "see you tomorrow", flood: 1},
+		{name: "blocked media", mediaType: domainModeration.MediaSticker, flood: 1, wantRule: domainModeration.RuleMedia},
+		{name: "allowed media", text: "holiday", mediaType: domainModeration.MediaImage, flood: 1},
+		{name: "keyword", text: "Best CASINO in town", flood: 1, wantRule: domainModeration.RuleKeyword},
+		{name: "keyword inside another word", text: "casinos are fun", flood: 1},
+		{name: "pattern", text: "get FREE   money now", flood: 1, wantRule: domainModeration.RulePattern},
+		{name: "link", text: "join https://spam.io/x", flood: 1, wantRule: domainModeration.RuleLink},
+		{name: "bare link with path", text: "go to bit.ly/abc", flood: 1, wantRule: domainModeration.RuleLink},
+		{name: "allowed subdomain", text: "docs at https://docs.example.com/start", flood: 1},
+		{name: "flood within limit", text: "hi", flood: 3},
+		{name: "flood over limit", text: "hi", flood: 4, wantRule: domainModeration.RuleFlood},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violation := evaluateModeration(rules, tt.text, tt.mediaType, moderationLinks(tt.text, nil), tt.flood)
+			switch {
+			case tt.wantRule == "" && violation != nil:
+				t.Fatalf("expected no violation, got %+v", violation)
+			case tt.wantRule != "" && (violation == nil || violation.Rule != tt.wantRule):
+				t.Fatalf("expected %s violation, got %+v", tt.wantRule, violation)
+			}
+		})
+	}
+}
+
+func TestModerationLinks(t *testing.T) {
+	msg := &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+		Text:        proto.String("look at spam.io"),
+		MatchedText: proto.String("spam.io"),
+	}}
+	links := moderationLinks(msg.GetExtendedTextMessage().GetText(), msg)
+	if len(links) != 1 || linkHost(links[0]) != "spam.io" {
+		t.Fatalf("unexpected links %v", links)
+	}
+
+	hosts := map[string]string{
+		"https://WWW.Example.com/path?q=1": "example.com",
+		"http://user@evil.io:8080/":        "evil.io",
+		"www.shop.co.uk,":                  "shop.co.uk",
+		"bit.ly/abc":                       "bit.ly",
+	}
+	for link, want := range hosts {
+		if got := linkHost(link); got != want {
+			t.Errorf("linkHost(%q) = %q, want %q", link, got, want)
+		}
+	}
+
+	if domainAllowed("notexample.com", []string{"example.com"}) {
+		t.Error("notexample.com must not match example.com")
+	}
+}
+
+func TestModerationMediaType(t *testing.T) {
+	tests := map[string]*waE2E.Message{
+		"":                            {Conversation: proto.String("hello")},
+		domainModeration.MediaImage:   {ImageMessage: &waE2E.ImageMessage{}},
+		domainModeration.MediaSticker: {StickerMessage: &waE2E.StickerMessage{}},
+		domainModeration.MediaContact: {ContactsArrayMessage: &waE2E.ContactsArrayMessage{}},
+		domainModeration.MediaPoll:    {PollCreationMessageV3: &waE2E.PollCreationMessage{Name: proto.String("Lunch?")}},
+	}
+	for want, msg := range tests {
+		if got := moderationMediaType(msg); got != want {
+			t.Errorf("moderationMediaType() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestFloodTracker(t *testing.T) {
+	tracker := &floodTracker{seen: make(map[string][]time.Time)}
+	now := time.Now()
+
+	for i := 1; i <= 3; i++ {
+		if got := tracker.record("a", now.Add(time.Duration(i)*time.Second), 10*time.Second); got != i {
+			t.Fatalf("message %d counted as %d", i, got)
+		}
+	}
+	if got := tracker.record("b", now, 10*time.Second); got != 1 {
+		t.Fatalf("other participant counted as %d", got)
+	}
+	// The first two messages fell out of the window
+	if got := tracker.record("a", now.Add(12*time.Second), 10*time.Second); got != 2 {
+		t.Fatalf("expected 2 messages in window, got %d", got)
+	}
+}
+
+func TestIsGroupAdmin(t *testing.T) {
+	info := &types.GroupInfo{Participants: []types.GroupParticipant{
+		{JID: types.NewJID("111", types.HiddenUserServer), PhoneNumber: types.NewJID("6281111111111", types.DefaultUserServer), IsAdmin: true},
+		{JID: types.NewJID("6282222222222", types.DefaultUserServer)},
+	}}
+
+	if !isGroupAdmin(info, types.NewJID("6281111111111", types.DefaultUserServer)) {
+		t.Error("admin matched by phone number was not recognised")
+	}
+	if !isGroupAdmin(info, types.NewJID("111", types.HiddenUserServer)) {
+		t.Error("admin matched by LID was not recognised")
+	}
+	if isGroupAdmin(info, types.NewJID("6282222222222", types.DefaultUserServer)) {
+		t.Error("member reported as admin")
+	}
+}
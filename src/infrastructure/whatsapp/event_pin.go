@@ -0,0 +1,89 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// defaultPinDuration applies when a pin arrives without a duration, which older clients do not send
+const defaultPinDuration = 7 * 24 * time.Hour
+
+// handlePinMessage stores or removes a pin made by anyone in the chat, including our own other devices, and
+// forwards it to webhooks
+func handlePinMessage(ctx context.Context, agentID string, evt *events.Message, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
+	pinMessage := evt.Message.GetPinInChatMessage()
+	if pinMessage == nil {
+		return
+	}
+
+	chatJID := evt.Info.Chat.ToNonAD().String()
+	messageID := pinMessage.GetKey().GetID()
+	actor := completeJIDPair(ctx, client, utils.MessageSenderJIDs(evt.Info.MessageSource))
+	pinnedBy := preferredJID(actor, evt.Info.Sender)
+
+	pinnedAt := evt.Info.Timestamp
+	if ms := pinMessage.GetSenderTimestampMS(); ms > 0 {
+		pinnedAt = time.UnixMilli(ms)
+	}
+
+	var pin *domainChatStorage.MessagePin
+	switch pinMessage.GetType() {
+	case waE2E.PinInChatMessage_PIN_FOR_ALL:
+		duration := time.Duration(evt.Message.GetMessageContextInfo().GetMessageAddOnDurationInSecs()) * time.Second
+		if duration <= 0 {
+			duration = defaultPinDuration
+		}
+		pin = &domainChatStorage.MessagePin{
+			ChatJID:   chatJID,
+			MessageID: messageID,
+			PinnedBy:  pinnedBy.String(),
+			PinnedAt:  pinnedAt,
+			ExpiresAt: pinnedAt.Add(duration),
+		}
+		if err := chatStorageRepo.StoreMessagePin(pin); err != nil {
+			log.Errorf("Failed to store pin of message %s in %s: %v", messageID, chatJID, err)
+		}
+	case waE2E.PinInChatMessage_UNPIN_FOR_ALL:
+		if err := chatStorageRepo.DeleteMessagePin(chatJID, messageID); err != nil {
+			log.Errorf("Failed to remove pin of message %s in %s: %v", messageID, chatJID, err)
+		}
+	default:
+		return
+	}
+
+	payload := createMessagePinPayload(chatJID, messageID, pinnedBy.String(), actor, pinnedAt, pin)
+	go func() {
+		if err := forwardPayloadToConfiguredWebhooks(ctx, payload, "message pin event", agentID); err != nil {
+			log.Errorf("Failed to forward message pin to webhook: %v", err)
+		}
+	}()
+}
+
+// createMessagePinPayload creates a webhook payload for pin and unpin events; pin is nil for an unpin
+func createMessagePinPayload(chatJID, messageID, actor string, actorJIDs utils.JIDPair, at time.Time, pin *domainChatStorage.MessagePin) map[string]any {
+	body := map[string]any{
+		"chat_id":       chatJID,
+		"message_id":    messageID,
+		"pinned_by":     actor,
+		"pinned_by_pn":  actorJIDs.PN.String(),
+		"pinned_by_lid": actorJIDs.LID.String(),
+		"action":        "unpin",
+	}
+	if pin != nil {
+		body["action"] = "pin"
+		body["duration_seconds"] = int64(pin.ExpiresAt.Sub(pin.PinnedAt) / time.Second)
+		body["expires_at"] = pin.ExpiresAt.Format(time.RFC3339)
+	}
+
+	return map[string]any{
+		"event":     "message.pin",
+		"timestamp": at.Format(time.RFC3339),
+		"payload":   body,
+	}
+}
@@ -0,0 +1,78 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// handlePollVote decrypts an incoming poll vote, stores the voter's current selection and forwards it to webhooks.
+// whatsmeow keeps the poll secret from when the poll was sent or received, so only polls seen by this device can
+// be decrypted.
+func handlePollVote(ctx context.Context, agentID string, evt *events.Message, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
+	pollUpdate := evt.Message.GetPollUpdateMessage()
+	if pollUpdate == nil || client == nil {
+		return
+	}
+	pollID := pollUpdate.GetPollCreationMessageKey().GetID()
+
+	vote, err := client.DecryptPollVote(ctx, evt)
+	if err != nil {
+		log.Warnf("Failed to decrypt vote on poll %s from %s: %v", pollID, evt.Info.SourceString(), err)
+		return
+	}
+
+	poll, err := chatStorageRepo.GetPoll(pollID)
+	if err != nil {
+		log.Errorf("Failed to load poll %s: %v", pollID, err)
+		return
+	}
+	if poll == nil {
+		log.Warnf("Received vote on unknown poll %s, skipping", pollID)
+		return
+	}
+
+	votedAt := evt.Info.Timestamp
+	if ms := pollUpdate.GetSenderTimestampMS(); ms > 0 {
+		votedAt = time.UnixMilli(ms)
+	}
+	voter := completeJIDPair(ctx, client, utils.MessageSenderJIDs(evt.Info.MessageSource))
+	pollVote := &domainChatStorage.PollVote{
+		PollMessageID:   poll.MessageID,
+		ChatJID:         poll.ChatJID,
+		Voter:           preferredJID(voter, evt.Info.Sender).String(),
+		SelectedOptions: utils.MatchPollOptions(poll.Options, vote.GetSelectedOptions()),
+		UpdatedAt:       votedAt,
+	}
+	if err := chatStorageRepo.StorePollVote(pollVote); err != nil {
+		log.Errorf("Failed to store vote on poll %s: %v", pollID, err)
+		return
+	}
+
+	go func() {
+		if err := forwardPayloadToConfiguredWebhooks(ctx, createPollVotePayload(poll, pollVote, voter), "poll vote event", agentID); err != nil {
+			log.Errorf("Failed to forward poll vote to webhook: %v", err)
+		}
+	}()
+}
+
+// createPollVotePayload creates a webhook payload for poll vote events, with both forms of the voter
+func createPollVotePayload(poll *domainChatStorage.Poll, vote *domainChatStorage.PollVote, voter utils.JIDPair) map[string]any {
+	return map[string]any{
+		"event":     "poll.vote",
+		"timestamp": vote.UpdatedAt.Format(time.RFC3339),
+		"payload": map[string]any{
+			"poll_id":          poll.MessageID,
+			"chat_id":          poll.ChatJID,
+			"question":         poll.Question,
+			"voter":            vote.Voter,
+			"voter_pn":         voter.PN.String(),
+			"voter_lid":        voter.LID.String(),
+			"selected_options": vote.SelectedOptions,
+		},
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 )
@@ -32,8 +34,9 @@ func getReceiptTypeDescription(evt types.ReceiptType) string {
 	}
 }
 
-// createReceiptPayload creates a webhook payload for message acknowledgement (receipt) events
-func createReceiptPayload(evt *events.Receipt) map[string]any {
+// createReceiptPayload creates a webhook payload for message acknowledgement (receipt) events. sender holds both
+// forms of the user the receipt came from.
+func createReceiptPayload(evt *events.Receipt, sender utils.JIDPair) map[string]any {
 	body := make(map[string]any)
 
 	// Create payload structure matching the expected format
@@ -47,6 +50,8 @@ func createReceiptPayload(evt *events.Receipt) map[string]any {
 	// Add from field (the chat where the message was sent)
 	payload["chat_id"] = evt.Chat
 	payload["sender_id"] = evt.Sender
+	payload["sender_pn"] = sender.PN.String()
+	payload["sender_lid"] = sender.LID.String()
 	payload["from"] = evt.SourceString()
 
 	if evt.Type == types.ReceiptTypeDelivered {
@@ -67,7 +72,8 @@ func createReceiptPayload(evt *events.Receipt) map[string]any {
 }
 
 // forwardReceiptToWebhook forwards message acknowledgement events to the configured webhook URLs
-func forwardReceiptToWebhook(ctx context.Context, agentID string, evt *events.Receipt) error {
-	payload := createReceiptPayload(evt)
+func forwardReceiptToWebhook(ctx context.Context, agentID string, evt *events.Receipt, client *whatsmeow.Client) error {
+	sender := completeJIDPair(ctx, client, utils.MessageSenderJIDs(evt.MessageSource))
+	payload := createReceiptPayload(evt, sender)
 	return forwardPayloadToConfiguredWebhooks(ctx, payload, "message ack event", agentID)
 }
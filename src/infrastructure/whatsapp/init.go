@@ -464,7 +464,7 @@ func handler(ctx context.Context, rawEvt any, chatStorageRepo domainChatStorage.
 	}
 	switch evt := rawEvt.(type) {
 	case *events.DeleteForMe:
-		handleDeleteForMe(ctx, agentID, evt, chatStorageRepo)
+		handleDeleteForMe(ctx, agentID, evt, chatStorageRepo, client)
 	case *events.AppStateSyncComplete:
 		handleAppStateSyncComplete(ctx, evt)
 	case *events.PairSuccess:
@@ -478,21 +478,26 @@ func handler(ctx context.Context, rawEvt any, chatStorageRepo domainChatStorage.
 	case *events.Message:
 		handleMessage(ctx, agentID, evt, chatStorageRepo, client)
 	case *events.Receipt:
-		handleReceipt(ctx, agentID, evt)
+		handleReceipt(ctx, agentID, evt, chatStorageRepo, client)
 	case *events.Presence:
 		handlePresence(ctx, evt)
 	case *events.HistorySync:
-		handleHistorySync(ctx, agentID, evt, chatStorageRepo)
+		handleHistorySync(ctx, agentID, evt, chatStorageRepo, client)
 	case *events.AppState:
 		handleAppState(ctx, evt)
+	case *events.Archive, *events.Mute, *events.MarkChatAsRead, *events.ClearChat, *events.DeleteChat:
+		handleChatStateEvent(ctx, evt, chatStorageRepo)
 	case *events.GroupInfo:
-		handleGroupInfo(ctx, agentID, evt)
+		handleJoinRequests(ctx, agentID, evt, chatStorageRepo, client)
+		handleGroupInfo(ctx, agentID, evt, client)
+	case *events.Blocklist:
+		handleBlocklist(ctx, agentID, evt, client)
 	}
 }
 
 // Event handler functions
 
-func handleDeleteForMe(ctx context.Context, agentID string, evt *events.DeleteForMe, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+func handleDeleteForMe(ctx context.Context, agentID string, evt *events.DeleteForMe, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
 	log.Infof("Deleted message %s for %s", evt.MessageID, evt.SenderJID.String())
 
 	// Find the message to get its chat JID
@@ -516,7 +521,7 @@ func handleDeleteForMe(ctx context.Context, agentID string, evt *events.DeleteFo
 
 	// Send webhook notification for delete event
 	go func() {
-		if err := forwardDeleteToWebhook(ctx, agentID, evt, message); err != nil {
+		if err := forwardDeleteToWebhook(ctx, agentID, evt, message, client); err != nil {
 			log.Errorf("Failed to forward delete event to webhook: %v", err)
 		}
 	}()
@@ -594,16 +599,28 @@ func handleMessage(ctx context.Context, agentID string, evt *events.Message, cha
 		evt.Message,
 	)
 
+	// Storage and webhooks record the sender in both its phone number and LID forms
+	completeMessageSource(ctx, client, &evt.Info.MessageSource)
+
 	if err := chatStorageRepo.CreateMessage(ctx, evt); err != nil {
 		// Log storage errors to avoid silent failures that could lead to data loss
 		log.Errorf("Failed to store incoming message %s: %v", evt.Info.ID, err)
 	}
 
+	// Decrypt and tally poll votes
+	handlePollVote(ctx, agentID, evt, chatStorageRepo, client)
+
+	// Track messages pinned or unpinned in the chat
+	handlePinMessage(ctx, agentID, evt, chatStorageRepo, client)
+
+	// Enforce the moderation rules of the group
+	handleGroupModeration(ctx, agentID, evt, chatStorageRepo, client)
+
 	// Handle image message if present
 	handleImageMessage(ctx, evt)
 
 	// Auto-mark message as read if configured
-	handleAutoMarkRead(ctx, evt)
+	handleAutoMarkRead(ctx, evt, chatStorageRepo)
 
 	// Handle auto-reply if configured
 	handleAutoReply(ctx, evt, chatStorageRepo)
@@ -647,7 +664,7 @@ func handleImageMessage(ctx context.Context, evt *events.Message) {
 	}
 }
 
-func handleAutoMarkRead(_ context.Context, evt *events.Message) {
+func handleAutoMarkRead(_ context.Context, evt *events.Message, chatStorageRepo domainChatStorage.IChatStorageRepository) {
 	// Only mark read if auto-mark read is enabled and message is incoming
 	if !config.WhatsappAutoMarkRead || evt.Info.IsFromMe {
 		return
@@ -663,6 +680,9 @@ func handleAutoMarkRead(_ context.Context, evt *events.Message) {
 		log.Warnf("Failed to mark message %s as read: %v", evt.Info.ID, err)
 	} else {
 		log.Debugf("Marked message %s as read", evt.Info.ID)
+		if err := chatStorageRepo.MarkChatRead(utils.MessageChatJID(evt.Info.MessageSource).String(), messageIDs, timestamp); err != nil {
+			log.Warnf("Failed to store read state of chat %s: %v", chat, err)
+		}
 	}
 }
 
@@ -736,11 +756,8 @@ func handleAutoReply(ctx context.Context, evt *events.Message, chatStorageRepo d
 	recipientJID := utils.FormatJID(evt.Info.Sender.String())
 
 	// Send the auto-reply message
-	response, err := cli.SendMessage(
-		ctx,
-		recipientJID,
-		&waE2E.Message{Conversation: proto.String(config.WhatsappAutoReplyMessage)},
-	)
+	reply := &waE2E.Message{Conversation: proto.String(config.WhatsappAutoReplyMessage)}
+	response, err := cli.SendMessage(ctx, recipientJID, reply)
 
 	if err != nil {
 		log.Errorf("Failed to send auto-reply message: %v", err)
@@ -754,15 +771,20 @@ func handleAutoReply(ctx context.Context, evt *events.Message, chatStorageRepo d
 		if cli.Store.ID != nil {
 			senderJID = cli.Store.ID.String()
 		}
+		// Keep the reply in the phone number chat the incoming message was stored under
+		chatJID, chatLID := ChatJIDs(ctx, cli, recipientJID)
 
 		// Store the sent auto-reply message
 		if err := chatStorageRepo.StoreSentMessageWithContext(
 			ctx,
 			response.ID,                     // Message ID from WhatsApp response
 			senderJID,                       // Our JID as sender
-			recipientJID.String(),           // Recipient JID
+			cli.Store.LID.String(),          // Our LID, when known
+			chatJID.String(),                // Recipient chat JID
+			chatLID.String(),                // Recipient LID, when known
 			config.WhatsappAutoReplyMessage, // Auto-reply content
 			response.Timestamp,              // Timestamp from response
+			reply,                           // Sent message
 		); err != nil {
 			// Log storage error but don't fail the auto-reply
 			log.Errorf("Failed to store auto-reply message in chat storage: %v", err)
@@ -792,12 +814,20 @@ func handleWebhookForward(ctx context.Context, agentID string, evt *events.Messa
 	}
 }
 
-func handleReceipt(ctx context.Context, agentID string, evt *events.Receipt) {
+func handleReceipt(ctx context.Context, agentID string, evt *events.Receipt, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
 	sendReceipt := false
 	switch evt.Type {
 	case types.ReceiptTypeRead, types.ReceiptTypeReadSelf:
 		sendReceipt = true
 		log.Infof("%v was read by %s at %s: %+v", evt.MessageIDs, evt.SourceString(), evt.Timestamp, evt)
+		// A read-self receipt means the chat was read on another of our devices
+		if evt.Type == types.ReceiptTypeReadSelf && chatStorageRepo != nil {
+			// Direct chats are stored under the phone number JID
+			chat := preferPhoneJID(ctx, client, evt.Chat)
+			if err := chatStorageRepo.MarkChatRead(chat.String(), evt.MessageIDs, evt.Timestamp); err != nil {
+				log.Warnf("Failed to store read state of chat %s: %v", evt.Chat, err)
+			}
+		}
 	case types.ReceiptTypeDelivered:
 		sendReceipt = true
 		log.Infof("%s was delivered to %s at %s: %+v", evt.MessageIDs[0], evt.SourceString(), evt.Timestamp, evt)
@@ -807,7 +837,7 @@ func handleReceipt(ctx context.Context, agentID string, evt *events.Receipt) {
 	// Note: Receipt events are not rate limited as they are critical for message delivery status
 	if sendReceipt {
 		go func(e *events.Receipt) {
-			if err := forwardReceiptToWebhook(ctx, agentID, e); err != nil {
+			if err := forwardReceiptToWebhook(ctx, agentID, e, client); err != nil {
 				logrus.Errorf("Failed to forward ack event to webhook: %v", err)
 			}
 		}(evt)
@@ -826,7 +856,7 @@ func handlePresence(_ context.Context, evt *events.Presence) {
 	}
 }
 
-func handleHistorySync(ctx context.Context, agentID string, evt *events.HistorySync, chatStorageRepo domainChatStorage.IChatStorageRepository) {
+func handleHistorySync(ctx context.Context, agentID string, evt *events.HistorySync, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) {
 	id := atomic.AddInt32(&historySyncID, 1)
 	storeID := agentID
 	if cli != nil && cli.Store != nil && cli.Store.ID != nil {
@@ -858,7 +888,7 @@ func handleHistorySync(ctx context.Context, agentID string, evt *events.HistoryS
 
 	// Process history sync data to database
 	if chatStorageRepo != nil {
-		if err := processHistorySync(ctx, evt.Data, chatStorageRepo); err != nil {
+		if err := processHistorySync(ctx, evt.Data, chatStorageRepo, client); err != nil {
 			log.Errorf("Failed to process history sync to database: %v", err)
 		}
 	}
@@ -869,7 +899,7 @@ func handleAppState(_ context.Context, evt *events.AppState) {
 }
 
 // processHistorySync processes history sync data and stores messages in the database
-func processHistorySync(ctx context.Context, data *waHistorySync.HistorySync, chatStorageRepo domainChatStorage.IChatStorageRepository) error {
+func processHistorySync(ctx context.Context, data *waHistorySync.HistorySync, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) error {
 	if data == nil {
 		return nil
 	}
@@ -880,7 +910,7 @@ func processHistorySync(ctx context.Context, data *waHistorySync.HistorySync, ch
 	switch syncType {
 	case waHistorySync.HistorySync_INITIAL_BOOTSTRAP, waHistorySync.HistorySync_RECENT:
 		// Process conversation messages
-		return processConversationMessages(ctx, data, chatStorageRepo)
+		return processConversationMessages(ctx, data, chatStorageRepo, client)
 	case waHistorySync.HistorySync_PUSH_NAME:
 		// Process push names to update chat names
 		return processPushNames(ctx, data, chatStorageRepo)
@@ -892,7 +922,7 @@ func processHistorySync(ctx context.Context, data *waHistorySync.HistorySync, ch
 }
 
 // processConversationMessages processes and stores conversation messages from history sync
-func processConversationMessages(_ context.Context, data *waHistorySync.HistorySync, chatStorageRepo domainChatStorage.IChatStorageRepository) error {
+func processConversationMessages(ctx context.Context, data *waHistorySync.HistorySync, chatStorageRepo domainChatStorage.IChatStorageRepository, client *whatsmeow.Client) error {
 	conversations := data.GetConversations()
 	log.Infof("Processing %d conversations from history sync", len(conversations))
 
@@ -909,6 +939,15 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 			continue
 		}
 
+		// Direct chats are stored under the phone number JID when it is known, like live messages
+		pnJID, _ := types.ParseJID(conv.GetPnJID())
+		lidJID, _ := types.ParseJID(conv.GetLidJID())
+		chatJIDs := completeJIDPair(ctx, client, utils.NewJIDPair(jid, pnJID, lidJID))
+		if jid.Server == types.HiddenUserServer && !chatJIDs.PN.IsEmpty() {
+			jid = chatJIDs.PN
+			chatJID = jid.String()
+		}
+
 		displayName := conv.GetDisplayName()
 
 		// Get or create chat
@@ -953,11 +992,12 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 
 			// Determine sender
 			sender := ""
+			senderLID := ""
 			isFromMe := msgKey.GetFromMe()
 			if isFromMe {
 				// For self-messages, use the full JID format to match regular message processing
-				if cli.Store.ID != nil {
-					sender = cli.Store.ID.String() // Use full JID instead of just User part
+				if client != nil && client.Store.ID != nil {
+					sender = client.Store.ID.String() // Use full JID instead of just User part
 				} else {
 					// Skip messages where we can't determine the sender to avoid NOT NULL violations
 					log.Warnf("Skipping self-message %s: client ID unavailable", messageID)
@@ -969,6 +1009,11 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 					// For group messages, participant contains the actual sender
 					if senderJID, err := types.ParseJID(participant); err == nil {
 						sender = senderJID.String() // Use full JID format for consistency
+						senderJIDs := ResolveJID(ctx, client, senderJID)
+						if senderJID.Server == types.HiddenUserServer && !senderJIDs.PN.IsEmpty() {
+							sender = senderJIDs.PN.String()
+						}
+						senderLID = senderJIDs.LID.String()
 					} else {
 						// Fallback to participant string, but ensure it's not empty
 						if participant != "" {
@@ -981,6 +1026,7 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 				} else {
 					// For individual chats, use the chat JID as sender with full format
 					sender = jid.String() // Use full JID format for consistency
+					senderLID = chatJIDs.LID.String()
 				}
 			}
 
@@ -998,6 +1044,7 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 				ID:            messageID,
 				ChatJID:       chatJID,
 				Sender:        sender,
+				SenderLID:     senderLID,
 				Content:       content,
 				Timestamp:     timestamp,
 				IsFromMe:      isFromMe,
@@ -1009,6 +1056,8 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 				FileEncSHA256: fileEncSHA256,
 				FileLength:    fileLength,
 			}
+			message.Mimetype, message.DirectPath = utils.ExtractMediaDetails(msg.GetMessage())
+			message.ForwardingScore = utils.ExtractForwardingScore(msg.GetMessage())
 
 			messageBatch = append(messageBatch, message)
 		}
@@ -1034,6 +1083,15 @@ func processConversationMessages(_ context.Context, data *waHistorySync.HistoryS
 			} else {
 				log.Debugf("Stored %d messages for chat %s", len(messageBatch), chatJID)
 			}
+
+			// The phone's unread state is authoritative for history it sends
+			unreadCount := int(conv.GetUnreadCount())
+			if err := chatStorageRepo.UpdateChatState(chatJID, domainChatStorage.ChatStateUpdate{
+				UnreadCount:  &unreadCount,
+				MarkedUnread: proto.Bool(conv.GetMarkedAsUnread()),
+			}); err != nil {
+				log.Warnf("Failed to store unread state for chat %s: %v", chatJID, err)
+			}
 		}
 	}
 
@@ -1074,7 +1132,7 @@ func processPushNames(_ context.Context, data *waHistorySync.HistorySync, chatSt
 	return nil
 }
 
-func handleGroupInfo(ctx context.Context, agentID string, evt *events.GroupInfo) {
+func handleGroupInfo(ctx context.Context, agentID string, evt *events.GroupInfo, client *whatsmeow.Client) {
 	// Only process events that have actual changes
 	hasChanges := len(evt.Join) > 0 || len(evt.Leave) > 0 || len(evt.Promote) > 0 || len(evt.Demote) > 0 ||
 		evt.Name != nil || evt.Topic != nil || evt.Locked != nil || evt.Announce != nil
@@ -1099,7 +1157,7 @@ func handleGroupInfo(ctx context.Context, agentID string, evt *events.GroupInfo)
 
 	// Forward group info event to webhook if configured
 	go func(e *events.GroupInfo) {
-		if err := forwardGroupInfoToWebhook(ctx, agentID, e); err != nil {
+		if err := forwardGroupInfoToWebhook(ctx, agentID, e, client); err != nil {
 			logrus.Errorf("Failed to forward group info event to webhook: %v", err)
 		}
 	}(evt)
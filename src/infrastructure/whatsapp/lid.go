@@ -0,0 +1,99 @@
+package whatsapp
+
+import (
+	"context"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// ResolveJID returns both forms of a user JID, looking the missing one up in the LID mapping of the device store.
+// Both forms are empty for JIDs that are not users, such as groups.
+func ResolveJID(ctx context.Context, client *whatsmeow.Client, jid types.JID) utils.JIDPair {
+	return completeJIDPair(ctx, client, utils.NewJIDPair(jid))
+}
+
+// completeJIDPair fills the missing form of a pair from the LID mapping. The mapping is learned from messages
+// and user info queries, so a form that was never seen stays empty.
+func completeJIDPair(ctx context.Context, client *whatsmeow.Client, pair utils.JIDPair) utils.JIDPair {
+	if client == nil || client.Store == nil || client.Store.LIDs == nil {
+		return pair
+	}
+	switch {
+	case pair.PN.IsEmpty() && !pair.LID.IsEmpty():
+		if pn, err := client.Store.LIDs.GetPNForLID(ctx, pair.LID); err == nil && !pn.IsEmpty() {
+			pair.PN = pn.ToNonAD()
+		}
+	case pair.LID.IsEmpty() && !pair.PN.IsEmpty():
+		if lid, err := client.Store.LIDs.GetLIDForPN(ctx, pair.PN); err == nil && !lid.IsEmpty() {
+			pair.LID = lid.ToNonAD()
+		}
+	}
+	return pair
+}
+
+// completeMessageSource fills in the alternative addresses WhatsApp left out of a message from the LID mapping,
+// so storage and webhooks see the sender and a direct chat in both forms
+func completeMessageSource(ctx context.Context, client *whatsmeow.Client, source *types.MessageSource) {
+	if source.SenderAlt.IsEmpty() {
+		sender := completeJIDPair(ctx, client, utils.MessageSenderJIDs(*source))
+		switch source.Sender.Server {
+		case types.DefaultUserServer:
+			source.SenderAlt = sender.LID
+		case types.HiddenUserServer:
+			source.SenderAlt = sender.PN
+		}
+	}
+	if source.IsFromMe && !source.IsGroup && source.Chat.Server == types.HiddenUserServer && source.RecipientAlt.IsEmpty() {
+		source.RecipientAlt = ResolveJID(ctx, client, source.Chat).PN
+	}
+}
+
+// resolveJIDs resolves each JID of a list, keeping the order
+func resolveJIDs(ctx context.Context, client *whatsmeow.Client, jids []types.JID) []utils.JIDPair {
+	pairs := make([]utils.JIDPair, len(jids))
+	for i, jid := range jids {
+		pairs[i] = ResolveJID(ctx, client, jid)
+	}
+	return pairs
+}
+
+// preferPhoneJID resolves a LID sender to its phone number JID, so the same person is recorded once whether they
+// act from a LID or not
+func preferPhoneJID(ctx context.Context, client *whatsmeow.Client, sender types.JID) types.JID {
+	if pn := ResolveJID(ctx, client, sender).PN; !pn.IsEmpty() {
+		return pn
+	}
+	return sender.ToNonAD()
+}
+
+// preferredJID picks the phone number form of a resolved user, falling back to the JID it was resolved from when
+// neither form is known
+func preferredJID(pair utils.JIDPair, jid types.JID) types.JID {
+	if preferred := pair.Preferred(); !preferred.IsEmpty() {
+		return preferred
+	}
+	return jid.ToNonAD()
+}
+
+// jidPairPayload describes both forms of a user for webhook payloads and responses
+func jidPairPayload(pair utils.JIDPair) map[string]string {
+	return map[string]string{
+		"pn":  pair.PN.String(),
+		"lid": pair.LID.String(),
+	}
+}
+
+// ChatJIDs returns the JID a direct chat is stored under, which is the phone number JID when it is known, together
+// with the LID of the user. Groups and other chats are returned as they are, without a LID.
+func ChatJIDs(ctx context.Context, client *whatsmeow.Client, jid types.JID) (chat types.JID, lid types.JID) {
+	pair := ResolveJID(ctx, client, jid)
+	if pair.PN.IsEmpty() && pair.LID.IsEmpty() {
+		return jid, types.EmptyJID
+	}
+	if !pair.PN.IsEmpty() {
+		return pair.PN, pair.LID
+	}
+	return pair.LID, pair.LID
+}
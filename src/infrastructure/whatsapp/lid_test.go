@@ -0,0 +1,119 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// fakeLIDStore knows a fixed set of mappings; methods the resolver does not use panic through the nil interface
+type fakeLIDStore struct {
+	store.LIDStore
+	pnByLID map[types.JID]types.JID
+}
+
+func (f fakeLIDStore) GetPNForLID(_ context.Context, lid types.JID) (types.JID, error) {
+	return f.pnByLID[lid.ToNonAD()], nil
+}
+
+func (f fakeLIDStore) GetLIDForPN(_ context.Context, pn types.JID) (types.JID, error) {
+	for lid, mapped := range f.pnByLID {
+		if mapped == pn.ToNonAD() {
+			return lid, nil
+		}
+	}
+	return types.JID{}, nil
+}
+
+var (
+	testPN  = types.NewJID("6281234567890", types.DefaultUserServer)
+	testLID = types.NewJID("123456789012345", types.HiddenUserServer)
+)
+
+func newLIDTestClient() *whatsmeow.Client {
+	return &whatsmeow.Client{Store: &store.Device{LIDs: fakeLIDStore{pnByLID: map[types.JID]types.JID{testLID: testPN}}}}
+}
+
+func TestResolveJID(t *testing.T) {
+	ctx := context.Background()
+	client := newLIDTestClient()
+	want := utils.JIDPair{PN: testPN, LID: testLID}
+
+	lidDevice := testLID
+	lidDevice.Device = 4
+	if got := ResolveJID(ctx, client, lidDevice); got != want {
+		t.Errorf("ResolveJID(lid) = %+v, want %+v", got, want)
+	}
+	if got := ResolveJID(ctx, client, testPN); got != want {
+		t.Errorf("ResolveJID(pn) = %+v, want %+v", got, want)
+	}
+	unknown := types.NewJID("447700900123", types.DefaultUserServer)
+	if got := ResolveJID(ctx, client, unknown); got != (utils.JIDPair{PN: unknown}) {
+		t.Errorf("ResolveJID(unknown) = %+v, want only the phone number", got)
+	}
+	if got := ResolveJID(ctx, nil, testLID); got != (utils.JIDPair{LID: testLID}) {
+		t.Errorf("ResolveJID() without client = %+v, want only the LID", got)
+	}
+	if got := preferPhoneJID(ctx, client, testLID); got != testPN {
+		t.Errorf("preferPhoneJID() = %v, want %v", got, testPN)
+	}
+}
+
+func TestCompleteMessageSource(t *testing.T) {
+	source := types.MessageSource{Chat: testLID, Sender: testLID}
+	completeMessageSource(context.Background(), newLIDTestClient(), &source)
+	if source.SenderAlt != testPN {
+		t.Errorf("SenderAlt = %v, want %v", source.SenderAlt, testPN)
+	}
+	if got := utils.MessageChatJID(source); got != testPN {
+		t.Errorf("MessageChatJID() = %v, want the direct chat under %v", got, testPN)
+	}
+
+	own := types.MessageSource{Chat: testLID, Sender: types.NewJID("6289999999999", types.DefaultUserServer), IsFromMe: true}
+	completeMessageSource(context.Background(), newLIDTestClient(), &own)
+	if own.RecipientAlt != testPN {
+		t.Errorf("RecipientAlt = %v, want %v", own.RecipientAlt, testPN)
+	}
+}
+
+func TestCreateGroupInfoPayloadParticipants(t *testing.T) {
+	evt := &events.GroupInfo{JID: types.NewJID("120363000000000000", types.GroupServer), Join: []types.JID{testLID}}
+	payload := createGroupInfoPayload(evt, "join", evt.Join, resolveJIDs(context.Background(), newLIDTestClient(), evt.Join))
+
+	body := payload["payload"].(map[string]any)
+	participants := body["participants"].([]map[string]string)
+	if len(participants) != 1 || participants[0]["pn"] != testPN.String() || participants[0]["lid"] != testLID.String() {
+		t.Errorf("participants = %v, want both forms of the joined user", participants)
+	}
+	if jids := body["jids"].([]string); len(jids) != 1 || jids[0] != testLID.String() {
+		t.Errorf("jids = %v, want the JIDs as sent by WhatsApp", jids)
+	}
+}
+
+func TestCreateDeletePayloadSender(t *testing.T) {
+	evt := &events.DeleteForMe{MessageID: "3EB0A", SenderJID: testLID}
+	sender := ResolveJID(context.Background(), newLIDTestClient(), evt.SenderJID)
+	body, err := createDeletePayload(context.Background(), evt, nil, sender)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body["from"] != testPN.String() || body["sender_pn"] != testPN.String() || body["sender_lid"] != testLID.String() {
+		t.Errorf("payload = %v, want the sender in both forms with from as the phone number", body)
+	}
+}
+
+func TestCreateMessagePinPayloadActor(t *testing.T) {
+	actor := ResolveJID(context.Background(), newLIDTestClient(), testLID)
+	payload := createMessagePinPayload("120363000000000000@g.us", "3EB0B", preferredJID(actor, testLID).String(), actor, time.Now(), nil)
+
+	body := payload["payload"].(map[string]any)
+	if body["pinned_by"] != testPN.String() || body["pinned_by_lid"] != testLID.String() {
+		t.Errorf("payload = %v, want the actor in both forms", body)
+	}
+}
@@ -0,0 +1,63 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// whatsmeow creates, follows and mutes channels, but has no call to edit or delete one. These are the GraphQL
+// queries WhatsApp Web uses for that, sent the same way whatsmeow sends its own channel queries.
+const (
+	mutationUpdateNewsletter = "7150902998257522"
+	mutationDeleteNewsletter = "8316537688430079"
+)
+
+// NewsletterUpdate holds the channel fields to change; nil fields are left as they are
+type NewsletterUpdate struct {
+	Name        *string
+	Description *string
+	Picture     []byte
+}
+
+// UpdateNewsletter changes the name, description or picture of a channel we own
+func UpdateNewsletter(ctx context.Context, client *whatsmeow.Client, jid types.JID, update NewsletterUpdate) (*types.NewsletterMetadata, error) {
+	updates := map[string]any{"settings": nil}
+	if update.Name != nil {
+		updates["name"] = *update.Name
+	}
+	if update.Description != nil {
+		updates["description"] = *update.Description
+	}
+	if update.Picture != nil {
+		updates["picture"] = base64.StdEncoding.EncodeToString(update.Picture)
+	}
+
+	data, err := client.DangerousInternals().SendMexIQ(ctx, mutationUpdateNewsletter, map[string]any{
+		"newsletter_id": jid.String(),
+		"updates":       updates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Newsletter *types.NewsletterMetadata `json:"xwa2_newsletter_update"`
+	}
+	if err = json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("invalid channel update response: %w", err)
+	}
+	return resp.Newsletter, nil
+}
+
+// DeleteNewsletter deletes a channel we own, for all of its followers
+func DeleteNewsletter(ctx context.Context, client *whatsmeow.Client, jid types.JID) error {
+	_, err := client.DangerousInternals().SendMexIQ(ctx, mutationDeleteNewsletter, map[string]any{
+		"newsletter_id": jid.String(),
+	})
+	return err
+}
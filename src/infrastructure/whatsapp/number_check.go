@@ -0,0 +1,119 @@
+package whatsapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// numberCheckChunkSize is how many numbers are checked per IsOnWhatsApp query
+const numberCheckChunkSize = 50
+
+// CheckNumbers tells for each phone number, given as digits in international format, whether it is on WhatsApp.
+// Checks younger than the configured TTL are answered from chat storage; the others are queried in chunks and
+// stored. A chunk that fails does not stop the others: its numbers are missing from the result and the failure
+// is returned as the error.
+func CheckNumbers(ctx context.Context, client *whatsmeow.Client, repo domainChatStorage.IChatStorageRepository, phones []string) (map[string]*domainChatStorage.WhatsAppNumber, error) {
+	numbers := make(map[string]*domainChatStorage.WhatsAppNumber, len(phones))
+
+	if repo != nil && config.WhatsappNumberCheckTTL > 0 {
+		cached, err := repo.GetWhatsAppNumbers(phones, time.Now().Add(-config.WhatsappNumberCheckTTL))
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to read cached number checks, checking all numbers on WhatsApp")
+		}
+		for _, number := range cached {
+			numbers[number.Phone] = number
+		}
+	}
+
+	var pending []string
+	for _, phone := range phones {
+		if _, ok := numbers[phone]; !ok {
+			pending = append(pending, phone)
+		}
+	}
+
+	var errs []error
+	for start := 0; start < len(pending); start += numberCheckChunkSize {
+		chunk := pending[start:min(start+numberCheckChunkSize, len(pending))]
+
+		checked, err := queryNumbers(ctx, client, chunk)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to check %d numbers on WhatsApp: %w", len(chunk), err))
+			continue
+		}
+		for _, number := range checked {
+			numbers[number.Phone] = number
+		}
+
+		if repo != nil && config.WhatsappNumberCheckTTL > 0 {
+			if err := repo.StoreWhatsAppNumbers(checked); err != nil {
+				logrus.WithError(err).Warn("Failed to cache number checks")
+			}
+		}
+	}
+
+	return numbers, errors.Join(errs...)
+}
+
+// IsNumberOnWhatsApp checks one phone number through the same cache as CheckNumbers
+func IsNumberOnWhatsApp(ctx context.Context, client *whatsmeow.Client, repo domainChatStorage.IChatStorageRepository, phone string) (bool, error) {
+	numbers, err := CheckNumbers(ctx, client, repo, []string{phone})
+	if err != nil {
+		return false, err
+	}
+	number, ok := numbers[phone]
+	return ok && number.IsRegistered, nil
+}
+
+// queryNumbers checks one chunk of numbers on WhatsApp. Every number gets a result; numbers WhatsApp does not
+// answer for are not registered.
+func queryNumbers(ctx context.Context, client *whatsmeow.Client, phones []string) ([]*domainChatStorage.WhatsAppNumber, error) {
+	queries := make([]string, len(phones))
+	for i, phone := range phones {
+		queries[i] = "+" + phone
+	}
+
+	responses, err := client.IsOnWhatsApp(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	byQuery := make(map[string]types.IsOnWhatsAppResponse, len(responses))
+	for _, info := range responses {
+		byQuery[info.Query] = info
+	}
+
+	checkedAt := time.Now()
+	numbers := make([]*domainChatStorage.WhatsAppNumber, 0, len(phones))
+	for i, phone := range phones {
+		number := toWhatsAppNumber(phone, byQuery[queries[i]], checkedAt)
+		if number.IsRegistered && client.Store != nil && client.Store.LIDs != nil {
+			if lid, err := client.Store.LIDs.GetLIDForPN(ctx, types.NewJID(phone, types.DefaultUserServer)); err == nil && !lid.IsEmpty() {
+				number.LID = lid.String()
+			}
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+func toWhatsAppNumber(phone string, info types.IsOnWhatsAppResponse, checkedAt time.Time) *domainChatStorage.WhatsAppNumber {
+	number := &domainChatStorage.WhatsAppNumber{
+		Phone:        phone,
+		IsRegistered: info.IsIn,
+		CheckedAt:    checkedAt,
+	}
+	if info.IsIn {
+		number.JID = info.JID.ToNonAD().String()
+		number.IsBusiness = info.VerifiedName != nil
+	}
+	return number
+}
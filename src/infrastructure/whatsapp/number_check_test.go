@@ -0,0 +1,70 @@
+package whatsapp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// numberCacheRepo serves number checks from memory; the other repository methods are not used
+type numberCacheRepo struct {
+	domainChatStorage.IChatStorageRepository
+	numbers map[string]*domainChatStorage.WhatsAppNumber
+	since   time.Time
+}
+
+func (r *numberCacheRepo) GetWhatsAppNumbers(phones []string, checkedSince time.Time) ([]*domainChatStorage.WhatsAppNumber, error) {
+	r.since = checkedSince
+	var found []*domainChatStorage.WhatsAppNumber
+	for _, phone := range phones {
+		if number, ok := r.numbers[phone]; ok && !number.CheckedAt.Before(checkedSince) {
+			found = append(found, number)
+		}
+	}
+	return found, nil
+}
+
+func TestCheckNumbersFromCache(t *testing.T) {
+	repo := &numberCacheRepo{numbers: map[string]*domainChatStorage.WhatsAppNumber{
+		"6281234567890": {Phone: "6281234567890", IsRegistered: true, JID: "6281234567890@s.whatsapp.net", CheckedAt: time.Now()},
+		"6289999999999": {Phone: "6289999999999", CheckedAt: time.Now()},
+	}}
+
+	// Every number is cached, so WhatsApp is not asked and no client is needed
+	numbers, err := CheckNumbers(context.Background(), nil, repo, []string{"6281234567890", "6289999999999"})
+	if err != nil {
+		t.Fatalf("CheckNumbers() error = %v", err)
+	}
+	if len(numbers) != 2 || !numbers["6281234567890"].IsRegistered || numbers["6289999999999"].IsRegistered {
+		t.Errorf("CheckNumbers() = %v", numbers)
+	}
+	if time.Since(repo.since) < 23*time.Hour {
+		t.Errorf("cache was read from %s, want the default TTL of 24h", repo.since)
+	}
+
+	registered, err := IsNumberOnWhatsApp(context.Background(), nil, repo, "6281234567890")
+	if err != nil || !registered {
+		t.Errorf("IsNumberOnWhatsApp() = (%v, %v), want true", registered, err)
+	}
+}
+
+func TestToWhatsAppNumber(t *testing.T) {
+	checkedAt := time.Now()
+	number := toWhatsAppNumber("6281234567890", types.IsOnWhatsAppResponse{
+		JID:          types.NewJID("6281234567890", types.DefaultUserServer),
+		IsIn:         true,
+		VerifiedName: &types.VerifiedName{},
+	}, checkedAt)
+	if !number.IsRegistered || !number.IsBusiness || number.JID != "6281234567890@s.whatsapp.net" || number.CheckedAt != checkedAt {
+		t.Errorf("toWhatsAppNumber() = %+v", number)
+	}
+
+	// A number WhatsApp did not answer for is not registered
+	missing := toWhatsAppNumber("6289999999999", types.IsOnWhatsAppResponse{}, checkedAt)
+	if missing.IsRegistered || missing.JID != "" {
+		t.Errorf("toWhatsAppNumber() of a missing answer = %+v", missing)
+	}
+}
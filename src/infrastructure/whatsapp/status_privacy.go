@@ -0,0 +1,36 @@
+package whatsapp
+
+import (
+	"context"
+
+	"go.mau.fi/whatsmeow"
+	waBinary "go.mau.fi/whatsmeow/binary"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// SetStatusPrivacy changes who receives our statuses. whatsmeow can read the status privacy but not change it, so
+// this sends the same query WhatsApp clients use. Statuses are fanned out according to this setting when posted.
+func SetStatusPrivacy(ctx context.Context, client *whatsmeow.Client, privacy types.StatusPrivacy) error {
+	_, err := client.DangerousInternals().SendIQ(ctx, whatsmeow.DangerousInfoQuery{
+		Namespace: "status",
+		Type:      "set",
+		To:        types.ServerJID,
+		Content:   []waBinary.Node{buildStatusPrivacyNode(privacy)},
+	})
+	return err
+}
+
+func buildStatusPrivacyNode(privacy types.StatusPrivacy) waBinary.Node {
+	list := waBinary.Node{
+		Tag:   "list",
+		Attrs: waBinary.Attrs{"type": string(privacy.Type)},
+	}
+	if privacy.Type != types.StatusPrivacyTypeContacts {
+		users := make([]waBinary.Node, 0, len(privacy.List))
+		for _, jid := range privacy.List {
+			users = append(users, waBinary.Node{Tag: "user", Attrs: waBinary.Attrs{"jid": jid.ToNonAD()}})
+		}
+		list.Content = users
+	}
+	return waBinary.Node{Tag: "privacy", Content: []waBinary.Node{list}}
+}
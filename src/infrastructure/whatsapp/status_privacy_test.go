@@ -0,0 +1,28 @@
+package whatsapp
+
+import (
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestBuildStatusPrivacyNode(t *testing.T) {
+	node := buildStatusPrivacyNode(types.StatusPrivacy{
+		Type: types.StatusPrivacyTypeWhitelist,
+		List: []types.JID{types.NewADJID("6289685028129", 0, 3)},
+	})
+
+	lists := node.GetChildren()
+	if node.Tag != "privacy" || len(lists) != 1 || lists[0].Tag != "list" || lists[0].Attrs["type"] != "whitelist" {
+		t.Fatalf("unexpected privacy node: %v", node)
+	}
+	users := lists[0].GetChildren()
+	if len(users) != 1 || users[0].Tag != "user" || users[0].Attrs["jid"] != types.NewJID("6289685028129", types.DefaultUserServer) {
+		t.Errorf("users = %v, want the allowed contact without device", users)
+	}
+
+	contacts := buildStatusPrivacyNode(types.StatusPrivacy{Type: types.StatusPrivacyTypeContacts, List: []types.JID{types.NewJID("1", types.DefaultUserServer)}})
+	if children := contacts.GetChildren()[0].GetChildren(); len(children) != 0 {
+		t.Errorf("contacts audience carries users %v", children)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"syscall"
 	"time"
 
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -14,9 +16,43 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// callbackClient is used for URLs chosen by API callers. It refuses to connect to anything but public addresses,
+// checked on the resolved IP so a hostname cannot be pointed at an internal service, and ignores proxy settings
+// so that check applies to the real target.
+var callbackClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		Proxy: nil,
+		DialContext: (&net.Dialer{
+			Timeout: 10 * time.Second,
+			Control: func(_, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if !utils.IsPublicIP(net.ParseIP(host)) {
+					return fmt.Errorf("refusing to connect to non-public address %s", host)
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+}
+
+// SubmitCallback delivers a payload to a single caller-provided URL, signed with the given secret. Only public
+// addresses are reached.
+func SubmitCallback(ctx context.Context, payload map[string]any, url, secret string) error {
+	return postWebhook(ctx, callbackClient, payload, url, secret)
+}
+
 func submitWebhook(ctx context.Context, payload map[string]any, url, secret string) error {
-	client := &http.Client{Timeout: 10 * time.Second}
+	return postWebhook(ctx, webhookClient, payload, url, secret)
+}
 
+func postWebhook(ctx context.Context, client *http.Client, payload map[string]any, url, secret string) error {
 	postBody, err := json.Marshal(payload)
 	if err != nil {
 		return pkgError.WebhookError(fmt.Sprintf("Failed to marshal body: %v", err))
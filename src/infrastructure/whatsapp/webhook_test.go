@@ -0,0 +1,35 @@
+package whatsapp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCallbackClientRefusesInternalAddresses(t *testing.T) {
+	reached := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		reached = true
+	}))
+	defer server.Close()
+
+	resp, err := callbackClient.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the callback client to refuse a loopback target")
+	}
+	if !strings.Contains(err.Error(), "non-public address") {
+		t.Errorf("error = %v, want a refusal of the non-public address", err)
+	}
+	if reached {
+		t.Error("the loopback server should not have been reached")
+	}
+
+	// Configured webhooks are set by the operator and may live on the internal network
+	resp, err = webhookClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("webhook client error = %v", err)
+	}
+	resp.Body.Close()
+}
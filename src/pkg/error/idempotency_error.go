@@ -0,0 +1,25 @@
+package error
+
+import "net/http"
+
+type IdempotencyError string
+
+// Error for complying the error interface
+func (e IdempotencyError) Error() string {
+	return string(e)
+}
+
+// ErrCode will return the error code based on the error data type
+func (e IdempotencyError) ErrCode() string {
+	return "IDEMPOTENCY_CONFLICT"
+}
+
+// StatusCode will return the HTTP status code based on the error data type
+func (e IdempotencyError) StatusCode() int {
+	return http.StatusConflict
+}
+
+const (
+	ErrIdempotencyKeyReused     = IdempotencyError("this Idempotency-Key was already used with a different request")
+	ErrIdempotencyKeyInProgress = IdempotencyError("a request with this Idempotency-Key is still being processed")
+)
@@ -70,6 +70,23 @@ func (e WaUploadMediaError) StatusCode() int {
 	return http.StatusInternalServerError
 }
 
+type RecipientBlockedError string
+
+// Error for complying the error interface
+func (e RecipientBlockedError) Error() string {
+	return string(e)
+}
+
+// ErrCode will return the error code based on the error data type
+func (e RecipientBlockedError) ErrCode() string {
+	return "RECIPIENT_BLOCKED"
+}
+
+// StatusCode will return the HTTP status code based on the error data type
+func (e RecipientBlockedError) StatusCode() int {
+	return http.StatusForbidden
+}
+
 const (
 	ErrInvalidJID        = InvalidJID("your JID is invalid")
 	ErrUserNotRegistered = InvalidJID("user is not registered")
@@ -9,6 +9,7 @@ import (
 	_ "image/png"  // For PNG encoding
 	"io"
 	"math"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -472,3 +473,23 @@ func FormatBusinessHourTime(timeValue any) string {
 
 	return fmt.Sprintf("%02d:%02d", hours, minutes)
 }
+
+// ParseBusinessHourTime converts HH:MM (e.g., "06:00", "12:30") to the numeric time format WhatsApp stores
+// (e.g., "600", "1230"), the reverse of FormatBusinessHourTime
+func ParseBusinessHourTime(value string) (string, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return "", fmt.Errorf("invalid business hour time %q, expected HH:MM", value)
+	}
+	return strconv.Itoa(parsed.Hour()*100 + parsed.Minute()), nil
+}
+
+// IsPublicIP reports whether ip is routable on the public internet, i.e. not loopback, private, link-local,
+// multicast or unspecified
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
@@ -2,6 +2,7 @@ package utils_test
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -604,6 +605,33 @@ func (suite *UtilsTestSuite) TestDownloadVideoFromURL() {
 	assert.Contains(suite.T(), err.Error(), "too many redirects")
 }
 
+func (suite *UtilsTestSuite) TestParseBusinessHourTime() {
+	for _, value := range []string{"00:00", "06:00", "09:30", "23:59"} {
+		parsed, err := utils.ParseBusinessHourTime(value)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), value, utils.FormatBusinessHourTime(parsed))
+	}
+
+	parsed, err := utils.ParseBusinessHourTime("12:30")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1230", parsed)
+
+	for _, value := range []string{"", "9", "24:00", "12:60", "noon"} {
+		_, err := utils.ParseBusinessHourTime(value)
+		assert.Error(suite.T(), err, value)
+	}
+}
+
+func (suite *UtilsTestSuite) TestIsPublicIP() {
+	for _, value := range []string{"8.8.8.8", "93.184.216.34", "2606:4700:4700::1111"} {
+		assert.True(suite.T(), utils.IsPublicIP(net.ParseIP(value)), value)
+	}
+	for _, value := range []string{"127.0.0.1", "10.0.0.5", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "::1", "fe80::1", "fd00::1", "224.0.0.1"} {
+		assert.False(suite.T(), utils.IsPublicIP(net.ParseIP(value)), value)
+	}
+	assert.False(suite.T(), utils.IsPublicIP(nil))
+}
+
 func TestUtilsTestSuite(t *testing.T) {
 	suite.Run(t, new(UtilsTestSuite))
 }
@@ -134,6 +134,11 @@ func ExtractMessageTextFromProto(msg *waE2E.Message) string {
 		return templateButtonReply.GetSelectedDisplayText()
 	}
 
+	// Check for poll creation, so polls are kept in chat storage
+	if poll := ExtractPollCreation(msg); poll != nil {
+		return "📊 " + poll.GetName()
+	}
+
 	return ""
 }
 
@@ -251,6 +256,41 @@ func ExtractMessageTextFromEvent(evt *events.Message) string {
 	return messageText
 }
 
+// ExtractPollCreation returns the poll creation payload of a message, whichever protocol version carries it
+func ExtractPollCreation(msg *waE2E.Message) *waE2E.PollCreationMessage {
+	if msg == nil {
+		return nil
+	}
+	for _, poll := range []*waE2E.PollCreationMessage{
+		msg.GetPollCreationMessage(),
+		msg.GetPollCreationMessageV2(),
+		msg.GetPollCreationMessageV3(),
+		msg.GetPollCreationMessageV5(),
+	} {
+		if poll != nil {
+			return poll
+		}
+	}
+	return nil
+}
+
+// MatchPollOptions maps the SHA-256 option hashes carried by a decrypted poll vote back to option names.
+// Hashes that match no known option are skipped.
+func MatchPollOptions(options []string, selectedHashes [][]byte) []string {
+	byHash := make(map[string]string, len(options))
+	for i, hash := range whatsmeow.HashPollOptions(options) {
+		byHash[string(hash)] = options[i]
+	}
+
+	selected := make([]string, 0, len(selectedHashes))
+	for _, hash := range selectedHashes {
+		if option, ok := byHash[string(hash)]; ok {
+			selected = append(selected, option)
+		}
+	}
+	return selected
+}
+
 // ExtractMediaInfo extracts media information from a WhatsApp message
 func ExtractMediaInfo(msg *waE2E.Message) (mediaType string, filename string, url string, mediaKey []byte, fileSHA256 []byte, fileEncSHA256 []byte, fileLength uint64) {
 	if msg == nil {
@@ -307,6 +347,56 @@ func ExtractMediaInfo(msg *waE2E.Message) (mediaType string, filename string, ur
 	return "", "", "", nil, nil, nil, 0
 }
 
+// ExtractMediaDetails returns the mimetype and CDN direct path of a media message, which together with the fields of
+// ExtractMediaInfo are needed to send the same media again without uploading it
+func ExtractMediaDetails(msg *waE2E.Message) (mimetype string, directPath string) {
+	if msg == nil {
+		return "", ""
+	}
+
+	if img := msg.GetImageMessage(); img != nil {
+		return img.GetMimetype(), img.GetDirectPath()
+	}
+	if vid := msg.GetVideoMessage(); vid != nil {
+		return vid.GetMimetype(), vid.GetDirectPath()
+	}
+	if aud := msg.GetAudioMessage(); aud != nil {
+		return aud.GetMimetype(), aud.GetDirectPath()
+	}
+	if doc := msg.GetDocumentMessage(); doc != nil {
+		return doc.GetMimetype(), doc.GetDirectPath()
+	}
+	if sticker := msg.GetStickerMessage(); sticker != nil {
+		return sticker.GetMimetype(), sticker.GetDirectPath()
+	}
+
+	return "", ""
+}
+
+// ExtractForwardingScore returns how many times a message was forwarded before it reached us, as counted by the
+// context info of its text or media
+func ExtractForwardingScore(msg *waE2E.Message) uint32 {
+	if msg == nil {
+		return 0
+	}
+
+	switch {
+	case msg.GetExtendedTextMessage() != nil:
+		return msg.GetExtendedTextMessage().GetContextInfo().GetForwardingScore()
+	case msg.GetImageMessage() != nil:
+		return msg.GetImageMessage().GetContextInfo().GetForwardingScore()
+	case msg.GetVideoMessage() != nil:
+		return msg.GetVideoMessage().GetContextInfo().GetForwardingScore()
+	case msg.GetAudioMessage() != nil:
+		return msg.GetAudioMessage().GetContextInfo().GetForwardingScore()
+	case msg.GetDocumentMessage() != nil:
+		return msg.GetDocumentMessage().GetContextInfo().GetForwardingScore()
+	case msg.GetStickerMessage() != nil:
+		return msg.GetStickerMessage().GetContextInfo().GetForwardingScore()
+	}
+	return 0
+}
+
 // ExtractEphemeralExpiration extracts ephemeral expiration from a WhatsApp message
 func ExtractEphemeralExpiration(msg *waE2E.Message) uint32 {
 	logrus.Debug("ExtractEphemeralExpiration: Starting extraction process")
@@ -532,6 +622,56 @@ func FormatJID(jid string) types.JID {
 	return formattedJID
 }
 
+// JIDPair holds the phone number and LID forms of the same user. Either is empty while it is unknown.
+type JIDPair struct {
+	PN  types.JID
+	LID types.JID
+}
+
+// NewJIDPair sorts user JIDs into their phone number and LID slots, dropping the device part. JIDs on other
+// servers, such as groups, are ignored.
+func NewJIDPair(jids ...types.JID) JIDPair {
+	var pair JIDPair
+	for _, jid := range jids {
+		switch jid.Server {
+		case types.DefaultUserServer:
+			pair.PN = jid.ToNonAD()
+		case types.HiddenUserServer:
+			pair.LID = jid.ToNonAD()
+		}
+	}
+	return pair
+}
+
+// Preferred returns the phone number form when it is known and the LID otherwise
+func (p JIDPair) Preferred() types.JID {
+	if !p.PN.IsEmpty() {
+		return p.PN
+	}
+	return p.LID
+}
+
+// MessageSenderJIDs returns both forms of a message sender, as far as they came with the message
+func MessageSenderJIDs(source types.MessageSource) JIDPair {
+	return NewJIDPair(source.Sender, source.SenderAlt)
+}
+
+// MessageChatJID returns the chat of a message. A direct chat addressed by LID is returned as the phone number
+// JID when that came with the message, so a conversation is stored under one JID whichever form it arrives in.
+func MessageChatJID(source types.MessageSource) types.JID {
+	if source.IsGroup || source.Chat.Server != types.HiddenUserServer {
+		return source.Chat
+	}
+	alt := source.SenderAlt
+	if source.IsFromMe {
+		alt = source.RecipientAlt
+	}
+	if alt.Server == types.DefaultUserServer {
+		return alt.ToNonAD()
+	}
+	return source.Chat
+}
+
 // ExtractedMedia represents extracted media information
 type ExtractedMedia struct {
 	MediaPath string `json:"media_path"`
@@ -599,10 +739,29 @@ func SanitizePhone(phone *string) {
 	}
 }
 
+// numberChecker answers IsOnWhatsapp from the number check cache when one is registered
+var numberChecker func(ctx context.Context, client *whatsmeow.Client, phone string) (bool, error)
+
+// SetNumberChecker registers the lookup IsOnWhatsapp uses instead of asking WhatsApp on every call
+func SetNumberChecker(fn func(ctx context.Context, client *whatsmeow.Client, phone string) (bool, error)) {
+	numberChecker = fn
+}
+
 // IsOnWhatsapp checks if a number is registered on WhatsApp
 func IsOnWhatsapp(client *whatsmeow.Client, jid string) bool {
 	// only check if the jid a user with @s.whatsapp.net
 	if strings.Contains(jid, "@s.whatsapp.net") {
+		if numberChecker != nil {
+			phone, _, _ := strings.Cut(jid, "@")
+			phone, _, _ = strings.Cut(phone, ":")
+			registered, err := numberChecker(context.Background(), client, phone)
+			if err != nil {
+				logrus.Error("Failed to check if user is on whatsapp: ", err)
+				return false
+			}
+			return registered
+		}
+
 		data, err := client.IsOnWhatsApp(context.Background(), []string{jid})
 		if err != nil {
 			logrus.Error("Failed to check if user is on whatsapp: ", err)
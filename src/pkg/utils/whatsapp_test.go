@@ -1,6 +1,14 @@
 package utils
 
-import "testing"
+import (
+	"context"
+	"testing"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
 
 func TestDetermineMediaExtension(t *testing.T) {
 	tests := []struct {
@@ -50,3 +58,126 @@ func TestDetermineMediaExtension(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchPollOptions(t *testing.T) {
+	options := []string{"Yes", "No", "Maybe"}
+	hashes := whatsmeow.HashPollOptions([]string{"Maybe", "Yes", "Unknown"})
+
+	got := MatchPollOptions(options, hashes)
+	want := []string{"Maybe", "Yes"}
+	if len(got) != len(want) {
+		t.Fatalf("MatchPollOptions() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("MatchPollOptions() = %v, want %v", got, want)
+		}
+	}
+
+	if withdrawn := MatchPollOptions(options, nil); len(withdrawn) != 0 {
+		t.Fatalf("expected empty selection for a withdrawn vote, got %v", withdrawn)
+	}
+}
+
+func TestExtractPollCreation(t *testing.T) {
+	poll := &waE2E.PollCreationMessage{Name: proto.String("Dinner?")}
+	if got := ExtractPollCreation(&waE2E.Message{PollCreationMessageV3: poll}); got != poll {
+		t.Fatalf("ExtractPollCreation() = %v, want V3 poll", got)
+	}
+	if got := ExtractPollCreation(&waE2E.Message{Conversation: proto.String("hi")}); got != nil {
+		t.Fatalf("ExtractPollCreation() = %v, want nil", got)
+	}
+}
+
+func TestExtractMediaDetailsAndForwardingScore(t *testing.T) {
+	msg := &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+		Mimetype:    proto.String("image/png"),
+		DirectPath:  proto.String("/v/t62.7118-24/1.enc"),
+		ContextInfo: &waE2E.ContextInfo{ForwardingScore: proto.Uint32(3)},
+	}}
+	if mimetype, directPath := ExtractMediaDetails(msg); mimetype != "image/png" || directPath != "/v/t62.7118-24/1.enc" {
+		t.Fatalf("ExtractMediaDetails() = %q, %q", mimetype, directPath)
+	}
+	if score := ExtractForwardingScore(msg); score != 3 {
+		t.Fatalf("ExtractForwardingScore() = %d, want 3", score)
+	}
+	if score := ExtractForwardingScore(&waE2E.Message{Conversation: proto.String("hi")}); score != 0 {
+		t.Fatalf("ExtractForwardingScore() = %d, want 0", score)
+	}
+}
+
+func TestIsOnWhatsappUsesNumberChecker(t *testing.T) {
+	var checked []string
+	SetNumberChecker(func(_ context.Context, _ *whatsmeow.Client, phone string) (bool, error) {
+		checked = append(checked, phone)
+		return phone == "6281234567890", nil
+	})
+	defer SetNumberChecker(nil)
+
+	if !IsOnWhatsapp(nil, "6281234567890:3@s.whatsapp.net") {
+		t.Error("IsOnWhatsapp() = false for a registered number")
+	}
+	if IsOnWhatsapp(nil, "6289999999999@s.whatsapp.net") {
+		t.Error("IsOnWhatsapp() = true for an unregistered number")
+	}
+	if !IsOnWhatsapp(nil, "120363000000000000@g.us") {
+		t.Error("IsOnWhatsapp() = false for a group, which is not checked")
+	}
+	if len(checked) != 2 || checked[0] != "6281234567890" {
+		t.Errorf("checked %v, want the two phone numbers without device", checked)
+	}
+}
+
+func TestMessageSenderAndChatJIDs(t *testing.T) {
+	pn := types.NewJID("6281234567890", types.DefaultUserServer)
+	lid := types.NewJID("123456789012345", types.HiddenUserServer)
+	group := types.NewJID("120363000000000000", types.GroupServer)
+	lidDevice := lid
+	lidDevice.Device = 3
+
+	tests := []struct {
+		name       string
+		source     types.MessageSource
+		wantSender JIDPair
+		wantChat   types.JID
+	}{
+		{
+			name:       "LIDDirectChatWithAlt",
+			source:     types.MessageSource{Chat: lid, Sender: lidDevice, SenderAlt: pn},
+			wantSender: JIDPair{PN: pn, LID: lid},
+			wantChat:   pn,
+		},
+		{
+			name:       "LIDDirectChatWithoutAlt",
+			source:     types.MessageSource{Chat: lid, Sender: lid},
+			wantSender: JIDPair{LID: lid},
+			wantChat:   lid,
+		},
+		{
+			name:       "OwnMessageInLIDChat",
+			source:     types.MessageSource{Chat: lid, Sender: types.NewJID("6289999999999", types.DefaultUserServer), IsFromMe: true, RecipientAlt: pn},
+			wantSender: JIDPair{PN: types.NewJID("6289999999999", types.DefaultUserServer)},
+			wantChat:   pn,
+		},
+		{
+			name:       "GroupKeepsChat",
+			source:     types.MessageSource{Chat: group, Sender: lid, SenderAlt: pn, IsGroup: true},
+			wantSender: JIDPair{PN: pn, LID: lid},
+			wantChat:   group,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MessageSenderJIDs(tt.source); got != tt.wantSender {
+				t.Errorf("MessageSenderJIDs() = %+v, want %+v", got, tt.wantSender)
+			}
+			if got := MessageChatJID(tt.source); got != tt.wantChat {
+				t.Errorf("MessageChatJID() = %v, want %v", got, tt.wantChat)
+			}
+		})
+	}
+
+	if got := (JIDPair{LID: lid}).Preferred(); got != lid {
+		t.Errorf("Preferred() = %v, want the LID when no phone number is known", got)
+	}
+}
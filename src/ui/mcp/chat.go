@@ -0,0 +1,294 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type ChatHandler struct {
+	chatService domainChat.IChatUsecase
+}
+
+func InitMcpChat(chatService domainChat.IChatUsecase) *ChatHandler {
+	return &ChatHandler{chatService: chatService}
+}
+
+func (h *ChatHandler) AddChatTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolArchiveChat(), h.handleArchiveChat)
+	mcpServer.AddTool(h.toolMuteChat(), h.handleMuteChat)
+	mcpServer.AddTool(h.toolMarkChatAsRead(), h.handleMarkChatAsRead)
+	mcpServer.AddTool(h.toolClearChat(), h.handleClearChat)
+	mcpServer.AddTool(h.toolDeleteChat(), h.handleDeleteChat)
+	mcpServer.AddTool(h.toolSetDisappearingTimer(), h.handleSetDisappearingTimer)
+	mcpServer.AddTool(h.toolSetDefaultDisappearingTimer(), h.handleSetDefaultDisappearingTimer)
+}
+
+func (h *ChatHandler) toolArchiveChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_archive",
+		mcp.WithDescription("Archive or unarchive a chat."),
+		mcp.WithTitleAnnotation("Archive Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("chat_jid",
+			mcp.Description("Chat JID, e.g. 628123456789@s.whatsapp.net or 120363024512399999@g.us."),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("archived",
+			mcp.Description("Set to true to archive the chat, false to unarchive it."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handleArchiveChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+	archived, err := requireBool(request, "archived")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.ArchiveChat(ctx, domainChat.ArchiveChatRequest{
+		ChatJID:  strings.TrimSpace(chatJID),
+		Archived: archived,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolMuteChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_mute",
+		mcp.WithDescription("Mute a chat indefinitely or until a given time, or unmute it."),
+		mcp.WithTitleAnnotation("Mute Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("chat_jid",
+			mcp.Description("Chat JID, e.g. 628123456789@s.whatsapp.net or 120363024512399999@g.us."),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("muted",
+			mcp.Description("Set to true to mute the chat, false to unmute it."),
+			mcp.Required(),
+		),
+		mcp.WithString("mute_until",
+			mcp.Description("Optional RFC3339 time when the mute ends. Omit to mute indefinitely."),
+		),
+	)
+}
+
+func (h *ChatHandler) handleMuteChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+	muted, err := requireBool(request, "muted")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.MuteChat(ctx, domainChat.MuteChatRequest{
+		ChatJID:   strings.TrimSpace(chatJID),
+		Muted:     muted,
+		MuteUntil: strings.TrimSpace(request.GetString("mute_until", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolMarkChatAsRead() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_mark_read",
+		mcp.WithDescription("Mark a whole chat as read or unread."),
+		mcp.WithTitleAnnotation("Mark Chat Read"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("chat_jid",
+			mcp.Description("Chat JID, e.g. 628123456789@s.whatsapp.net or 120363024512399999@g.us."),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("read",
+			mcp.Description("Set to true to mark the chat as read, false to mark it as unread."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handleMarkChatAsRead(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+	read, err := requireBool(request, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.MarkChatAsRead(ctx, domainChat.MarkChatAsReadRequest{
+		ChatJID: strings.TrimSpace(chatJID),
+		Read:    read,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolClearChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_clear",
+		mcp.WithDescription("Delete all messages in a chat on every linked device, keeping the chat itself."),
+		mcp.WithTitleAnnotation("Clear Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("chat_jid",
+			mcp.Description("Chat JID, e.g. 628123456789@s.whatsapp.net or 120363024512399999@g.us."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handleClearChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.ClearChat(ctx, domainChat.ClearChatRequest{ChatJID: strings.TrimSpace(chatJID)})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolDeleteChat() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_delete",
+		mcp.WithDescription("Delete a chat and its messages on every linked device."),
+		mcp.WithTitleAnnotation("Delete Chat"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("chat_jid",
+			mcp.Description("Chat JID, e.g. 628123456789@s.whatsapp.net or 120363024512399999@g.us."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handleDeleteChat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.DeleteChat(ctx, domainChat.DeleteChatRequest{ChatJID: strings.TrimSpace(chatJID)})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolSetDisappearingTimer() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_chat_set_disappearing",
+		mcp.WithDescription("Set or clear the disappearing-messages timer of a chat or group."),
+		mcp.WithTitleAnnotation("Set Disappearing Messages"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("chat_jid",
+			mcp.Description("Chat JID, e.g. 628123456789@s.whatsapp.net or 120363024512399999@g.us."),
+			mcp.Required(),
+		),
+		mcp.WithNumber("timer_seconds",
+			mcp.Description("Timer in seconds: 0 (off), 86400 (24 hours), 604800 (7 days) or 7776000 (90 days)."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handleSetDisappearingTimer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	chatJID, err := request.RequireString("chat_jid")
+	if err != nil {
+		return nil, err
+	}
+	timer, err := request.RequireInt("timer_seconds")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.SetDisappearingTimer(ctx, domainChat.SetDisappearingTimerRequest{
+		ChatJID:      strings.TrimSpace(chatJID),
+		TimerSeconds: uint32(max(timer, 0)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func (h *ChatHandler) toolSetDefaultDisappearingTimer() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_set_default_disappearing",
+		mcp.WithDescription("Set the disappearing-messages timer applied to new chats started by this account."),
+		mcp.WithTitleAnnotation("Set Default Disappearing Messages"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithNumber("timer_seconds",
+			mcp.Description("Timer in seconds: 0 (off), 86400 (24 hours), 604800 (7 days) or 7776000 (90 days)."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *ChatHandler) handleSetDefaultDisappearingTimer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	timer, err := request.RequireInt("timer_seconds")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.chatService.SetDefaultDisappearingTimer(ctx, domainChat.SetDefaultDisappearingTimerRequest{
+		TimerSeconds: uint32(max(timer, 0)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Message), nil
+}
+
+func requireBool(request mcp.CallToolRequest, name string) (bool, error) {
+	args := request.GetArguments()
+	if args == nil {
+		return false, fmt.Errorf("%s flag is required", name)
+	}
+	value, ok := args[name]
+	if !ok {
+		return false, fmt.Errorf("%s flag is required", name)
+	}
+	return toBool(value)
+}
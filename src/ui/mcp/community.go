@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainCommunity "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/community"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type CommunityHandler struct {
+	communityService domainCommunity.ICommunityUsecase
+}
+
+func InitMcpCommunity(communityService domainCommunity.ICommunityUsecase) *CommunityHandler {
+	return &CommunityHandler{communityService: communityService}
+}
+
+func (h *CommunityHandler) AddCommunityTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolCreateCommunity(), h.handleCreateCommunity)
+	mcpServer.AddTool(h.toolCommunityInfo(), h.handleCommunityInfo)
+	mcpServer.AddTool(h.toolGetSubGroups(), h.handleGetSubGroups)
+	mcpServer.AddTool(h.toolLinkGroups(), h.handleLinkGroups)
+	mcpServer.AddTool(h.toolUnlinkGroups(), h.handleUnlinkGroups)
+}
+
+func (h *CommunityHandler) toolCreateCommunity() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_create",
+		mcp.WithDescription("Create a new WhatsApp community. WhatsApp adds its announcement group automatically."),
+		mcp.WithTitleAnnotation("Create Community"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("name",
+			mcp.Description("Community name."),
+			mcp.Required(),
+		),
+		mcp.WithString("description",
+			mcp.Description("Optional community description."),
+		),
+	)
+}
+
+func (h *CommunityHandler) handleCreateCommunity(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.communityService.CreateCommunity(ctx, domainCommunity.CreateCommunityRequest{
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(request.GetString("description", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Created community %s", resp.CommunityID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *CommunityHandler) toolCommunityInfo() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_info",
+		mcp.WithDescription("Get the name, description, owner and member count of a community."),
+		mcp.WithTitleAnnotation("Community Info"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("community_id",
+			mcp.Description("Community JID or numeric ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *CommunityHandler) handleCommunityInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	communityID, err := request.RequireString("community_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.communityService.CommunityInfo(ctx, domainCommunity.CommunityInfoRequest{
+		CommunityID: strings.TrimSpace(communityID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Community %s (%s) has %d members", resp.Name, resp.CommunityID, resp.MemberCount)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *CommunityHandler) toolGetSubGroups() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_subgroups",
+		mcp.WithDescription("List the groups linked to a community and its announcement group."),
+		mcp.WithTitleAnnotation("List Community Groups"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("community_id",
+			mcp.Description("Community JID or numeric ID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *CommunityHandler) handleGetSubGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	communityID, err := request.RequireString("community_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.communityService.GetSubGroups(ctx, domainCommunity.GetSubGroupsRequest{
+		CommunityID: strings.TrimSpace(communityID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Community %s has %d linked groups", resp.CommunityID, len(resp.SubGroups))
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *CommunityHandler) toolLinkGroups() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_link_groups",
+		mcp.WithDescription("Link existing groups to a community. Requires admin rights in the community and each group."),
+		mcp.WithTitleAnnotation("Link Groups to Community"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("community_id",
+			mcp.Description("Community JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithArray("group_ids",
+			mcp.Description("Group JIDs or numeric IDs to link."),
+			mcp.Required(),
+			mcp.WithStringItems(),
+		),
+	)
+}
+
+func (h *CommunityHandler) handleLinkGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	linkRequest, err := linkGroupsRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.communityService.LinkGroups(ctx, linkRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Processed %d groups for community %s", len(result), linkRequest.CommunityID)
+	return mcp.NewToolResultStructured(map[string]any{"results": result}, fallback), nil
+}
+
+func (h *CommunityHandler) toolUnlinkGroups() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_community_unlink_groups",
+		mcp.WithDescription("Unlink groups from a community. The groups keep existing on their own."),
+		mcp.WithTitleAnnotation("Unlink Groups from Community"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("community_id",
+			mcp.Description("Community JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithArray("group_ids",
+			mcp.Description("Group JIDs or numeric IDs to unlink."),
+			mcp.Required(),
+			mcp.WithStringItems(),
+		),
+	)
+}
+
+func (h *CommunityHandler) handleUnlinkGroups(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	linkRequest, err := linkGroupsRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.communityService.UnlinkGroups(ctx, linkRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Processed %d groups for community %s", len(result), linkRequest.CommunityID)
+	return mcp.NewToolResultStructured(map[string]any{"results": result}, fallback), nil
+}
+
+func linkGroupsRequest(request mcp.CallToolRequest) (domainCommunity.LinkGroupsRequest, error) {
+	communityID, err := request.RequireString("community_id")
+	if err != nil {
+		return domainCommunity.LinkGroupsRequest{}, err
+	}
+
+	args := request.GetArguments()
+	if args == nil {
+		return domainCommunity.LinkGroupsRequest{}, fmt.Errorf("group_ids are required")
+	}
+	rawGroupIDs, exists := args["group_ids"]
+	if !exists {
+		return domainCommunity.LinkGroupsRequest{}, fmt.Errorf("group_ids are required")
+	}
+	groupIDs, err := toStringSlice(rawGroupIDs)
+	if err != nil {
+		return domainCommunity.LinkGroupsRequest{}, err
+	}
+
+	return domainCommunity.LinkGroupsRequest{
+		CommunityID: strings.TrimSpace(communityID),
+		GroupIDs:    groupIDs,
+	}, nil
+}
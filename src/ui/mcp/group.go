@@ -10,6 +10,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
 )
 
 type GroupHandler struct {
@@ -32,6 +33,9 @@ func (h *GroupHandler) AddGroupTools(mcpServer *server.MCPServer) {
 	mcpServer.AddTool(h.toolSetGroupTopic(), h.handleSetGroupTopic)
 	mcpServer.AddTool(h.toolSetGroupLocked(), h.handleSetGroupLocked)
 	mcpServer.AddTool(h.toolSetGroupAnnounce(), h.handleSetGroupAnnounce)
+	mcpServer.AddTool(h.toolSetGroupMemberAddMode(), h.handleSetGroupMemberAddMode)
+	mcpServer.AddTool(h.toolSetGroupJoinApproval(), h.handleSetGroupJoinApproval)
+	mcpServer.AddTool(h.toolSetGroupDisappearing(), h.handleSetGroupDisappearing)
 	mcpServer.AddTool(h.toolListGroupJoinRequests(), h.handleListGroupJoinRequests)
 	mcpServer.AddTool(h.toolManageGroupJoinRequests(), h.handleManageGroupJoinRequests)
 }
@@ -538,6 +542,141 @@ func (h *GroupHandler) handleSetGroupAnnounce(ctx context.Context, request mcp.C
 	return mcp.NewToolResultText(fmt.Sprintf("Group %s is now in %s mode", trimmed, state)), nil
 }
 
+func (h *GroupHandler) toolSetGroupMemberAddMode() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_set_member_add_mode",
+		mcp.WithDescription("Choose whether only admins or all members can add new members."),
+		mcp.WithTitleAnnotation("Set Group Member Add Mode"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithString("mode",
+			mcp.Description("admin_add to let only admins add members, all_member_add to let everyone add members."),
+			mcp.Enum(string(types.GroupMemberAddModeAdmin), string(types.GroupMemberAddModeAllMember)),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleSetGroupMemberAddMode(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupID, err := request.RequireString("group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := request.RequireString("mode")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(groupID)
+	utils.SanitizePhone(&trimmed)
+
+	if err := h.groupService.SetGroupMemberAddMode(ctx, domainGroup.SetGroupMemberAddModeRequest{
+		GroupID: trimmed,
+		Mode:    types.GroupMemberAddMode(strings.TrimSpace(mode)),
+	}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Group %s member add mode set to %s", trimmed, mode)), nil
+}
+
+func (h *GroupHandler) toolSetGroupJoinApproval() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_set_join_approval",
+		mcp.WithDescription("Toggle whether admins must approve people joining through the invite link."),
+		mcp.WithTitleAnnotation("Set Group Join Approval"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("join_approval",
+			mcp.Description("Set to true to require admin approval for new members."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleSetGroupJoinApproval(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupID, err := request.RequireString("group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	joinApproval, err := requireBool(request, "join_approval")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(groupID)
+	utils.SanitizePhone(&trimmed)
+
+	if err := h.groupService.SetGroupJoinApproval(ctx, domainGroup.SetGroupJoinApprovalRequest{GroupID: trimmed, JoinApproval: joinApproval}); err != nil {
+		return nil, err
+	}
+
+	state := "disabled"
+	if joinApproval {
+		state = "enabled"
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Join approval for group %s is now %s", trimmed, state)), nil
+}
+
+func (h *GroupHandler) toolSetGroupDisappearing() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_group_set_disappearing",
+		mcp.WithDescription("Set or clear the disappearing-messages timer of a group."),
+		mcp.WithTitleAnnotation("Set Group Disappearing Messages"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("group_id",
+			mcp.Description("Group JID or numeric ID."),
+			mcp.Required(),
+		),
+		mcp.WithNumber("timer_seconds",
+			mcp.Description("Timer in seconds: 0 (off), 86400 (24 hours), 604800 (7 days) or 7776000 (90 days)."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *GroupHandler) handleSetGroupDisappearing(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	groupID, err := request.RequireString("group_id")
+	if err != nil {
+		return nil, err
+	}
+
+	timer, err := request.RequireInt("timer_seconds")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(groupID)
+	utils.SanitizePhone(&trimmed)
+
+	if err := h.groupService.SetGroupDisappearingTimer(ctx, domainGroup.SetGroupDisappearingTimerRequest{
+		GroupID:      trimmed,
+		TimerSeconds: uint32(max(timer, 0)),
+	}); err != nil {
+		return nil, err
+	}
+
+	if timer <= 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Disappearing messages turned off for group %s", trimmed)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Disappearing messages for group %s set to %d seconds", trimmed, timer)), nil
+}
+
 func (h *GroupHandler) toolListGroupJoinRequests() mcp.Tool {
 	return mcp.NewTool(
 		"whatsapp_group_join_requests",
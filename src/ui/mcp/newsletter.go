@@ -0,0 +1,389 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type NewsletterHandler struct {
+	newsletterService domainNewsletter.INewsletterUsecase
+}
+
+func InitMcpNewsletter(newsletterService domainNewsletter.INewsletterUsecase) *NewsletterHandler {
+	return &NewsletterHandler{newsletterService: newsletterService}
+}
+
+func (h *NewsletterHandler) AddNewsletterTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolCreateNewsletter(), h.handleCreateNewsletter)
+	mcpServer.AddTool(h.toolNewsletterInfo(), h.handleNewsletterInfo)
+	mcpServer.AddTool(h.toolUpdateNewsletter(), h.handleUpdateNewsletter)
+	mcpServer.AddTool(h.toolDeleteNewsletter(), h.handleDeleteNewsletter)
+	mcpServer.AddTool(h.toolFollowNewsletter(), h.handleFollowNewsletter)
+	mcpServer.AddTool(h.toolUnfollowNewsletter(), h.handleUnfollowNewsletter)
+	mcpServer.AddTool(h.toolMuteNewsletter(), h.handleMuteNewsletter)
+	mcpServer.AddTool(h.toolNewsletterMessages(), h.handleNewsletterMessages)
+	mcpServer.AddTool(h.toolSendNewsletter(), h.handleSendNewsletter)
+	mcpServer.AddTool(h.toolNewsletterStats(), h.handleNewsletterStats)
+}
+
+func (h *NewsletterHandler) toolCreateNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_create",
+		mcp.WithDescription("Create a WhatsApp channel owned by this account."),
+		mcp.WithTitleAnnotation("Create Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("name",
+			mcp.Description("Channel name."),
+			mcp.Required(),
+		),
+		mcp.WithString("description",
+			mcp.Description("Optional channel description."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleCreateNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Create(ctx, domainNewsletter.CreateRequest{
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(request.GetString("description", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Created channel %s (%s)", resp.Name, resp.NewsletterID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolNewsletterInfo() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_info",
+		mcp.WithDescription("Get the name, description, invite link, follower count and our role of a channel."),
+		mcp.WithTitleAnnotation("Channel Info"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID, e.g. 120363144038483540@newsletter. Either this or invite_link is required."),
+		),
+		mcp.WithString("invite_link",
+			mcp.Description("Channel invite link, for channels this account does not follow."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleNewsletterInfo(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	resp, err := h.newsletterService.Info(ctx, domainNewsletter.InfoRequest{
+		NewsletterID: strings.TrimSpace(request.GetString("newsletter_id", "")),
+		InviteLink:   strings.TrimSpace(request.GetString("invite_link", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Channel %s (%s) has %d followers", resp.Name, resp.NewsletterID, resp.SubscriberCount)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolUpdateNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_update",
+		mcp.WithDescription("Change the name or description of a channel this account owns. Omitted fields stay as they are."),
+		mcp.WithTitleAnnotation("Update Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID."),
+			mcp.Required(),
+		),
+		mcp.WithString("name",
+			mcp.Description("New channel name."),
+		),
+		mcp.WithString("description",
+			mcp.Description("New channel description."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleUpdateNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Update(ctx, domainNewsletter.UpdateRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		Name:         strings.TrimSpace(request.GetString("name", "")),
+		Description:  strings.TrimSpace(request.GetString("description", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Updated channel %s", resp.NewsletterID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolDeleteNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_delete",
+		mcp.WithDescription("Delete a channel this account owns, for all of its followers."),
+		mcp.WithTitleAnnotation("Delete Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleDeleteNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.newsletterService.Delete(ctx, domainNewsletter.NewsletterRequest{NewsletterID: strings.TrimSpace(newsletterID)}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Deleted channel %s", newsletterID)), nil
+}
+
+func (h *NewsletterHandler) toolFollowNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_follow",
+		mcp.WithDescription("Follow a channel through its invite link."),
+		mcp.WithTitleAnnotation("Follow Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("invite_link",
+			mcp.Description("Channel invite link, e.g. https://whatsapp.com/channel/0029Va4K0PZ5a245NkngBA2M."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleFollowNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	inviteLink, err := request.RequireString("invite_link")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Follow(ctx, domainNewsletter.FollowRequest{InviteLink: strings.TrimSpace(inviteLink)})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Following channel %s (%s)", resp.Name, resp.NewsletterID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolUnfollowNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_unfollow",
+		mcp.WithDescription("Stop following a channel."),
+		mcp.WithTitleAnnotation("Unfollow Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleUnfollowNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.newsletterService.Unfollow(ctx, domainNewsletter.UnfollowRequest{NewsletterID: strings.TrimSpace(newsletterID)}); err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Unfollowed channel %s", newsletterID)), nil
+}
+
+func (h *NewsletterHandler) toolMuteNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_mute",
+		mcp.WithDescription("Mute or unmute notifications of a followed channel."),
+		mcp.WithTitleAnnotation("Mute Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID."),
+			mcp.Required(),
+		),
+		mcp.WithBoolean("mute",
+			mcp.Description("Set to true to mute the channel, false to unmute it."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleMuteNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+	mute, err := requireBool(request, "mute")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.newsletterService.Mute(ctx, domainNewsletter.MuteRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		Mute:         mute,
+	}); err != nil {
+		return nil, err
+	}
+
+	if mute {
+		return mcp.NewToolResultText(fmt.Sprintf("Muted channel %s", newsletterID)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Unmuted channel %s", newsletterID)), nil
+}
+
+func (h *NewsletterHandler) toolNewsletterMessages() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_messages",
+		mcp.WithDescription("Fetch the latest posts of a channel with their view and reaction counts."),
+		mcp.WithTitleAnnotation("Channel Messages"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID."),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of posts to return (default 20, max 100)."),
+		),
+		mcp.WithNumber("before",
+			mcp.Description("Only return posts older than this server ID, for paging."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleNewsletterMessages(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Messages(ctx, domainNewsletter.MessagesRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		Limit:        request.GetInt("limit", 20),
+		Before:       request.GetInt("before", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("Fetched %d posts from channel %s", len(resp.Messages), resp.NewsletterID)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
+
+func (h *NewsletterHandler) toolSendNewsletter() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_send",
+		mcp.WithDescription("Post text, or an image or video with the text as caption, to a channel this account owns or administers."),
+		mcp.WithTitleAnnotation("Post to Channel"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID."),
+			mcp.Required(),
+		),
+		mcp.WithString("message",
+			mcp.Description("Post text, or the caption when media is attached."),
+		),
+		mcp.WithString("image_url",
+			mcp.Description("Optional URL of an image to post."),
+		),
+		mcp.WithString("video_url",
+			mcp.Description("Optional URL of a video to post."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleSendNewsletter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	sendRequest := domainNewsletter.SendRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		Message:      request.GetString("message", ""),
+	}
+	if imageURL := strings.TrimSpace(request.GetString("image_url", "")); imageURL != "" {
+		sendRequest.ImageURL = &imageURL
+	}
+	if videoURL := strings.TrimSpace(request.GetString("video_url", "")); videoURL != "" {
+		sendRequest.VideoURL = &videoURL
+	}
+
+	resp, err := h.newsletterService.Send(ctx, sendRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewToolResultStructured(resp, resp.Status), nil
+}
+
+func (h *NewsletterHandler) toolNewsletterStats() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_newsletter_stats",
+		mcp.WithDescription("Read the current view and reaction counts of the latest channel posts, with totals."),
+		mcp.WithTitleAnnotation("Channel Stats"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithString("newsletter_id",
+			mcp.Description("Channel JID."),
+			mcp.Required(),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of posts to count (default 20, max 100)."),
+		),
+		mcp.WithString("since",
+			mcp.Description("Optional RFC3339 time; only posts after it are counted."),
+		),
+	)
+}
+
+func (h *NewsletterHandler) handleNewsletterStats(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	newsletterID, err := request.RequireString("newsletter_id")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.newsletterService.Stats(ctx, domainNewsletter.StatsRequest{
+		NewsletterID: strings.TrimSpace(newsletterID),
+		Limit:        request.GetInt("limit", 20),
+		Since:        strings.TrimSpace(request.GetString("since", "")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fallback := fmt.Sprintf("%d posts of channel %s have %d views and %d reactions", len(resp.Messages), resp.NewsletterID, resp.TotalViews, resp.TotalReactions)
+	return mcp.NewToolResultStructured(resp, fallback), nil
+}
@@ -79,11 +79,25 @@ func (h *QueryHandler) toolListChats() mcp.Tool {
 			mcp.Description("If true, return only chats that contain media messages."),
 			mcp.DefaultBool(false),
 		),
+		mcp.WithBoolean("archived",
+			mcp.Description("If set, return only archived (true) or non-archived (false) chats."),
+		),
+		mcp.WithBoolean("muted",
+			mcp.Description("If set, return only muted (true) or unmuted (false) chats."),
+		),
+		mcp.WithBoolean("unread",
+			mcp.Description("If set, return only chats with unread messages or marked as unread (true), or neither (false)."),
+		),
+		mcp.WithString("sort_by",
+			mcp.Description("Ordering: last_message (default) or unread to list chats needing attention first."),
+			mcp.Enum("last_message", "unread"),
+		),
 	)
 }
 
 func (h *QueryHandler) handleListChats(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	var hasMedia bool
+	var archived, muted, unread *bool
 	args := request.GetArguments()
 	if args != nil {
 		if value, ok := args["has_media"]; ok {
@@ -93,6 +107,15 @@ func (h *QueryHandler) handleListChats(ctx context.Context, request mcp.CallTool
 			}
 			hasMedia = parsed
 		}
+		for name, target := range map[string]**bool{"archived": &archived, "muted": &muted, "unread": &unread} {
+			if value, ok := args[name]; ok {
+				parsed, err := toBool(value)
+				if err != nil {
+					return nil, err
+				}
+				*target = &parsed
+			}
+		}
 	}
 
 	req := domainChat.ListChatsRequest{
@@ -100,6 +123,10 @@ func (h *QueryHandler) handleListChats(ctx context.Context, request mcp.CallTool
 		Offset:   request.GetInt("offset", 0),
 		Search:   request.GetString("search", ""),
 		HasMedia: hasMedia,
+		Archived: archived,
+		Muted:    muted,
+		Unread:   unread,
+		SortBy:   request.GetString("sort_by", ""),
 	}
 
 	resp, err := h.chatService.ListChats(ctx, req)
@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	domainSession "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/session"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type SessionHandler struct {
+	sessionService domainSession.ISessionUsecase
+}
+
+func InitMcpSession(sessionService domainSession.ISessionUsecase) *SessionHandler {
+	return &SessionHandler{sessionService: sessionService}
+}
+
+func (h *SessionHandler) AddSessionTools(mcpServer *server.MCPServer) {
+	mcpServer.AddTool(h.toolSessionPairCode(), h.handleSessionPairCode)
+}
+
+func (h *SessionHandler) toolSessionPairCode() mcp.Tool {
+	return mcp.NewTool(
+		"whatsapp_session_pair_code",
+		mcp.WithDescription("Generate a pairing code to link the WhatsApp device of an agent session without scanning a QR. Enter it on the phone under Linked devices > Link with phone number."),
+		mcp.WithTitleAnnotation("Session Pairing Code"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("agent_id",
+			mcp.Description("Agent ID of a session created with POST /sessions."),
+			mcp.Required(),
+		),
+		mcp.WithString("phone",
+			mcp.Description("Phone number in international format (e.g. +628123456789)."),
+			mcp.Required(),
+		),
+	)
+}
+
+func (h *SessionHandler) handleSessionPairCode(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	agentID, err := request.RequireString("agent_id")
+	if err != nil {
+		return nil, err
+	}
+	phone, err := request.RequireString("phone")
+	if err != nil {
+		return nil, err
+	}
+
+	trimmedPhone := strings.TrimSpace(phone)
+	resp, err := h.sessionService.RequestPairCode(strings.TrimSpace(agentID), domainSession.PairCodeRequest{PhoneNumber: trimmedPhone})
+	if err != nil {
+		return nil, err
+	}
+
+	structured := map[string]any{
+		"agent_id":      agentID,
+		"phone":         trimmedPhone,
+		"pair_code":     resp.PairCode,
+		"session_state": resp.SessionState,
+	}
+
+	fallback := fmt.Sprintf("Pair code %s generated for agent %s (%s)", resp.PairCode, agentID, trimmedPhone)
+	return mcp.NewToolResultStructured(structured, fallback), nil
+}
@@ -2,14 +2,16 @@ package agent
 
 import (
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/domains/agent"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/middleware"
 	"github.com/gofiber/fiber/v2"
 )
 
 func InitRoutes(app fiber.Router, usecase agent.IAgentUsecase) {
 	handler := NewHandler(usecase)
+	idempotency := middleware.Idempotency()
 
 	// Agent routes
 	app.Post("/agents/:agentId/run", handler.ExecuteRun)
-	app.Post("/agents/:agentId/messages", handler.SendMessage)
-	app.Post("/agents/:agentId/media", handler.SendMedia)
+	app.Post("/agents/:agentId/messages", idempotency, handler.SendMessage)
+	app.Post("/agents/:agentId/media", idempotency, handler.SendMedia)
 }
@@ -17,6 +17,13 @@ func InitRestChat(app fiber.Router, service domainChat.IChatUsecase) Chat {
 	app.Get("/chats", rest.ListChats)
 	app.Get("/chat/:chat_jid/messages", rest.GetChatMessages)
 	app.Post("/chat/:chat_jid/pin", rest.PinChat)
+	app.Post("/chat/:chat_jid/archive", rest.ArchiveChat)
+	app.Post("/chat/:chat_jid/mute", rest.MuteChat)
+	app.Post("/chat/:chat_jid/read", rest.MarkChatAsRead)
+	app.Post("/chat/:chat_jid/clear", rest.ClearChat)
+	app.Post("/chat/:chat_jid/delete", rest.DeleteChat)
+	app.Post("/chat/:chat_jid/disappearing", rest.SetDisappearingTimer)
+	app.Post("/chats/default-disappearing", rest.SetDefaultDisappearingTimer)
 
 	return rest
 }
@@ -29,6 +36,19 @@ func (controller *Chat) ListChats(c *fiber.Ctx) error {
 	request.Offset = c.QueryInt("offset", 0)
 	request.Search = c.Query("search", "")
 	request.HasMedia = c.QueryBool("has_media", false)
+	request.SortBy = c.Query("sort_by", "")
+	if archivedStr := c.Query("archived"); archivedStr != "" {
+		archived := c.QueryBool("archived")
+		request.Archived = &archived
+	}
+	if mutedStr := c.Query("muted"); mutedStr != "" {
+		muted := c.QueryBool("muted")
+		request.Muted = &muted
+	}
+	if unreadStr := c.Query("unread"); unreadStr != "" {
+		unread := c.QueryBool("unread")
+		request.Unread = &unread
+	}
 
 	response, err := controller.Service.ListChats(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
@@ -104,3 +124,168 @@ func (controller *Chat) PinChat(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+func (controller *Chat) SetDisappearingTimer(c *fiber.Ctx) error {
+	var request domainChat.SetDisappearingTimerRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// Parse JSON body
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(400).JSON(utils.ResponseData{
+			Status:  400,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Results: nil,
+		})
+	}
+
+	response, err := controller.Service.SetDisappearingTimer(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) SetDefaultDisappearingTimer(c *fiber.Ctx) error {
+	var request domainChat.SetDefaultDisappearingTimerRequest
+
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(400).JSON(utils.ResponseData{
+			Status:  400,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Results: nil,
+		})
+	}
+
+	response, err := controller.Service.SetDefaultDisappearingTimer(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) ArchiveChat(c *fiber.Ctx) error {
+	var request domainChat.ArchiveChatRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// Parse JSON body
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(400).JSON(utils.ResponseData{
+			Status:  400,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Results: nil,
+		})
+	}
+
+	response, err := controller.Service.ArchiveChat(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) MuteChat(c *fiber.Ctx) error {
+	var request domainChat.MuteChatRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// Parse JSON body
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(400).JSON(utils.ResponseData{
+			Status:  400,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Results: nil,
+		})
+	}
+
+	response, err := controller.Service.MuteChat(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) MarkChatAsRead(c *fiber.Ctx) error {
+	var request domainChat.MarkChatAsReadRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	// Parse JSON body
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(400).JSON(utils.ResponseData{
+			Status:  400,
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Results: nil,
+		})
+	}
+
+	response, err := controller.Service.MarkChatAsRead(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) ClearChat(c *fiber.Ctx) error {
+	var request domainChat.ClearChatRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	response, err := controller.Service.ClearChat(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
+
+func (controller *Chat) DeleteChat(c *fiber.Ctx) error {
+	var request domainChat.DeleteChatRequest
+
+	// Parse path parameter
+	request.ChatJID = c.Params("chat_jid")
+
+	response, err := controller.Service.DeleteChat(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Message,
+		Results: response,
+	})
+}
@@ -0,0 +1,103 @@
+package rest
+
+import (
+	"fmt"
+
+	domainCommunity "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/community"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Community struct {
+	Service domainCommunity.ICommunityUsecase
+}
+
+func InitRestCommunity(app fiber.Router, service domainCommunity.ICommunityUsecase) Community {
+	rest := Community{Service: service}
+	app.Post("/community", rest.CreateCommunity)
+	app.Get("/community/info", rest.CommunityInfo)
+	app.Get("/community/subgroups", rest.GetSubGroups)
+	app.Post("/community/link", rest.LinkGroups)
+	app.Post("/community/unlink", rest.UnlinkGroups)
+	return rest
+}
+
+func (controller *Community) CreateCommunity(c *fiber.Ctx) error {
+	var request domainCommunity.CreateCommunityRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.CreateCommunity(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Success created community with id %s", response.CommunityID),
+		Results: response,
+	})
+}
+
+func (controller *Community) CommunityInfo(c *fiber.Ctx) error {
+	var request domainCommunity.CommunityInfoRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.CommunityInfo(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get community info",
+		Results: response,
+	})
+}
+
+func (controller *Community) GetSubGroups(c *fiber.Ctx) error {
+	var request domainCommunity.GetSubGroupsRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.GetSubGroups(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get community subgroups",
+		Results: response,
+	})
+}
+
+func (controller *Community) LinkGroups(c *fiber.Ctx) error {
+	var request domainCommunity.LinkGroupsRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	result, err := controller.Service.LinkGroups(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success link groups",
+		Results: result,
+	})
+}
+
+func (controller *Community) UnlinkGroups(c *fiber.Ctx) error {
+	var request domainCommunity.LinkGroupsRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	result, err := controller.Service.UnlinkGroups(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success unlink groups",
+		Results: result,
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/gofiber/fiber/v2"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
 )
 
 type Group struct {
@@ -27,6 +28,7 @@ func InitRestGroup(app fiber.Router, service domainGroup.IGroupUsecase) Group {
 	app.Post("/group/leave", rest.LeaveGroup)
 	app.Get("/group/participants", rest.ListParticipants)
 	app.Get("/group/participants/export", rest.ExportParticipants)
+	app.Post("/group/participants/import", rest.ImportParticipants)
 	app.Post("/group/participants", rest.AddParticipants)
 	app.Post("/group/participants/remove", rest.DeleteParticipants)
 	app.Post("/group/participants/promote", rest.PromoteParticipants)
@@ -39,7 +41,11 @@ func InitRestGroup(app fiber.Router, service domainGroup.IGroupUsecase) Group {
 	app.Post("/group/locked", rest.SetGroupLocked)
 	app.Post("/group/announce", rest.SetGroupAnnounce)
 	app.Post("/group/topic", rest.SetGroupTopic)
+	app.Post("/group/member-add-mode", rest.SetGroupMemberAddMode)
+	app.Post("/group/join-approval", rest.SetGroupJoinApproval)
+	app.Post("/group/disappearing", rest.SetGroupDisappearingTimer)
 	app.Get("/group/invite-link", rest.GetGroupInviteLink)
+	app.Post("/group/invite-link/reset", rest.ResetGroupInviteLink)
 	return rest
 }
 
@@ -191,6 +197,29 @@ func (controller *Group) ExportParticipants(c *fiber.Ctx) error {
 	return c.Send(buffer.Bytes())
 }
 
+func (controller *Group) ImportParticipants(c *fiber.Ctx) error {
+	var request domainGroup.ImportParticipantsRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	// A missing file is reported by the validation
+	if file, err := c.FormFile("file"); err == nil {
+		request.File = file
+	}
+
+	response, err := controller.Service.ImportParticipants(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success import participants",
+		Results: response,
+	})
+}
+
 func (controller *Group) AddParticipants(c *fiber.Ctx) error {
 	return controller.manageParticipants(c, whatsmeow.ParticipantChangeAdd, "Success add participants")
 }
@@ -443,3 +472,89 @@ func (controller *Group) GetGroupInviteLink(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+// ResetGroupInviteLink revokes the current invite link and returns the new one
+func (controller *Group) ResetGroupInviteLink(c *fiber.Ctx) error {
+	var request domainGroup.GetGroupInviteLinkRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+	request.Reset = true
+
+	response, err := controller.Service.GetGroupInviteLink(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success reset group invite link",
+		Results: response,
+	})
+}
+
+func (controller *Group) SetGroupMemberAddMode(c *fiber.Ctx) error {
+	var request domainGroup.SetGroupMemberAddModeRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	err = controller.Service.SetGroupMemberAddMode(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success allow all members to add members"
+	if request.Mode == types.GroupMemberAddModeAdmin {
+		message = "Success allow only admins to add members"
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+	})
+}
+
+func (controller *Group) SetGroupJoinApproval(c *fiber.Ctx) error {
+	var request domainGroup.SetGroupJoinApprovalRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	err = controller.Service.SetGroupJoinApproval(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success disable join approval"
+	if request.JoinApproval {
+		message = "Success enable join approval"
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+	})
+}
+
+func (controller *Group) SetGroupDisappearingTimer(c *fiber.Ctx) error {
+	var request domainGroup.SetGroupDisappearingTimerRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.GroupID)
+
+	err = controller.Service.SetGroupDisappearingTimer(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success turn off disappearing messages"
+	if request.TimerSeconds > 0 {
+		message = fmt.Sprintf("Success set disappearing messages to %d seconds", request.TimerSeconds)
+	}
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+	})
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"fmt"
+
+	domainJoinRequest "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/joinrequest"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type JoinRequest struct {
+	Service domainJoinRequest.IJoinRequestUsecase
+}
+
+func InitRestJoinRequest(app fiber.Router, service domainJoinRequest.IJoinRequestUsecase) JoinRequest {
+	rest := JoinRequest{Service: service}
+	app.Get("/group/join-policy", rest.GetPolicy)
+	app.Post("/group/join-policy", rest.SetPolicy)
+	app.Post("/group/join-policy/delete", rest.DeletePolicy)
+	app.Post("/group/join-policy/review", rest.ReviewPending)
+	return rest
+}
+
+func (controller *JoinRequest) SetPolicy(c *fiber.Ctx) error {
+	var request domainJoinRequest.SetPolicyRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.SetPolicy(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success set group join request policy",
+		Results: response,
+	})
+}
+
+func (controller *JoinRequest) GetPolicy(c *fiber.Ctx) error {
+	var request domainJoinRequest.GroupRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.GetPolicy(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get group join request policy",
+		Results: response,
+	})
+}
+
+func (controller *JoinRequest) DeletePolicy(c *fiber.Ctx) error {
+	var request domainJoinRequest.GroupRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.DeletePolicy(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success delete group join request policy",
+		Results: nil,
+	})
+}
+
+func (controller *JoinRequest) ReviewPending(c *fiber.Ctx) error {
+	var request domainJoinRequest.GroupRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.ReviewPending(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Success review %d join requests", len(response.Decisions)),
+		Results: response,
+	})
+}
@@ -1,8 +1,11 @@
 package rest
 
 import (
+	"fmt"
+
 	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/middleware"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -25,16 +28,21 @@ func readAgentID(c *fiber.Ctx, current string) string {
 
 func InitRestMessage(app fiber.Router, service domainMessage.IMessageUsecase) Message {
 	rest := Message{Service: service}
+	idempotency := middleware.Idempotency()
 
 	// Message action endpoints
-	app.Post("/message/:message_id/reaction", rest.ReactMessage)
-	app.Post("/message/:message_id/revoke", rest.RevokeMessage)
-	app.Post("/message/:message_id/delete", rest.DeleteMessage)
-	app.Post("/message/:message_id/update", rest.UpdateMessage)
-	app.Post("/message/:message_id/read", rest.MarkAsRead)
-	app.Post("/message/:message_id/star", rest.StarMessage)
-	app.Post("/message/:message_id/unstar", rest.UnstarMessage)
+	app.Post("/message/:message_id/reaction", idempotency, rest.ReactMessage)
+	app.Post("/message/:message_id/revoke", idempotency, rest.RevokeMessage)
+	app.Post("/message/:message_id/delete", idempotency, rest.DeleteMessage)
+	app.Post("/message/:message_id/update", idempotency, rest.UpdateMessage)
+	app.Post("/message/:message_id/forward", idempotency, rest.ForwardMessage)
+	app.Post("/message/:message_id/read", idempotency, rest.MarkAsRead)
+	app.Post("/message/:message_id/star", idempotency, rest.StarMessage)
+	app.Post("/message/:message_id/unstar", idempotency, rest.UnstarMessage)
+	app.Post("/message/:message_id/pin", idempotency, rest.PinMessage)
+	app.Post("/message/:message_id/unpin", idempotency, rest.UnpinMessage)
 	app.Get("/message/:message_id/download", rest.DownloadMedia)
+	app.Get("/message/:message_id/poll-results", rest.GetPollResults)
 	return rest
 }
 
@@ -58,6 +66,28 @@ func (controller *Message) RevokeMessage(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *Message) ForwardMessage(c *fiber.Ctx) error {
+	var request domainMessage.ForwardRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	request.MessageID = c.Params("message_id")
+	request.AgentID = readAgentID(c, request.AgentID)
+	for i := range request.Phones {
+		utils.SanitizePhone(&request.Phones[i])
+	}
+
+	response, err := controller.Service.ForwardMessage(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Message forwarded to %d of %d chats", response.Sent, len(request.Phones)),
+		Results: response,
+	})
+}
+
 func (controller *Message) DeleteMessage(c *fiber.Ctx) error {
 	var request domainMessage.DeleteRequest
 	err := c.BodyParser(&request)
@@ -179,6 +209,48 @@ func (controller *Message) UnstarMessage(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *Message) PinMessage(c *fiber.Ctx) error {
+	var request domainMessage.PinMessageRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	request.MessageID = c.Params("message_id")
+	request.AgentID = readAgentID(c, request.AgentID)
+	utils.SanitizePhone(&request.Phone)
+	request.IsPinned = true
+
+	response, err := controller.Service.PinMessage(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Message) UnpinMessage(c *fiber.Ctx) error {
+	var request domainMessage.PinMessageRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	request.MessageID = c.Params("message_id")
+	request.AgentID = readAgentID(c, request.AgentID)
+	utils.SanitizePhone(&request.Phone)
+	request.IsPinned = false
+
+	response, err := controller.Service.PinMessage(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
 func (controller *Message) DownloadMedia(c *fiber.Ctx) error {
 	var request domainMessage.DownloadMediaRequest
 
@@ -197,3 +269,20 @@ func (controller *Message) DownloadMedia(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+func (controller *Message) GetPollResults(c *fiber.Ctx) error {
+	var request domainMessage.PollResultsRequest
+
+	request.MessageID = c.Params("message_id")
+	request.AgentID = readAgentID(c, request.AgentID)
+
+	response, err := controller.Service.GetPollResults(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Poll results for %s", request.MessageID),
+		Results: response,
+	})
+}
@@ -0,0 +1,227 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+	idempotencySweepInterval = time.Minute
+)
+
+type idempotencyEntry struct {
+	fingerprint string
+	done        bool
+	status      int
+	contentType string
+	body        []byte
+	expiresAt   time.Time
+}
+
+// IdempotencyStore keeps the first successful response for every Idempotency-Key so retries can be replayed.
+type IdempotencyStore struct {
+	mu        sync.Mutex
+	entries   map[string]*idempotencyEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewIdempotencyStore() *IdempotencyStore {
+	return &IdempotencyStore{
+		entries: make(map[string]*idempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+var defaultIdempotencyStore = NewIdempotencyStore()
+
+// Idempotency replays the stored response when a request is retried with the same Idempotency-Key and body.
+// Requests without the header pass through untouched.
+func Idempotency() fiber.Handler {
+	return defaultIdempotencyStore.Handler()
+}
+
+func (s *IdempotencyStore) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" || c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return writeGenericError(c, pkgError.ValidationError("Idempotency-Key must not exceed 255 characters"))
+		}
+
+		scopedKey := idempotencyScope(c) + "|" + key
+		fingerprint := requestFingerprint(c)
+
+		entry, reserved := s.reserve(scopedKey, fingerprint)
+		if !reserved {
+			switch {
+			case entry.fingerprint != fingerprint:
+				return writeGenericError(c, pkgError.ErrIdempotencyKeyReused)
+			case !entry.done:
+				return writeGenericError(c, pkgError.ErrIdempotencyKeyInProgress)
+			}
+			c.Set(HeaderIdempotentReplayed, "true")
+			c.Set(fiber.HeaderContentType, entry.contentType)
+			return c.Status(entry.status).Send(entry.body)
+		}
+
+		// Release the reservation if the handler panics so the client can retry; Recovery renders the error.
+		defer func() {
+			if r := recover(); r != nil {
+				s.release(scopedKey)
+				panic(r)
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			s.release(scopedKey)
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status < http.StatusOK || status >= http.StatusMultipleChoices {
+			// Only successful responses are pinned; failed sends can safely be retried with the same key.
+			s.release(scopedKey)
+			return nil
+		}
+
+		s.complete(scopedKey, status, string(c.Response().Header.ContentType()), c.Response().Body())
+		return nil
+	}
+}
+
+// reserve registers an in-flight request for the key. It returns the existing entry and false when the key is taken.
+func (s *IdempotencyStore) reserve(key, fingerprint string) (idempotencyEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweepLocked(now)
+
+	if existing, ok := s.entries[key]; ok && now.Before(existing.expiresAt) {
+		return *existing, false
+	}
+
+	s.entries[key] = &idempotencyEntry{
+		fingerprint: fingerprint,
+		expiresAt:   now.Add(config.AppIdempotencyTTL),
+	}
+	return idempotencyEntry{}, true
+}
+
+func (s *IdempotencyStore) complete(key string, status int, contentType string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	entry.done = true
+	entry.status = status
+	entry.contentType = contentType
+	entry.body = append([]byte(nil), body...)
+	entry.expiresAt = s.now().Add(config.AppIdempotencyTTL)
+}
+
+func (s *IdempotencyStore) release(key string) {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+}
+
+func (s *IdempotencyStore) sweepLocked(now time.Time) {
+	if now.Sub(s.lastSweep) < idempotencySweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// idempotencyScope keeps keys from different agents apart so two tenants cannot collide on the same key.
+func idempotencyScope(c *fiber.Ctx) string {
+	if agentID := c.Params("agentId"); agentID != "" {
+		return agentID
+	}
+	if agentID := c.Get("X-Agent-ID"); agentID != "" {
+		return agentID
+	}
+	return c.Query("agent_id")
+}
+
+// requestFingerprint identifies what a request asks for, so a retry matches even when the client encodes it
+// differently. Multipart bodies are fingerprinted from their parsed fields and file contents because clients pick a
+// new boundary on every attempt. The query string is included since it changes the response, e.g. ?async=true.
+func requestFingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte{'\n'})
+	hash.Write([]byte(c.Path()))
+	hash.Write([]byte{'\n'})
+	hash.Write(c.Request().URI().QueryString())
+	hash.Write([]byte{'\n'})
+	if form, err := c.MultipartForm(); err == nil {
+		writeMultipartFingerprint(hash, form)
+	} else {
+		hash.Write(c.Body())
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func writeMultipartFingerprint(w io.Writer, form *multipart.Form) {
+	for _, name := range sortedKeys(form.Value) {
+		for _, value := range form.Value[name] {
+			fmt.Fprintf(w, "field %q=%q\n", name, value)
+		}
+	}
+	for _, name := range sortedKeys(form.File) {
+		for _, header := range form.File[name] {
+			fmt.Fprintf(w, "file %q=%q %d ", name, header.Filename, header.Size)
+			if file, err := header.Open(); err == nil {
+				fileHash := sha256.New()
+				_, _ = io.Copy(fileHash, file)
+				file.Close()
+				w.Write(fileHash.Sum(nil))
+			}
+			w.Write([]byte{'\n'})
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func writeGenericError(c *fiber.Ctx, err pkgError.GenericError) error {
+	return c.Status(err.StatusCode()).JSON(utils.ResponseData{
+		Status:  err.StatusCode(),
+		Code:    err.ErrCode(),
+		Message: err.Error(),
+	})
+}
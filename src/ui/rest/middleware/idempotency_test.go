@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newIdempotencyTestApp(status int) (*fiber.App, *int) {
+	calls := 0
+	app := fiber.New()
+	app.Post("/send/message", NewIdempotencyStore().Handler(), func(c *fiber.Ctx) error {
+		calls++
+		return c.Status(status).JSON(fiber.Map{"call": calls})
+	})
+	return app, &calls
+}
+
+func doIdempotentRequest(t *testing.T, app *fiber.App, key, body string) (*http.Response, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/send/message", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	raw, _ := io.ReadAll(resp.Body)
+	return resp, string(raw)
+}
+
+func TestIdempotencyReplaysSuccessfulResponse(t *testing.T) {
+	app, calls := newIdempotencyTestApp(http.StatusOK)
+
+	_, first := doIdempotentRequest(t, app, "abc", `{"phone":"1"}`)
+	resp, second := doIdempotentRequest(t, app, "abc", `{"phone":"1"}`)
+
+	if *calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", *calls)
+	}
+	if first != second {
+		t.Fatalf("expected replayed body %q, got %q", first, second)
+	}
+	if resp.Header.Get(HeaderIdempotentReplayed) != "true" {
+		t.Fatalf("expected %s header on replay", HeaderIdempotentReplayed)
+	}
+}
+
+func TestIdempotencyRejectsDifferentBody(t *testing.T) {
+	app, calls := newIdempotencyTestApp(http.StatusOK)
+
+	doIdempotentRequest(t, app, "abc", `{"phone":"1"}`)
+	resp, _ := doIdempotentRequest(t, app, "abc", `{"phone":"2"}`)
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", resp.StatusCode)
+	}
+	if *calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", *calls)
+	}
+}
+
+func TestIdempotencyDoesNotStoreFailures(t *testing.T) {
+	app, calls := newIdempotencyTestApp(http.StatusInternalServerError)
+
+	doIdempotentRequest(t, app, "abc", `{"phone":"1"}`)
+	doIdempotentRequest(t, app, "abc", `{"phone":"1"}`)
+
+	if *calls != 2 {
+		t.Fatalf("expected failed request to be retried, handler ran %d times", *calls)
+	}
+}
+
+func TestIdempotencyWithoutKeyPassesThrough(t *testing.T) {
+	app, calls := newIdempotencyTestApp(http.StatusOK)
+
+	doIdempotentRequest(t, app, "", `{"phone":"1"}`)
+	doIdempotentRequest(t, app, "", `{"phone":"1"}`)
+
+	if *calls != 2 {
+		t.Fatalf("expected handler to run for every request without a key, ran %d times", *calls)
+	}
+}
+
+func doMultipartRequest(t *testing.T, app *fiber.App, target, key, boundary, caption string) *http.Response {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.SetBoundary(boundary); err != nil {
+		t.Fatal(err)
+	}
+	_ = writer.WriteField("caption", caption)
+	part, _ := writer.CreateFormFile("image", "photo.png")
+	part.Write([]byte("image bytes"))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, target, &body)
+	req.Header.Set(fiber.HeaderContentType, writer.FormDataContentType())
+	req.Header.Set(HeaderIdempotencyKey, key)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp
+}
+
+func TestIdempotencyMultipartIgnoresBoundary(t *testing.T) {
+	app, calls := newIdempotencyTestApp(http.StatusOK)
+
+	doMultipartRequest(t, app, "/send/message", "abc", "boundary-first", "hello")
+	resp := doMultipartRequest(t, app, "/send/message", "abc", "boundary-retry", "hello")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get(HeaderIdempotentReplayed) != "true" {
+		t.Fatalf("expected retry with a new boundary to replay, got status %d", resp.StatusCode)
+	}
+
+	resp = doMultipartRequest(t, app, "/send/message", "abc", "boundary-other", "changed")
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected status 409 for a different caption, got %d", resp.StatusCode)
+	}
+	if *calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", *calls)
+	}
+}
+
+func TestIdempotencyRejectsDifferentQuery(t *testing.T) {
+	app, calls := newIdempotencyTestApp(http.StatusOK)
+
+	doMultipartRequest(t, app, "/send/message", "abc", "b1", "hello")
+	resp := doMultipartRequest(t, app, "/send/message?async=true", "abc", "b1", "hello")
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected status 409 for a different query, got %d", resp.StatusCode)
+	}
+	if *calls != 1 {
+		t.Fatalf("expected handler to run once, ran %d times", *calls)
+	}
+}
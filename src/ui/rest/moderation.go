@@ -0,0 +1,84 @@
+package rest
+
+import (
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Moderation struct {
+	Service domainModeration.IModerationUsecase
+}
+
+func InitRestModeration(app fiber.Router, service domainModeration.IModerationUsecase) Moderation {
+	rest := Moderation{Service: service}
+	app.Get("/group/moderation", rest.GetRules)
+	app.Post("/group/moderation", rest.SetRules)
+	app.Post("/group/moderation/delete", rest.DeleteRules)
+	app.Get("/group/moderation/audit", rest.GetAuditLog)
+	return rest
+}
+
+func (controller *Moderation) SetRules(c *fiber.Ctx) error {
+	var request domainModeration.SetRulesRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.SetRules(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success set group moderation rules",
+		Results: response,
+	})
+}
+
+func (controller *Moderation) GetRules(c *fiber.Ctx) error {
+	var request domainModeration.GroupRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.GetRules(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get group moderation rules",
+		Results: response,
+	})
+}
+
+func (controller *Moderation) DeleteRules(c *fiber.Ctx) error {
+	var request domainModeration.GroupRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.DeleteRules(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success delete group moderation rules",
+		Results: nil,
+	})
+}
+
+func (controller *Moderation) GetAuditLog(c *fiber.Ctx) error {
+	var request domainModeration.AuditLogRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.GetAuditLog(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get group moderation audit log",
+		Results: response,
+	})
+}
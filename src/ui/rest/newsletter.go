@@ -12,7 +12,16 @@ type Newsletter struct {
 
 func InitRestNewsletter(app fiber.Router, service domainNewsletter.INewsletterUsecase) Newsletter {
 	rest := Newsletter{Service: service}
+	app.Post("/newsletter", rest.Create)
+	app.Get("/newsletter/info", rest.Info)
+	app.Post("/newsletter/update", rest.Update)
+	app.Post("/newsletter/delete", rest.Delete)
+	app.Post("/newsletter/follow", rest.Follow)
 	app.Post("/newsletter/unfollow", rest.Unfollow)
+	app.Post("/newsletter/mute", rest.Mute)
+	app.Get("/newsletter/messages", rest.Messages)
+	app.Post("/newsletter/send", rest.Send)
+	app.Get("/newsletter/stats", rest.Stats)
 	return rest
 }
 
@@ -30,3 +39,164 @@ func (controller *Newsletter) Unfollow(c *fiber.Ctx) error {
 		Message: "Success unfollow newsletter",
 	})
 }
+
+func (controller *Newsletter) Create(c *fiber.Ctx) error {
+	var request domainNewsletter.CreateRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	if file, errFile := c.FormFile("picture"); errFile == nil {
+		request.Picture = file
+	}
+
+	response, err := controller.Service.Create(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success create newsletter",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Info(c *fiber.Ctx) error {
+	var request domainNewsletter.InfoRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.Info(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get newsletter info",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Update(c *fiber.Ctx) error {
+	var request domainNewsletter.UpdateRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	if file, errFile := c.FormFile("picture"); errFile == nil {
+		request.Picture = file
+	}
+
+	response, err := controller.Service.Update(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success update newsletter",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Delete(c *fiber.Ctx) error {
+	var request domainNewsletter.NewsletterRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.Delete(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success delete newsletter",
+	})
+}
+
+func (controller *Newsletter) Follow(c *fiber.Ctx) error {
+	var request domainNewsletter.FollowRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.Follow(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success follow newsletter",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Mute(c *fiber.Ctx) error {
+	var request domainNewsletter.MuteRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.Mute(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	message := "Success unmute newsletter"
+	if request.Mute {
+		message = "Success mute newsletter"
+	}
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: message,
+	})
+}
+
+func (controller *Newsletter) Messages(c *fiber.Ctx) error {
+	var request domainNewsletter.MessagesRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.Messages(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get newsletter messages",
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Send(c *fiber.Ctx) error {
+	var request domainNewsletter.SendRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	if file, errFile := c.FormFile("image"); errFile == nil {
+		request.Image = file
+	}
+	if file, errFile := c.FormFile("video"); errFile == nil {
+		request.Video = file
+	}
+
+	response, err := controller.Service.Send(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Newsletter) Stats(c *fiber.Ctx) error {
+	var request domainNewsletter.StatsRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.Stats(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get newsletter stats",
+		Results: response,
+	})
+}
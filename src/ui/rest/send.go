@@ -1,13 +1,19 @@
 package rest
 
 import (
+	"encoding/json"
+	"fmt"
+
 	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/middleware"
 	"github.com/gofiber/fiber/v2"
 )
 
 type Send struct {
-	Service domainSend.ISendUsecase
+	Service    domainSend.ISendUsecase
+	JobService domainSend.ISendJobUsecase
 }
 
 func applyAgentID(c *fiber.Ctx, current string) string {
@@ -23,20 +29,26 @@ func applyAgentID(c *fiber.Ctx, current string) string {
 	return current
 }
 
-func InitRestSend(app fiber.Router, service domainSend.ISendUsecase) Send {
-	rest := Send{Service: service}
-	app.Post("/send/message", rest.SendText)
-	app.Post("/send/image", rest.SendImage)
-	app.Post("/send/file", rest.SendFile)
-	app.Post("/send/video", rest.SendVideo)
-	app.Post("/send/sticker", rest.SendSticker)
-	app.Post("/send/contact", rest.SendContact)
-	app.Post("/send/link", rest.SendLink)
-	app.Post("/send/location", rest.SendLocation)
-	app.Post("/send/audio", rest.SendAudio)
-	app.Post("/send/poll", rest.SendPoll)
-	app.Post("/send/presence", rest.SendPresence)
-	app.Post("/send/chat-presence", rest.SendChatPresence)
+func InitRestSend(app fiber.Router, service domainSend.ISendUsecase, jobService domainSend.ISendJobUsecase) Send {
+	rest := Send{Service: service, JobService: jobService}
+	idempotency := middleware.Idempotency()
+	app.Post("/send/message", idempotency, rest.SendText)
+	app.Post("/send/message/batch", idempotency, rest.SendTextBatch)
+	app.Post("/send/image", idempotency, rest.SendImage)
+	app.Post("/send/image/batch", idempotency, rest.SendImageBatch)
+	app.Post("/send/file", idempotency, rest.SendFile)
+	app.Post("/send/file/batch", idempotency, rest.SendFileBatch)
+	app.Post("/send/video", idempotency, rest.SendVideo)
+	app.Post("/send/sticker", idempotency, rest.SendSticker)
+	app.Post("/send/contact", idempotency, rest.SendContact)
+	app.Post("/send/link", idempotency, rest.SendLink)
+	app.Post("/send/location", idempotency, rest.SendLocation)
+	app.Post("/send/audio", idempotency, rest.SendAudio)
+	app.Post("/send/album", idempotency, rest.SendAlbum)
+	app.Post("/send/poll", idempotency, rest.SendPoll)
+	app.Post("/send/presence", idempotency, rest.SendPresence)
+	app.Post("/send/chat-presence", idempotency, rest.SendChatPresence)
+	app.Get("/jobs/:id", rest.GetJob)
 	return rest
 }
 
@@ -121,6 +133,18 @@ func (controller *Send) SendVideo(c *fiber.Ctx) error {
 	request.AgentID = applyAgentID(c, request.AgentID)
 	utils.SanitizePhone(&request.Phone)
 
+	if c.QueryBool("async") {
+		job, err := controller.JobService.SubmitVideo(c.UserContext(), request, c.Query("callback_url"))
+		utils.PanicIfNeeded(err)
+
+		return c.Status(fiber.StatusAccepted).JSON(utils.ResponseData{
+			Status:  fiber.StatusAccepted,
+			Code:    "SUCCESS",
+			Message: "Video send job queued",
+			Results: job,
+		})
+	}
+
 	response, err := controller.Service.SendVideo(c.UserContext(), request)
 	utils.PanicIfNeeded(err)
 
@@ -132,6 +156,44 @@ func (controller *Send) SendVideo(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *Send) SendAlbum(c *fiber.Ctx) error {
+	var request domainSend.AlbumRequest
+	request.Compress = true
+
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	// Multipart bodies carry the items as JSON and each item names the form field holding its file
+	if raw := c.FormValue("items"); raw != "" && len(request.Items) == 0 {
+		if err := json.Unmarshal([]byte(raw), &request.Items); err != nil {
+			panic(pkgError.ValidationError("items must be a JSON array of {\"type\", \"url\" or \"file\", \"caption\"} objects"))
+		}
+	}
+	for i := range request.Items {
+		if request.Items[i].File == "" {
+			continue
+		}
+		file, errFile := c.FormFile(request.Items[i].File)
+		if errFile != nil {
+			panic(pkgError.ValidationError(fmt.Sprintf("items[%d]: file field %q not found in upload", i, request.Items[i].File)))
+		}
+		request.Items[i].Upload = file
+	}
+
+	request.AgentID = applyAgentID(c, request.AgentID)
+	utils.SanitizePhone(&request.Phone)
+
+	response, err := controller.Service.SendAlbum(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
 func (controller *Send) SendSticker(c *fiber.Ctx) error {
 	var request domainSend.StickerRequest
 	err := c.BodyParser(&request)
@@ -291,3 +353,86 @@ func (controller *Send) SendChatPresence(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+func (controller *Send) GetJob(c *fiber.Ctx) error {
+	job, err := controller.JobService.GetJob(c.UserContext(), applyAgentID(c, ""), c.Params("id"))
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Job %s is %s", job.ID, job.Status),
+		Results: job,
+	})
+}
+
+// applyBatchRecipients reads the JSON-encoded "recipients" form field used by multipart batch uploads
+// and normalizes every phone the same way single sends do.
+func applyBatchRecipients(c *fiber.Ctx, base *domainSend.BatchBaseRequest) {
+	if raw := c.FormValue("recipients"); raw != "" && len(base.Recipients) == 0 {
+		if err := json.Unmarshal([]byte(raw), &base.Recipients); err != nil {
+			panic(pkgError.ValidationError("recipients must be a JSON array of {\"phone\", \"variables\"} objects"))
+		}
+	}
+	base.AgentID = applyAgentID(c, base.AgentID)
+	for i := range base.Recipients {
+		utils.SanitizePhone(&base.Recipients[i].Phone)
+	}
+}
+
+func batchResponseData(response domainSend.BatchResponse) utils.ResponseData {
+	return utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Batch processed: %d sent, %d failed", response.Sent, response.Failed),
+		Results: response,
+	}
+}
+
+func (controller *Send) SendTextBatch(c *fiber.Ctx) error {
+	var request domainSend.BatchMessageRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	applyBatchRecipients(c, &request.BatchBaseRequest)
+
+	response, err := controller.Service.SendTextBatch(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(batchResponseData(response))
+}
+
+func (controller *Send) SendImageBatch(c *fiber.Ctx) error {
+	var request domainSend.BatchImageRequest
+	request.Compress = true
+
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	if file, errFile := c.FormFile("image"); errFile == nil {
+		request.Image = file
+	}
+	applyBatchRecipients(c, &request.BatchBaseRequest)
+
+	response, err := controller.Service.SendImageBatch(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(batchResponseData(response))
+}
+
+func (controller *Send) SendFileBatch(c *fiber.Ctx) error {
+	var request domainSend.BatchFileRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	file, err := c.FormFile("file")
+	utils.PanicIfNeeded(err)
+	request.File = file
+
+	applyBatchRecipients(c, &request.BatchBaseRequest)
+
+	response, err := controller.Service.SendFileBatch(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(batchResponseData(response))
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/domains/session"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 )
@@ -200,6 +201,54 @@ func (h *Handler) GetQR(c *fiber.Ctx) error {
 	return c.JSON(resp)
 }
 
+// POST /sessions/:agentId/pair-code
+func (h *Handler) RequestPairCode(c *fiber.Ctx) error {
+	agentID := c.Params("agentId")
+	var req session.PairCodeRequest
+	if err := c.BodyParser(&req); err != nil && err != io.EOF {
+		return c.Status(400).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_PAYLOAD",
+				"message": "Invalid request body: " + err.Error(),
+			},
+		})
+	}
+	if agentID == "" || strings.TrimSpace(req.PhoneNumber) == "" {
+		return c.Status(400).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    "INVALID_PAYLOAD",
+				"message": "agentId and phoneNumber are required",
+			},
+		})
+	}
+
+	resp, err := h.usecase.RequestPairCode(agentID, req)
+	if err != nil {
+		msg := err.Error()
+		code := "INTERNAL_ERROR"
+		status := 500
+		var validationErr pkgError.ValidationError
+		if errors.As(err, &validationErr) {
+			code = "INVALID_PAYLOAD"
+			status = 400
+		} else if strings.Contains(strings.ToLower(msg), "not found") {
+			code = "SESSION_NOT_FOUND"
+			status = 404
+		} else if strings.Contains(strings.ToLower(msg), "already logged in") || strings.Contains(strings.ToLower(msg), "already saved") {
+			code = "SESSION_ALREADY_LOGGED_IN"
+			status = 409
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error": fiber.Map{
+				"code":    code,
+				"message": msg,
+			},
+		})
+	}
+
+	return c.JSON(resp)
+}
+
 // GET /sessions
 func (h *Handler) ListSessions(c *fiber.Ctx) error {
 	logrus.Info("ListSessions handler called")
@@ -15,4 +15,5 @@ func InitRoutes(app fiber.Router, usecase session.ISessionUsecase) {
 	app.Delete("/sessions/:agentId", handler.DeleteSession)
 	app.Post("/sessions/:agentId/reconnect", handler.ReconnectSession)
 	app.Post("/sessions/:agentId/qr", handler.GetQR)
+	app.Post("/sessions/:agentId/pair-code", handler.RequestPairCode)
 }
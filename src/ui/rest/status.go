@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"fmt"
+
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+type Status struct {
+	Service domainStatus.IStatusUsecase
+}
+
+func InitRestStatus(app fiber.Router, service domainStatus.IStatusUsecase) Status {
+	rest := Status{Service: service}
+	idempotency := middleware.Idempotency()
+	app.Get("/status", rest.ListStatuses)
+	app.Post("/status/text", idempotency, rest.PostText)
+	app.Post("/status/image", idempotency, rest.PostImage)
+	app.Post("/status/video", idempotency, rest.PostVideo)
+	return rest
+}
+
+func applyStatusAudience(c *fiber.Ctx, request *domainStatus.BaseRequest) {
+	request.AgentID = applyAgentID(c, request.AgentID)
+	for i := range request.AudienceJIDs {
+		utils.SanitizePhone(&request.AudienceJIDs[i])
+	}
+}
+
+func (controller *Status) PostText(c *fiber.Ctx) error {
+	var request domainStatus.TextRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	applyStatusAudience(c, &request.BaseRequest)
+
+	response, err := controller.Service.PostText(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) PostImage(c *fiber.Ctx) error {
+	var request domainStatus.ImageRequest
+	request.Compress = true
+
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	if file, errFile := c.FormFile("image"); errFile == nil {
+		request.Image = file
+	}
+	applyStatusAudience(c, &request.BaseRequest)
+
+	response, err := controller.Service.PostImage(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) PostVideo(c *fiber.Ctx) error {
+	var request domainStatus.VideoRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	if file, errFile := c.FormFile("video"); errFile == nil {
+		request.Video = file
+	}
+	applyStatusAudience(c, &request.BaseRequest)
+
+	response, err := controller.Service.PostVideo(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: response.Status,
+		Results: response,
+	})
+}
+
+func (controller *Status) ListStatuses(c *fiber.Ctx) error {
+	var request domainStatus.ListRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	request.AgentID = applyAgentID(c, request.AgentID)
+
+	response, err := controller.Service.ListStatuses(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: fmt.Sprintf("Found %d statuses", len(response.Data)),
+		Results: response,
+	})
+}
@@ -16,12 +16,20 @@ func InitRestUser(app fiber.Router, service domainUser.IUserUsecase) User {
 	app.Get("/user/avatar", rest.UserAvatar)
 	app.Post("/user/avatar", rest.UserChangeAvatar)
 	app.Post("/user/pushname", rest.UserChangePushName)
+	app.Post("/user/about", rest.UserChangeAbout)
 	app.Get("/user/my/privacy", rest.UserMyPrivacySetting)
+	app.Post("/user/my/privacy", rest.UserSetPrivacySetting)
 	app.Get("/user/my/groups", rest.UserMyListGroups)
 	app.Get("/user/my/newsletters", rest.UserMyListNewsletter)
 	app.Get("/user/my/contacts", rest.UserMyListContacts)
 	app.Get("/user/check", rest.UserCheck)
+	app.Post("/user/check/bulk", rest.UserCheckBulk)
+	app.Get("/user/lid", rest.UserLIDMapping)
 	app.Get("/user/business-profile", rest.UserBusinessProfile)
+	app.Post("/user/business-profile", rest.UserUpdateBusinessProfile)
+	app.Get("/user/blocklist", rest.UserBlocklist)
+	app.Post("/user/block", rest.UserBlock)
+	app.Post("/user/unblock", rest.UserUnblock)
 
 	return rest
 }
@@ -92,6 +100,22 @@ func (controller *User) UserMyPrivacySetting(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *User) UserSetPrivacySetting(c *fiber.Ctx) error {
+	var request domainUser.SetPrivacySettingRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.SetPrivacySetting(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success set privacy",
+		Results: response,
+	})
+}
+
 func (controller *User) UserMyListGroups(c *fiber.Ctx) error {
 	response, err := controller.Service.MyListGroups(c.UserContext())
 	utils.PanicIfNeeded(err)
@@ -143,6 +167,21 @@ func (controller *User) UserChangePushName(c *fiber.Ctx) error {
 	})
 }
 
+func (controller *User) UserChangeAbout(c *fiber.Ctx) error {
+	var request domainUser.ChangeAboutRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	err = controller.Service.ChangeAbout(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success change about",
+	})
+}
+
 func (controller *User) UserCheck(c *fiber.Ctx) error {
 	var request domainUser.CheckRequest
 	err := c.QueryParser(&request)
@@ -176,3 +215,104 @@ func (controller *User) UserBusinessProfile(c *fiber.Ctx) error {
 		Results: response,
 	})
 }
+
+func (controller *User) UserBlocklist(c *fiber.Ctx) error {
+	response, err := controller.Service.Blocklist(c.UserContext())
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get blocklist",
+		Results: response,
+	})
+}
+
+func (controller *User) UserBlock(c *fiber.Ctx) error {
+	var request domainUser.BlockRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.Phone)
+
+	response, err := controller.Service.Block(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success block user",
+		Results: response,
+	})
+}
+
+func (controller *User) UserUnblock(c *fiber.Ctx) error {
+	var request domainUser.BlockRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	utils.SanitizePhone(&request.Phone)
+
+	response, err := controller.Service.Unblock(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success unblock user",
+		Results: response,
+	})
+}
+
+func (controller *User) UserUpdateBusinessProfile(c *fiber.Ctx) error {
+	var request domainUser.UpdateBusinessProfileRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.UpdateBusinessProfile(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success update business profile",
+		Results: response,
+	})
+}
+
+func (controller *User) UserCheckBulk(c *fiber.Ctx) error {
+	var request domainUser.BulkCheckRequest
+	err := c.BodyParser(&request)
+	utils.PanicIfNeeded(err)
+
+	// A CSV file is optional when the numbers are sent as a list
+	if file, err := c.FormFile("file"); err == nil {
+		request.File = file
+	}
+
+	response, err := controller.Service.CheckBulk(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success check numbers",
+		Results: response,
+	})
+}
+
+func (controller *User) UserLIDMapping(c *fiber.Ctx) error {
+	var request domainUser.LIDMappingRequest
+	err := c.QueryParser(&request)
+	utils.PanicIfNeeded(err)
+
+	response, err := controller.Service.LIDMapping(c.UserContext(), request)
+	utils.PanicIfNeeded(err)
+
+	return c.JSON(utils.ResponseData{
+		Status:  200,
+		Code:    "SUCCESS",
+		Message: "Success get LID mapping",
+		Results: response,
+	})
+}
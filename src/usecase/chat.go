@@ -12,6 +12,7 @@ import (
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
 	"github.com/sirupsen/logrus"
 	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/types"
 )
 
 type serviceChat struct {
@@ -35,6 +36,10 @@ func (service serviceChat) ListChats(ctx context.Context, request domainChat.Lis
 		Offset:     request.Offset,
 		SearchName: request.Search,
 		HasMedia:   request.HasMedia,
+		Archived:   request.Archived,
+		Muted:      request.Muted,
+		Unread:     request.Unread,
+		SortBy:     request.SortBy,
 	}
 
 	// Get chats from storage
@@ -53,6 +58,7 @@ func (service serviceChat) ListChats(ctx context.Context, request domainChat.Lis
 	}
 
 	// Convert entities to domain objects
+	now := time.Now()
 	chatInfos := make([]domainChat.ChatInfo, 0, len(chats))
 	for _, chat := range chats {
 		chatInfo := domainChat.ChatInfo{
@@ -60,9 +66,20 @@ func (service serviceChat) ListChats(ctx context.Context, request domainChat.Lis
 			Name:                chat.Name,
 			LastMessageTime:     chat.LastMessageTime.Format(time.RFC3339),
 			EphemeralExpiration: chat.EphemeralExpiration,
+			Archived:            chat.Archived,
+			Muted:               chat.IsMuted(now),
+			MarkedUnread:        chat.MarkedUnread,
+			UnreadCount:         chat.UnreadCount,
+			LastReadMessageID:   chat.LastReadMessageID,
 			CreatedAt:           chat.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:           chat.UpdatedAt.Format(time.RFC3339),
 		}
+		if !chat.LastActivity.IsZero() {
+			chatInfo.LastActivity = chat.LastActivity.Format(time.RFC3339)
+		}
+		if chatInfo.Muted && chat.MuteEndTimestamp > 0 {
+			chatInfo.MutedUntil = time.UnixMilli(chat.MuteEndTimestamp).Format(time.RFC3339)
+		}
 		chatInfos = append(chatInfos, chatInfo)
 	}
 
@@ -96,6 +113,15 @@ func (service serviceChat) GetChatMessages(ctx context.Context, request domainCh
 		logrus.WithError(err).WithField("chat_jid", request.ChatJID).Error("Failed to get chat info")
 		return response, err
 	}
+	// Direct chats addressed by LID are stored under the phone number JID once it is known
+	if jid, parseErr := types.ParseJID(request.ChatJID); chat == nil && parseErr == nil && jid.Server == types.HiddenUserServer {
+		if pn := whatsapp.ResolveJID(ctx, whatsapp.GetClient(), jid).PN; !pn.IsEmpty() {
+			request.ChatJID = pn.String()
+			if chat, err = service.chatStorageRepo.GetChat(request.ChatJID); err != nil {
+				return response, err
+			}
+		}
+	}
 	if chat == nil {
 		return response, fmt.Errorf("chat with JID %s not found", request.ChatJID)
 	}
@@ -155,10 +181,13 @@ func (service serviceChat) GetChatMessages(ctx context.Context, request domainCh
 	// Convert entities to domain objects
 	messageInfos := make([]domainChat.MessageInfo, 0, len(messages))
 	for _, message := range messages {
+		sender := storedMessageSender(message)
 		messageInfo := domainChat.MessageInfo{
 			ID:         message.ID,
 			ChatJID:    message.ChatJID,
 			SenderJID:  message.Sender,
+			SenderPN:   sender.PN.String(),
+			SenderLID:  sender.LID.String(),
 			Content:    message.Content,
 			Timestamp:  message.Timestamp.Format(time.RFC3339),
 			IsFromMe:   message.IsFromMe,
@@ -192,6 +221,7 @@ func (service serviceChat) GetChatMessages(ctx context.Context, request domainCh
 	response.Data = messageInfos
 	response.Pagination = pagination
 	response.ChatInfo = chatInfo
+	response.PinnedMessages = service.pinnedMessages(request.ChatJID)
 
 	logrus.WithFields(logrus.Fields{
 		"chat_jid":       request.ChatJID,
@@ -203,6 +233,25 @@ func (service serviceChat) GetChatMessages(ctx context.Context, request domainCh
 	return response, nil
 }
 
+// pinnedMessages lists the pins of a chat that are still in effect. A storage error only leaves the list empty.
+func (service serviceChat) pinnedMessages(chatJID string) []domainChat.PinnedMessageInfo {
+	pinned := make([]domainChat.PinnedMessageInfo, 0)
+	pins, err := service.chatStorageRepo.GetMessagePins(chatJID)
+	if err != nil {
+		logrus.WithError(err).WithField("chat_jid", chatJID).Warn("Failed to get pinned messages")
+		return pinned
+	}
+	for _, pin := range pins {
+		pinned = append(pinned, domainChat.PinnedMessageInfo{
+			MessageID: pin.MessageID,
+			PinnedBy:  pin.PinnedBy,
+			PinnedAt:  pin.PinnedAt.Format(time.RFC3339),
+			ExpiresAt: pin.ExpiresAt.Format(time.RFC3339),
+		})
+	}
+	return pinned
+}
+
 func (service serviceChat) PinChat(ctx context.Context, request domainChat.PinChatRequest) (response domainChat.PinChatResponse, err error) {
 	if err = validations.ValidatePinChat(ctx, &request); err != nil {
 		return response, err
@@ -244,3 +293,11 @@ func (service serviceChat) PinChat(ctx context.Context, request domainChat.PinCh
 
 	return response, nil
 }
+
+// storedMessageSender returns both forms of the sender of a stored message. Messages stored before the LID was
+// recorded only have the form they arrived with.
+func storedMessageSender(message *domainChatStorage.Message) utils.JIDPair {
+	sender, _ := types.ParseJID(message.Sender)
+	lid, _ := types.ParseJID(message.SenderLID)
+	return utils.NewJIDPair(sender, lid)
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// SetDisappearingTimer sets or clears the disappearing-messages timer of a 1:1 chat or group and records the new
+// timer in chat storage, so chat listings reflect it without waiting for the next incoming message.
+func (service serviceChat) SetDisappearingTimer(ctx context.Context, request domainChat.SetDisappearingTimerRequest) (response domainChat.SetDisappearingTimerResponse, err error) {
+	if err = validations.ValidateSetDisappearingTimer(ctx, &request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.GetClient()
+	targetJID, err := utils.ValidateJidWithLogin(client, request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+	if targetJID.Server == types.NewsletterServer {
+		return response, pkgError.ValidationError("disappearing messages cannot be set on newsletters")
+	}
+
+	timer := time.Duration(request.TimerSeconds) * time.Second
+	if err = client.SetDisappearingTimer(ctx, targetJID, timer, time.Now()); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"chat_jid":      request.ChatJID,
+			"timer_seconds": request.TimerSeconds,
+		}).Error("Failed to set disappearing timer")
+		return response, err
+	}
+
+	if err = service.syncChatDisappearingTimer(targetJID, request.TimerSeconds); err != nil {
+		// The timer is already applied on WhatsApp; storage catches up with the next message in the chat
+		logrus.WithError(err).WithField("chat_jid", targetJID.String()).Warn("Failed to store disappearing timer")
+	}
+
+	response.Status = "success"
+	response.ChatJID = targetJID.String()
+	response.TimerSeconds = request.TimerSeconds
+	if request.TimerSeconds == domainChat.DisappearingTimerOff {
+		response.Message = "Disappearing messages turned off"
+	} else {
+		response.Message = fmt.Sprintf("Disappearing messages set to %s", formatDisappearingTimer(request.TimerSeconds))
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"chat_jid":      response.ChatJID,
+		"timer_seconds": request.TimerSeconds,
+	}).Info("Disappearing timer updated successfully")
+
+	return response, nil
+}
+
+// SetDefaultDisappearingTimer sets the timer WhatsApp applies to new 1:1 chats started by this account
+func (service serviceChat) SetDefaultDisappearingTimer(ctx context.Context, request domainChat.SetDefaultDisappearingTimerRequest) (response domainChat.SetDefaultDisappearingTimerResponse, err error) {
+	if err = validations.ValidateSetDefaultDisappearingTimer(ctx, &request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.GetClient()
+	if client == nil {
+		return response, pkgError.ErrWaCLI
+	}
+	if !client.IsLoggedIn() {
+		return response, pkgError.ErrNotLoggedIn
+	}
+
+	timer := time.Duration(request.TimerSeconds) * time.Second
+	if err = client.SetDefaultDisappearingTimer(ctx, timer); err != nil {
+		logrus.WithError(err).WithField("timer_seconds", request.TimerSeconds).Error("Failed to set default disappearing timer")
+		return response, err
+	}
+
+	response.Status = "success"
+	response.TimerSeconds = request.TimerSeconds
+	if request.TimerSeconds == domainChat.DisappearingTimerOff {
+		response.Message = "Default disappearing messages turned off"
+	} else {
+		response.Message = fmt.Sprintf("Default disappearing messages set to %s", formatDisappearingTimer(request.TimerSeconds))
+	}
+
+	return response, nil
+}
+
+func (service serviceChat) syncChatDisappearingTimer(jid types.JID, timerSeconds uint32) error {
+	existing, err := service.chatStorageRepo.GetChat(jid.String())
+	if err != nil {
+		return err
+	}
+	name := ""
+	if existing == nil {
+		name = service.chatStorageRepo.GetChatNameWithPushName(jid, jid.String(), "", "")
+	}
+	return service.chatStorageRepo.StoreChat(chatWithDisappearingTimer(existing, jid.String(), name, timerSeconds))
+}
+
+// chatWithDisappearingTimer returns the chat record to store after a timer change, keeping everything else of an
+// existing record and creating a new one for chats that have no messages yet
+func chatWithDisappearingTimer(existing *domainChatStorage.Chat, jid, name string, timerSeconds uint32) *domainChatStorage.Chat {
+	if existing == nil {
+		return &domainChatStorage.Chat{
+			JID:                 jid,
+			Name:                name,
+			EphemeralExpiration: timerSeconds,
+		}
+	}
+	updated := *existing
+	updated.EphemeralExpiration = timerSeconds
+	return &updated
+}
+
+func formatDisappearingTimer(timerSeconds uint32) string {
+	switch timerSeconds {
+	case domainChat.DisappearingTimer24Hours:
+		return "24 hours"
+	case domainChat.DisappearingTimer7Days:
+		return "7 days"
+	case domainChat.DisappearingTimer90Days:
+		return "90 days"
+	default:
+		return fmt.Sprintf("%d seconds", timerSeconds)
+	}
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChatWithDisappearingTimer(t *testing.T) {
+	lastMessage := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	existing := &domainChatStorage.Chat{
+		JID:                 "6289685028129@s.whatsapp.net",
+		Name:                "Support",
+		LastMessageTime:     lastMessage,
+		EphemeralExpiration: domainChat.DisappearingTimer7Days,
+	}
+
+	updated := chatWithDisappearingTimer(existing, existing.JID, "", domainChat.DisappearingTimer90Days)
+	assert.Equal(t, domainChat.DisappearingTimer90Days, updated.EphemeralExpiration)
+	assert.Equal(t, "Support", updated.Name)
+	assert.Equal(t, lastMessage, updated.LastMessageTime)
+	assert.Equal(t, domainChat.DisappearingTimer7Days, existing.EphemeralExpiration, "existing record must not be modified")
+
+	created := chatWithDisappearingTimer(nil, "120363024512399999@g.us", "Legal", domainChat.DisappearingTimer90Days)
+	assert.Equal(t, "120363024512399999@g.us", created.JID)
+	assert.Equal(t, "Legal", created.Name)
+	assert.Equal(t, domainChat.DisappearingTimer90Days, created.EphemeralExpiration)
+
+	cleared := chatWithDisappearingTimer(existing, existing.JID, "", domainChat.DisappearingTimerOff)
+	assert.Equal(t, domainChat.DisappearingTimerOff, cleared.EphemeralExpiration)
+}
+
+func TestFormatDisappearingTimer(t *testing.T) {
+	assert.Equal(t, "24 hours", formatDisappearingTimer(domainChat.DisappearingTimer24Hours))
+	assert.Equal(t, "7 days", formatDisappearingTimer(domainChat.DisappearingTimer7Days))
+	assert.Equal(t, "90 days", formatDisappearingTimer(domainChat.DisappearingTimer90Days))
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waSyncAction"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func (service serviceChat) ArchiveChat(ctx context.Context, request domainChat.ArchiveChatRequest) (response domainChat.ChatActionResponse, err error) {
+	if err = validations.ValidateArchiveChat(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	lastTS, lastKey := service.lastMessageRange(targetJID)
+	patchInfo := appstate.BuildArchive(targetJID, request.Archived, lastTS, lastKey)
+	if err = service.sendChatAppState(ctx, patchInfo, "archive", request.ChatJID); err != nil {
+		return response, err
+	}
+
+	service.storeChatState(targetJID, domainChatStorage.ChatStateUpdate{Archived: proto.Bool(request.Archived)})
+
+	message := "Chat unarchived successfully"
+	if request.Archived {
+		message = "Chat archived successfully"
+	}
+	return chatActionResponse(targetJID, message), nil
+}
+
+func (service serviceChat) MuteChat(ctx context.Context, request domainChat.MuteChatRequest) (response domainChat.ChatActionResponse, err error) {
+	if err = validations.ValidateMuteChat(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	// A nil end timestamp mutes the chat indefinitely
+	var muteEnd *int64
+	if request.Muted && request.MuteUntil != "" {
+		muteUntil, _ := time.Parse(time.RFC3339, request.MuteUntil)
+		muteEnd = proto.Int64(muteUntil.UnixMilli())
+	}
+
+	patchInfo := appstate.BuildMuteAbs(targetJID, request.Muted, muteEnd)
+	if err = service.sendChatAppState(ctx, patchInfo, "mute", request.ChatJID); err != nil {
+		return response, err
+	}
+
+	stored := int64(0)
+	if request.Muted {
+		stored = -1
+		if muteEnd != nil {
+			stored = *muteEnd
+		}
+	}
+	service.storeChatState(targetJID, domainChatStorage.ChatStateUpdate{MuteEndTimestamp: proto.Int64(stored)})
+
+	message := "Chat unmuted successfully"
+	if request.Muted {
+		message = "Chat muted successfully"
+	}
+	return chatActionResponse(targetJID, message), nil
+}
+
+func (service serviceChat) MarkChatAsRead(ctx context.Context, request domainChat.MarkChatAsReadRequest) (response domainChat.ChatActionResponse, err error) {
+	if err = validations.ValidateMarkChatAsRead(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	lastTS, lastKey := service.lastMessageRange(targetJID)
+	patchInfo := appstate.BuildMarkChatAsRead(targetJID, request.Read, lastTS, lastKey)
+	if err = service.sendChatAppState(ctx, patchInfo, "mark_read", request.ChatJID); err != nil {
+		return response, err
+	}
+
+	if request.Read {
+		var readIDs []string
+		if lastKey != nil {
+			readIDs = []string{lastKey.GetID()}
+		}
+		if err = service.chatStorageRepo.MarkChatRead(targetJID.String(), readIDs, time.Now()); err != nil {
+			logrus.WithError(err).WithField("chat_jid", targetJID.String()).Warn("Failed to store chat read state")
+		}
+	} else {
+		service.storeChatState(targetJID, domainChatStorage.ChatStateUpdate{MarkedUnread: proto.Bool(true)})
+	}
+
+	message := "Chat marked as unread successfully"
+	if request.Read {
+		message = "Chat marked as read successfully"
+	}
+	return chatActionResponse(targetJID, message), nil
+}
+
+// ClearChat removes all messages from a chat on every linked device while keeping the chat in the list
+func (service serviceChat) ClearChat(ctx context.Context, request domainChat.ClearChatRequest) (response domainChat.ChatActionResponse, err error) {
+	if err = validations.ValidateClearChat(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	lastTS, lastKey := service.lastMessageRange(targetJID)
+	if err = service.sendChatAppState(ctx, buildClearChat(targetJID, lastTS, lastKey), "clear", request.ChatJID); err != nil {
+		return response, err
+	}
+
+	if err = service.chatStorageRepo.ClearChatMessages(targetJID.String()); err != nil {
+		logrus.WithError(err).WithField("chat_jid", targetJID.String()).Warn("Failed to clear stored messages")
+	}
+
+	return chatActionResponse(targetJID, "Chat cleared successfully"), nil
+}
+
+// DeleteChat removes the chat and its messages on every linked device
+func (service serviceChat) DeleteChat(ctx context.Context, request domainChat.DeleteChatRequest) (response domainChat.ChatActionResponse, err error) {
+	if err = validations.ValidateDeleteChat(ctx, &request); err != nil {
+		return response, err
+	}
+
+	targetJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.ChatJID)
+	if err != nil {
+		return response, err
+	}
+
+	lastTS, lastKey := service.lastMessageRange(targetJID)
+	patchInfo := appstate.BuildDeleteChat(targetJID, lastTS, lastKey)
+	if err = service.sendChatAppState(ctx, patchInfo, "delete", request.ChatJID); err != nil {
+		return response, err
+	}
+
+	if err = service.chatStorageRepo.DeleteChat(targetJID.String()); err != nil {
+		logrus.WithError(err).WithField("chat_jid", targetJID.String()).Warn("Failed to delete stored chat")
+	}
+
+	return chatActionResponse(targetJID, "Chat deleted successfully"), nil
+}
+
+func (service serviceChat) sendChatAppState(ctx context.Context, patchInfo appstate.PatchInfo, action, chatJID string) error {
+	if err := whatsapp.GetClient().SendAppState(ctx, patchInfo); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"chat_jid": chatJID,
+			"action":   action,
+		}).Error("Failed to send chat app state")
+		return err
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"chat_jid": chatJID,
+		"action":   action,
+	}).Info("Chat app state updated successfully")
+	return nil
+}
+
+// storeChatState mirrors a change into chat storage. WhatsApp does not echo our own app state patches back as
+// events, so without this the stored state would lag until the next full sync.
+func (service serviceChat) storeChatState(jid types.JID, update domainChatStorage.ChatStateUpdate) {
+	if err := service.chatStorageRepo.UpdateChatState(jid.String(), update); err != nil {
+		logrus.WithError(err).WithField("chat_jid", jid.String()).Warn("Failed to store chat state")
+	}
+}
+
+// lastMessageRange returns the newest stored message of a chat, which WhatsApp uses to scope archive, read, clear
+// and delete actions. Both values are zero when nothing is stored, and whatsmeow then falls back to the current time.
+func (service serviceChat) lastMessageRange(jid types.JID) (time.Time, *waCommon.MessageKey) {
+	messages, err := service.chatStorageRepo.GetMessages(&domainChatStorage.MessageFilter{ChatJID: jid.String(), Limit: 1})
+	if err != nil || len(messages) == 0 {
+		return time.Time{}, nil
+	}
+	return messages[0].Timestamp, storedMessageKey(jid, messages[0])
+}
+
+// storedMessageKey builds the key WhatsApp uses to refer to a stored message, including the sender for incoming
+// group messages
+func storedMessageKey(chatJID types.JID, message *domainChatStorage.Message) *waCommon.MessageKey {
+	key := &waCommon.MessageKey{
+		RemoteJID: proto.String(chatJID.String()),
+		FromMe:    proto.Bool(message.IsFromMe),
+		ID:        proto.String(message.ID),
+	}
+	if chatJID.Server == types.GroupServer && !message.IsFromMe && message.Sender != "" {
+		key.Participant = proto.String(message.Sender)
+	}
+	return key
+}
+
+// buildClearChat builds the clear-chat patch, which whatsmeow has no builder for. The index flags match what
+// WhatsApp Web sends when clearing without keeping starred messages.
+func buildClearChat(target types.JID, lastMessageTimestamp time.Time, lastMessageKey *waCommon.MessageKey) appstate.PatchInfo {
+	if lastMessageTimestamp.IsZero() {
+		lastMessageTimestamp = time.Now()
+	}
+	messageRange := &waSyncAction.SyncActionMessageRange{
+		LastMessageTimestamp: proto.Int64(lastMessageTimestamp.Unix()),
+	}
+	if lastMessageKey != nil {
+		messageRange.Messages = []*waSyncAction.SyncActionMessage{{
+			Key:       lastMessageKey,
+			Timestamp: proto.Int64(lastMessageTimestamp.Unix()),
+		}}
+	}
+
+	return appstate.PatchInfo{
+		Type: appstate.WAPatchRegularHigh,
+		Mutations: []appstate.MutationInfo{{
+			Index:   []string{appstate.IndexClearChat, target.String(), "1", "0"},
+			Version: 6,
+			Value: &waSyncAction.SyncActionValue{
+				ClearChatAction: &waSyncAction.ClearChatAction{MessageRange: messageRange},
+			},
+		}},
+	}
+}
+
+func chatActionResponse(jid types.JID, message string) domainChat.ChatActionResponse {
+	return domainChat.ChatActionResponse{
+		Status:  "success",
+		Message: message,
+		ChatJID: jid.String(),
+	}
+}
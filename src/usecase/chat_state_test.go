@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	"github.com/stretchr/testify/assert"
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestStoredMessageKey(t *testing.T) {
+	group := types.NewJID("120363024512399999", types.GroupServer)
+	incoming := &domainChatStorage.Message{ID: "3EB0ABC", Sender: "6289685028129@s.whatsapp.net"}
+
+	key := storedMessageKey(group, incoming)
+	assert.Equal(t, group.String(), key.GetRemoteJID())
+	assert.Equal(t, "3EB0ABC", key.GetID())
+	assert.False(t, key.GetFromMe())
+	assert.Equal(t, "6289685028129@s.whatsapp.net", key.GetParticipant())
+
+	own := &domainChatStorage.Message{ID: "3EB0DEF", Sender: "6281111111111@s.whatsapp.net", IsFromMe: true}
+	key = storedMessageKey(group, own)
+	assert.True(t, key.GetFromMe())
+	assert.Nil(t, key.Participant)
+
+	direct := types.NewJID("6289685028129", types.DefaultUserServer)
+	key = storedMessageKey(direct, incoming)
+	assert.Nil(t, key.Participant)
+}
+
+func TestBuildClearChat(t *testing.T) {
+	target := types.NewJID("6289685028129", types.DefaultUserServer)
+	lastTS := time.Unix(1700000000, 0)
+	key := storedMessageKey(target, &domainChatStorage.Message{ID: "3EB0ABC"})
+
+	patch := buildClearChat(target, lastTS, key)
+	assert.Equal(t, appstate.WAPatchRegularHigh, patch.Type)
+	assert.Len(t, patch.Mutations, 1)
+
+	mutation := patch.Mutations[0]
+	assert.Equal(t, []string{appstate.IndexClearChat, target.String(), "1", "0"}, mutation.Index)
+	messageRange := mutation.Value.GetClearChatAction().GetMessageRange()
+	assert.Equal(t, int64(1700000000), messageRange.GetLastMessageTimestamp())
+	assert.Len(t, messageRange.GetMessages(), 1)
+	assert.Equal(t, "3EB0ABC", messageRange.GetMessages()[0].GetKey().GetID())
+
+	patch = buildClearChat(target, time.Time{}, nil)
+	messageRange = patch.Mutations[0].Value.GetClearChatAction().GetMessageRange()
+	assert.NotZero(t, messageRange.GetLastMessageTimestamp())
+	assert.Empty(t, messageRange.GetMessages())
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"testing"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+func TestStoredMessageSender(t *testing.T) {
+	sender := storedMessageSender(&domainChatStorage.Message{
+		Sender:    "6281234567890@s.whatsapp.net",
+		SenderLID: "123456789012345@lid",
+	})
+	if sender.PN.String() != "6281234567890@s.whatsapp.net" || sender.LID.String() != "123456789012345@lid" {
+		t.Errorf("storedMessageSender() = %+v, want both forms", sender)
+	}
+
+	// Messages stored before the LID was recorded may have a LID sender and no phone number
+	sender = storedMessageSender(&domainChatStorage.Message{Sender: "123456789012345:2@lid"})
+	if !sender.PN.IsEmpty() || sender.LID.String() != "123456789012345@lid" {
+		t.Errorf("storedMessageSender() = %+v, want only the LID without device", sender)
+	}
+}
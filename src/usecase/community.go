@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	domainCommunity "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/community"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+type serviceCommunity struct{}
+
+func NewCommunityService() domainCommunity.ICommunityUsecase {
+	return &serviceCommunity{}
+}
+
+// CreateCommunity creates an empty community. WhatsApp adds the announcement group on its own; existing groups
+// are added afterwards with LinkGroups.
+func (service serviceCommunity) CreateCommunity(ctx context.Context, request domainCommunity.CreateCommunityRequest) (response domainCommunity.CreateCommunityResponse, err error) {
+	if err = validations.ValidateCreateCommunity(ctx, request); err != nil {
+		return response, err
+	}
+	utils.MustLogin(whatsapp.GetClient())
+
+	communityInfo, err := whatsapp.GetClient().CreateGroup(ctx, whatsmeow.ReqCreateGroup{
+		Name:        request.Name,
+		GroupParent: types.GroupParent{IsParent: true},
+	})
+	if err != nil {
+		return response, err
+	}
+
+	if request.Description != "" {
+		if err = whatsapp.GetClient().SetGroupTopic(ctx, communityInfo.JID, "", "", request.Description); err != nil {
+			// The community exists at this point, so report it and let the description be set again later
+			logrus.WithError(err).WithField("community_id", communityInfo.JID.String()).Warn("Failed to set community description")
+		}
+	}
+
+	response.CommunityID = communityInfo.JID.String()
+	response.Name = communityInfo.Name
+	return response, nil
+}
+
+func (service serviceCommunity) CommunityInfo(ctx context.Context, request domainCommunity.CommunityInfoRequest) (response domainCommunity.CommunityInfoResponse, err error) {
+	if err = validations.ValidateCommunityInfo(ctx, request); err != nil {
+		return response, err
+	}
+
+	communityJID, err := service.communityJID(request.CommunityID)
+	if err != nil {
+		return response, err
+	}
+
+	communityInfo, err := whatsapp.GetClient().GetGroupInfo(ctx, communityJID)
+	if err != nil {
+		return response, err
+	}
+	if !communityInfo.IsParent {
+		return response, pkgError.ValidationError(fmt.Sprintf("%s is a group, not a community", communityJID))
+	}
+
+	response = domainCommunity.CommunityInfoResponse{
+		CommunityID:            communityJID.String(),
+		Name:                   communityInfo.Name,
+		Description:            communityInfo.Topic,
+		OwnerJID:               communityInfo.OwnerJID.String(),
+		CreatedAt:              communityInfo.GroupCreated,
+		IsJoinApprovalRequired: communityInfo.IsJoinApprovalRequired,
+	}
+
+	// Members of a community are the members of all its groups
+	members, err := whatsapp.GetClient().GetLinkedGroupsParticipants(ctx, communityJID)
+	if err != nil {
+		logrus.WithError(err).WithField("community_id", response.CommunityID).Warn("Failed to count community members")
+	} else {
+		response.MemberCount = len(members)
+	}
+
+	return response, nil
+}
+
+// GetSubGroups lists the groups of a community, with the announcement group reported separately
+func (service serviceCommunity) GetSubGroups(ctx context.Context, request domainCommunity.GetSubGroupsRequest) (response domainCommunity.GetSubGroupsResponse, err error) {
+	if err = validations.ValidateGetSubGroups(ctx, request); err != nil {
+		return response, err
+	}
+
+	communityJID, err := service.communityJID(request.CommunityID)
+	if err != nil {
+		return response, err
+	}
+
+	subGroups, err := whatsapp.GetClient().GetSubGroups(ctx, communityJID)
+	if err != nil {
+		return response, err
+	}
+
+	response.CommunityID = communityJID.String()
+	response.SubGroups = make([]domainCommunity.SubGroup, 0, len(subGroups))
+	for _, subGroup := range subGroups {
+		group := domainCommunity.SubGroup{
+			GroupID: subGroup.JID.String(),
+			Name:    subGroup.Name,
+		}
+		if subGroup.IsDefaultSubGroup {
+			response.AnnouncementGroup = &group
+			continue
+		}
+		response.SubGroups = append(response.SubGroups, group)
+	}
+
+	return response, nil
+}
+
+func (service serviceCommunity) LinkGroups(ctx context.Context, request domainCommunity.LinkGroupsRequest) (result []domainCommunity.LinkGroupStatus, err error) {
+	if err = validations.ValidateLinkGroups(ctx, request); err != nil {
+		return result, err
+	}
+
+	communityJID, err := service.communityJID(request.CommunityID)
+	if err != nil {
+		return result, err
+	}
+
+	return service.changeGroupLinks(request.GroupIDs, "link", func(groupJID types.JID) error {
+		return whatsapp.GetClient().LinkGroup(ctx, communityJID, groupJID)
+	}), nil
+}
+
+func (service serviceCommunity) UnlinkGroups(ctx context.Context, request domainCommunity.LinkGroupsRequest) (result []domainCommunity.LinkGroupStatus, err error) {
+	if err = validations.ValidateLinkGroups(ctx, request); err != nil {
+		return result, err
+	}
+
+	communityJID, err := service.communityJID(request.CommunityID)
+	if err != nil {
+		return result, err
+	}
+
+	return service.changeGroupLinks(request.GroupIDs, "unlink", func(groupJID types.JID) error {
+		return whatsapp.GetClient().UnlinkGroup(ctx, communityJID, groupJID)
+	}), nil
+}
+
+// changeGroupLinks applies a link or unlink to every group on its own, so one group that fails (for example
+// because we are not its admin) does not stop the rest of a large batch
+func (service serviceCommunity) changeGroupLinks(groupIDs []string, action string, change func(groupJID types.JID) error) []domainCommunity.LinkGroupStatus {
+	result := make([]domainCommunity.LinkGroupStatus, 0, len(groupIDs))
+	for _, groupID := range groupIDs {
+		groupJID, err := parseGroupJID(groupID)
+		if err == nil {
+			err = change(groupJID)
+		}
+		if err != nil {
+			result = append(result, domainCommunity.LinkGroupStatus{
+				GroupID: groupID,
+				Status:  "error",
+				Message: fmt.Sprintf("Failed to %s group: %v", action, err),
+			})
+			continue
+		}
+		result = append(result, domainCommunity.LinkGroupStatus{
+			GroupID: groupJID.String(),
+			Status:  "success",
+			Message: fmt.Sprintf("Group %sed successfully", action),
+		})
+	}
+	return result
+}
+
+func (service serviceCommunity) communityJID(communityID string) (types.JID, error) {
+	utils.MustLogin(whatsapp.GetClient())
+	return parseGroupJID(communityID)
+}
+
+// parseGroupJID accepts a group JID with or without the @g.us suffix
+func parseGroupJID(groupID string) (types.JID, error) {
+	utils.SanitizePhone(&groupID)
+	groupJID, err := utils.ParseJID(groupID)
+	if err != nil {
+		return groupJID, pkgError.ValidationError(err.Error())
+	}
+	if groupJID.Server != types.GroupServer {
+		return groupJID, pkgError.ValidationError(fmt.Sprintf("%s is not a group", groupID))
+	}
+	return groupJID, nil
+}
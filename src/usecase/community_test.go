@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestParseGroupJID(t *testing.T) {
+	for _, input := range []string{"120363025246125486@g.us", "120363025246125486"} {
+		jid, err := parseGroupJID(input)
+		if err != nil {
+			t.Fatalf("parseGroupJID(%q) returned error: %v", input, err)
+		}
+		if jid.Server != types.GroupServer || jid.User != "120363025246125486" {
+			t.Fatalf("parseGroupJID(%q) = %s", input, jid)
+		}
+	}
+
+	for _, input := range []string{"6281234567890@s.whatsapp.net", "6281234567890"} {
+		if _, err := parseGroupJID(input); err == nil {
+			t.Fatalf("parseGroupJID(%q) should reject a user JID", input)
+		}
+	}
+}
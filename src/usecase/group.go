@@ -7,6 +7,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -16,10 +17,14 @@ import (
 	"go.mau.fi/whatsmeow/types"
 )
 
-type serviceGroup struct{}
+type serviceGroup struct {
+	chatUsecase domainChat.IChatUsecase
+}
 
-func NewGroupService() domainGroup.IGroupUsecase {
-	return &serviceGroup{}
+func NewGroupService(chatUsecase domainChat.IChatUsecase) domainGroup.IGroupUsecase {
+	return &serviceGroup{
+		chatUsecase: chatUsecase,
+	}
 }
 
 func (service serviceGroup) JoinGroupWithLink(ctx context.Context, request domainGroup.JoinGroupWithLinkRequest) (groupID string, err error) {
@@ -346,6 +351,45 @@ func (service serviceGroup) SetGroupTopic(ctx context.Context, request domainGro
 	return whatsapp.GetClient().SetGroupTopic(ctx, groupJID, "", "", request.Topic)
 }
 
+func (service serviceGroup) SetGroupMemberAddMode(ctx context.Context, request domainGroup.SetGroupMemberAddModeRequest) (err error) {
+	if err = validations.ValidateSetGroupMemberAddMode(ctx, request); err != nil {
+		return err
+	}
+
+	groupJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.GroupID)
+	if err != nil {
+		return err
+	}
+
+	return whatsapp.GetClient().SetGroupMemberAddMode(ctx, groupJID, request.Mode)
+}
+
+func (service serviceGroup) SetGroupJoinApproval(ctx context.Context, request domainGroup.SetGroupJoinApprovalRequest) (err error) {
+	if err = validations.ValidateSetGroupJoinApproval(ctx, request); err != nil {
+		return err
+	}
+
+	groupJID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), request.GroupID)
+	if err != nil {
+		return err
+	}
+
+	return whatsapp.GetClient().SetGroupJoinApprovalMode(ctx, groupJID, request.JoinApproval)
+}
+
+func (service serviceGroup) SetGroupDisappearingTimer(ctx context.Context, request domainGroup.SetGroupDisappearingTimerRequest) (err error) {
+	if err = validations.ValidateSetGroupDisappearingTimer(ctx, request); err != nil {
+		return err
+	}
+
+	// The chat usecase applies the timer and records it in chat storage, which groups need as much as 1:1 chats
+	_, err = service.chatUsecase.SetDisappearingTimer(ctx, domainChat.SetDisappearingTimerRequest{
+		ChatJID:      request.GroupID,
+		TimerSeconds: request.TimerSeconds,
+	})
+	return err
+}
+
 // GroupInfo retrieves detailed information about a WhatsApp group
 func (service serviceGroup) GroupInfo(ctx context.Context, request domainGroup.GroupInfoRequest) (response domainGroup.GroupInfoResponse, err error) {
 	// Validate the incoming request
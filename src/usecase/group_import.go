@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// onWhatsAppBatchSize is how many numbers are checked per IsOnWhatsApp query
+	onWhatsAppBatchSize = 50
+	// participantAddChunkSize is how many people are added per request. WhatsApp flags accounts that add large
+	// numbers of people in one go, so big imports are split up and paced.
+	participantAddChunkSize = 20
+	// participantAddPause is the wait between two add requests of the same import
+	participantAddPause = 5 * time.Second
+)
+
+type phoneCSVRow struct {
+	Row   int
+	Input string
+}
+
+// ImportParticipants adds every phone number of a CSV file to a group. Numbers are normalized and checked on
+// WhatsApp first; people whose privacy settings block direct adds get a group invite instead. Every row gets its
+// own result, and a failing row never stops the rest of the import.
+func (service serviceGroup) ImportParticipants(ctx context.Context, request domainGroup.ImportParticipantsRequest) (response domainGroup.ImportParticipantsResponse, err error) {
+	if err = validations.ValidateImportParticipants(ctx, request); err != nil {
+		return response, err
+	}
+
+	client := whatsapp.GetClient()
+	groupJID, err := utils.ValidateJidWithLogin(client, request.GroupID)
+	if err != nil {
+		return response, err
+	}
+
+	file, err := request.File.Open()
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to open uploaded file: %v", err))
+	}
+	defer file.Close()
+
+	rows, err := parsePhoneCSV(file)
+	if err != nil {
+		return response, pkgError.ValidationError(fmt.Sprintf("file: %v", err))
+	}
+	if len(rows) == 0 {
+		return response, pkgError.ValidationError("file: no phone numbers found")
+	}
+
+	groupInfo, err := client.GetGroupInfo(ctx, groupJID)
+	if err != nil {
+		return response, err
+	}
+
+	results := make([]domainGroup.ImportParticipantResult, len(rows))
+	pending := prepareParticipantImport(rows, groupInfo.Participants, results)
+	toAdd := service.checkImportRegistration(ctx, client, pending, results)
+
+	for start := 0; start < len(toAdd); start += participantAddChunkSize {
+		chunk := toAdd[start:min(start+participantAddChunkSize, len(toAdd))]
+		if start > 0 {
+			select {
+			case <-ctx.Done():
+				for _, item := range toAdd[start:] {
+					setImportResult(&results[item.index], domainGroup.ImportStatusFailed, "import was cancelled before this number was added")
+				}
+				return summarizeParticipantImport(groupJID, results), nil
+			case <-time.After(participantAddPause):
+			}
+		}
+		service.addImportChunk(ctx, client, groupInfo, chunk, request.InviteMessage, results)
+	}
+
+	response = summarizeParticipantImport(groupJID, results)
+	logrus.WithFields(logrus.Fields{
+		"group_id": response.GroupID,
+		"total":    response.Total,
+		"added":    response.Added,
+		"invited":  response.Invited,
+		"failed":   response.Failed,
+	}).Info("Participant import finished")
+
+	return response, nil
+}
+
+type importCandidate struct {
+	index int
+	phone string // digits only
+	jid   types.JID
+}
+
+// prepareParticipantImport fills in the result of every row that needs no request to WhatsApp (invalid numbers,
+// duplicates and current members) and returns the rest
+func prepareParticipantImport(rows []phoneCSVRow, members []types.GroupParticipant, results []domainGroup.ImportParticipantResult) []importCandidate {
+	isMember := make(map[string]bool, len(members))
+	for _, member := range members {
+		isMember[member.JID.User] = true
+		isMember[member.PhoneNumber.User] = true
+	}
+
+	seen := make(map[string]int, len(rows))
+	var pending []importCandidate
+	for i, row := range rows {
+		results[i] = domainGroup.ImportParticipantResult{Row: row.Row, Input: row.Input}
+
+		phone, ok := normalizePhoneNumber(row.Input)
+		if !ok {
+			setImportResult(&results[i], domainGroup.ImportStatusInvalid, "not a valid phone number")
+			continue
+		}
+		jid := phone
+		utils.SanitizePhone(&jid)
+		results[i].Phone = jid
+
+		if firstRow, duplicate := seen[phone]; duplicate {
+			setImportResult(&results[i], domainGroup.ImportStatusDuplicate, fmt.Sprintf("same number as row %d", firstRow))
+			continue
+		}
+		seen[phone] = row.Row
+
+		if isMember[phone] {
+			setImportResult(&results[i], domainGroup.ImportStatusAlreadyMember, "already in the group")
+			continue
+		}
+		pending = append(pending, importCandidate{index: i, phone: phone})
+	}
+	return pending
+}
+
+// checkImportRegistration drops numbers that are not on WhatsApp and resolves the JID of the others
+func (service serviceGroup) checkImportRegistration(ctx context.Context, client *whatsmeow.Client, pending []importCandidate, results []domainGroup.ImportParticipantResult) []importCandidate {
+	var registered []importCandidate
+	for start := 0; start < len(pending); start += onWhatsAppBatchSize {
+		batch := pending[start:min(start+onWhatsAppBatchSize, len(pending))]
+
+		queries := make([]string, len(batch))
+		for i, candidate := range batch {
+			queries[i] = "+" + candidate.phone
+		}
+
+		responses, err := client.IsOnWhatsApp(ctx, queries)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to check imported numbers on WhatsApp")
+			for _, candidate := range batch {
+				setImportResult(&results[candidate.index], domainGroup.ImportStatusFailed, fmt.Sprintf("could not check the number on WhatsApp: %v", err))
+			}
+			continue
+		}
+
+		byQuery := make(map[string]types.IsOnWhatsAppResponse, len(responses))
+		for _, info := range responses {
+			byQuery[info.Query] = info
+		}
+		for i, candidate := range batch {
+			info, ok := byQuery[queries[i]]
+			if !ok || !info.IsIn {
+				setImportResult(&results[candidate.index], domainGroup.ImportStatusNotOnWhatsApp, "number is not registered on WhatsApp")
+				continue
+			}
+			candidate.jid = info.JID
+			registered = append(registered, candidate)
+		}
+	}
+	return registered
+}
+
+func (service serviceGroup) addImportChunk(ctx context.Context, client *whatsmeow.Client, groupInfo *types.GroupInfo, chunk []importCandidate, inviteMessage string, results []domainGroup.ImportParticipantResult) {
+	jids := make([]types.JID, len(chunk))
+	for i, candidate := range chunk {
+		jids[i] = candidate.jid
+	}
+
+	participants, err := client.UpdateGroupParticipants(ctx, groupInfo.JID, jids, whatsmeow.ParticipantChangeAdd)
+	if err != nil {
+		for _, candidate := range chunk {
+			setImportResult(&results[candidate.index], domainGroup.ImportStatusFailed, fmt.Sprintf("failed to add: %v", err))
+		}
+		return
+	}
+
+	matched := matchAddResults(jids, participants)
+	for i, candidate := range chunk {
+		result := &results[candidate.index]
+		participant := matched[i]
+		switch {
+		case participant == nil:
+			setImportResult(result, domainGroup.ImportStatusFailed, "WhatsApp returned no result for this number")
+		case participant.Error == 0:
+			setImportResult(result, domainGroup.ImportStatusAdded, "")
+		case participant.Error == 409:
+			setImportResult(result, domainGroup.ImportStatusAlreadyMember, "already in the group")
+		case participant.Error == 403 && participant.AddRequest != nil:
+			if err := sendGroupInvite(ctx, client, groupInfo, candidate.jid, participant.AddRequest, inviteMessage); err != nil {
+				setImportResult(result, domainGroup.ImportStatusFailed, fmt.Sprintf("privacy settings block adding, and sending an invite failed: %v", err))
+				continue
+			}
+			setImportResult(result, domainGroup.ImportStatusInvited, "privacy settings block adding, invite sent instead")
+		default:
+			setImportResult(result, domainGroup.ImportStatusFailed, fmt.Sprintf("WhatsApp refused the add (code %d)", participant.Error))
+		}
+	}
+}
+
+// matchAddResults pairs each requested JID with its entry in the add response. WhatsApp may answer with the LID
+// of a participant, so entries are matched on the phone number as well, and by position as a last resort.
+func matchAddResults(requested []types.JID, participants []types.GroupParticipant) []*types.GroupParticipant {
+	byUser := make(map[string]*types.GroupParticipant, len(participants)*2)
+	for i := range participants {
+		participant := &participants[i]
+		byUser[participant.JID.User] = participant
+		if !participant.PhoneNumber.IsEmpty() {
+			byUser[participant.PhoneNumber.User] = participant
+		}
+	}
+
+	matched := make([]*types.GroupParticipant, len(requested))
+	for i, jid := range requested {
+		if participant, ok := byUser[jid.User]; ok {
+			matched[i] = participant
+		} else if len(participants) == len(requested) {
+			matched[i] = &participants[i]
+		}
+	}
+	return matched
+}
+
+// sendGroupInvite sends the invite WhatsApp hands out when a user's privacy settings do not allow adding them
+func sendGroupInvite(ctx context.Context, client *whatsmeow.Client, groupInfo *types.GroupInfo, recipient types.JID, addRequest *types.GroupParticipantAddRequest, caption string) error {
+	if caption == "" {
+		caption = fmt.Sprintf("Join my WhatsApp group %s", groupInfo.Name)
+	}
+	_, err := client.SendMessage(ctx, recipient, &waE2E.Message{
+		GroupInviteMessage: &waE2E.GroupInviteMessage{
+			GroupJID:         proto.String(groupInfo.JID.String()),
+			InviteCode:       proto.String(addRequest.Code),
+			InviteExpiration: proto.Int64(addRequest.Expiration.Unix()),
+			GroupName:        proto.String(groupInfo.Name),
+			Caption:          proto.String(caption),
+		},
+	})
+	return err
+}
+
+// parsePhoneCSV reads the phone numbers of a CSV file. A first row without any digits is treated as a
+// header; its phone_number or phone column is used if present, otherwise the first column.
+func parsePhoneCSV(r io.Reader) ([]phoneCSVRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	// Row numbers are line numbers of the file, so they still match what the user sees when blank lines are skipped
+	var records [][]string
+	var lines []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+
+	column, start := 0, 0
+	if len(records) > 0 && !strings.ContainsAny(strings.Join(records[0], ""), "0123456789") {
+		start = 1
+		for i, header := range records[0] {
+			name := strings.ToLower(strings.TrimSpace(header))
+			if name == "phone_number" || name == "phone" {
+				column = i
+				break
+			}
+		}
+	}
+
+	var rows []phoneCSVRow
+	for i := start; i < len(records); i++ {
+		if column >= len(records[i]) {
+			continue
+		}
+		input := strings.TrimSpace(records[i][column])
+		if input == "" {
+			continue
+		}
+		rows = append(rows, phoneCSVRow{Row: lines[i], Input: input})
+	}
+	return rows, nil
+}
+
+// normalizePhoneNumber reduces a phone number as people write it (+62 812-3456-7890, 0062..., or a JID from an
+// export) to its digits in international format
+func normalizePhoneNumber(input string) (string, bool) {
+	phone, _, _ := strings.Cut(strings.TrimSpace(input), "@")
+	phone, _, _ = strings.Cut(phone, ":")
+	phone = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "", "+", "").Replace(phone)
+	phone = strings.TrimPrefix(phone, "00")
+
+	if len(phone) < 7 || len(phone) > 15 {
+		return "", false
+	}
+	for _, r := range phone {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return phone, true
+}
+
+func setImportResult(result *domainGroup.ImportParticipantResult, status, message string) {
+	result.Status = status
+	result.Message = message
+}
+
+func summarizeParticipantImport(groupJID types.JID, results []domainGroup.ImportParticipantResult) domainGroup.ImportParticipantsResponse {
+	response := domainGroup.ImportParticipantsResponse{
+		GroupID: groupJID.String(),
+		Total:   len(results),
+		Results: results,
+	}
+	for _, result := range results {
+		switch result.Status {
+		case domainGroup.ImportStatusAdded:
+			response.Added++
+		case domainGroup.ImportStatusInvited:
+			response.Invited++
+		case domainGroup.ImportStatusAlreadyMember, domainGroup.ImportStatusDuplicate:
+			response.Skipped++
+		default:
+			response.Failed++
+		}
+	}
+	return response
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	valid := map[string]string{
+		"6281234567890":                   "6281234567890",
+		"+62 812-3456-7890":               "6281234567890",
+		"(+62) 812.3456.7890":             "6281234567890",
+		"006281234567890":                 "6281234567890",
+		"6281234567890@s.whatsapp.net":    "6281234567890",
+		"6281234567890:12@s.whatsapp.net": "6281234567890",
+	}
+	for input, want := range valid {
+		got, ok := normalizePhoneNumber(input)
+		if !ok || got != want {
+			t.Errorf("normalizePhoneNumber(%q) = %q, %v; want %q", input, got, ok, want)
+		}
+	}
+
+	for _, input := range []string{"", "12345", "62812abc4567", "1234567890123456"} {
+		if got, ok := normalizePhoneNumber(input); ok {
+			t.Errorf("normalizePhoneNumber(%q) = %q, want invalid", input, got)
+		}
+	}
+}
+
+func TestParsePhoneCSV(t *testing.T) {
+	t.Run("plain list without header", func(t *testing.T) {
+		rows, err := parsePhoneCSV(strings.NewReader("6281111111111\n\n+62 822 2222 2222\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 2 || rows[0].Row != 1 || rows[1].Row != 3 || rows[1].Input != "+62 822 2222 2222" {
+			t.Fatalf("unexpected rows %+v", rows)
+		}
+	})
+
+	t.Run("export file uses the phone_number column", func(t *testing.T) {
+		export := "participant_jid,phone_number,lid,display_name,role\n" +
+			"123@lid,6281111111111@s.whatsapp.net,123@lid,,admin\n" +
+			"6282222222222@s.whatsapp.net,6282222222222@s.whatsapp.net,,,member\n"
+		rows, err := parsePhoneCSV(strings.NewReader(export))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 2 || rows[0].Input != "6281111111111@s.whatsapp.net" || rows[0].Row != 2 {
+			t.Fatalf("unexpected rows %+v", rows)
+		}
+	})
+
+	t.Run("header without phone column uses the first column", func(t *testing.T) {
+		rows, err := parsePhoneCSV(strings.NewReader("number,name\n6281111111111,Ann\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 1 || rows[0].Input != "6281111111111" {
+			t.Fatalf("unexpected rows %+v", rows)
+		}
+	})
+}
+
+func TestPrepareParticipantImport(t *testing.T) {
+	rows := []phoneCSVRow{
+		{Row: 1, Input: "6281111111111"},
+		{Row: 2, Input: "not a number"},
+		{Row: 3, Input: "+62 811 1111 1111"},
+		{Row: 4, Input: "6282222222222"},
+		{Row: 5, Input: "6283333333333"},
+	}
+	members := []types.GroupParticipant{{
+		JID:         types.NewJID("123456", types.HiddenUserServer),
+		PhoneNumber: types.NewJID("6282222222222", types.DefaultUserServer),
+	}}
+	results := make([]domainGroup.ImportParticipantResult, len(rows))
+
+	pending := prepareParticipantImport(rows, members, results)
+
+	if len(pending) != 2 || pending[0].phone != "6281111111111" || pending[1].phone != "6283333333333" {
+		t.Fatalf("unexpected pending %+v", pending)
+	}
+	want := []string{"", domainGroup.ImportStatusInvalid, domainGroup.ImportStatusDuplicate, domainGroup.ImportStatusAlreadyMember, ""}
+	for i, status := range want {
+		if results[i].Status != status {
+			t.Errorf("row %d status = %q, want %q", rows[i].Row, results[i].Status, status)
+		}
+	}
+	if results[0].Phone != "6281111111111@s.whatsapp.net" {
+		t.Errorf("phone = %q", results[0].Phone)
+	}
+}
+
+func TestMatchAddResults(t *testing.T) {
+	first := types.NewJID("6281111111111", types.DefaultUserServer)
+	second := types.NewJID("6282222222222", types.DefaultUserServer)
+
+	// Answered with a LID, but the phone number is known
+	participants := []types.GroupParticipant{
+		{JID: second, Error: 403},
+		{JID: types.NewJID("999", types.HiddenUserServer), PhoneNumber: first},
+	}
+	matched := matchAddResults([]types.JID{first, second}, participants)
+	if matched[0] != &participants[1] || matched[1] != &participants[0] {
+		t.Fatalf("unexpected match %+v", matched)
+	}
+
+	// Unknown entries fall back to the position only when the counts line up
+	participants = []types.GroupParticipant{{JID: types.NewJID("999", types.HiddenUserServer)}}
+	if matched = matchAddResults([]types.JID{first}, participants); matched[0] != &participants[0] {
+		t.Fatalf("expected positional match, got %+v", matched)
+	}
+	if matched = matchAddResults([]types.JID{first, second}, participants); matched[0] != nil || matched[1] != nil {
+		t.Fatalf("expected no match, got %+v", matched)
+	}
+}
+
+func TestSummarizeParticipantImport(t *testing.T) {
+	results := []domainGroup.ImportParticipantResult{
+		{Status: domainGroup.ImportStatusAdded},
+		{Status: domainGroup.ImportStatusAdded},
+		{Status: domainGroup.ImportStatusInvited},
+		{Status: domainGroup.ImportStatusAlreadyMember},
+		{Status: domainGroup.ImportStatusDuplicate},
+		{Status: domainGroup.ImportStatusNotOnWhatsApp},
+		{Status: domainGroup.ImportStatusInvalid},
+		{Status: domainGroup.ImportStatusFailed},
+	}
+	response := summarizeParticipantImport(types.NewJID("120363025246125486", types.GroupServer), results)
+	if response.Total != 8 || response.Added != 2 || response.Invited != 1 || response.Skipped != 2 || response.Failed != 3 {
+		t.Fatalf("unexpected summary %+v", response)
+	}
+}
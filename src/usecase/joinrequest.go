@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainJoinRequest "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/joinrequest"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+)
+
+type serviceJoinRequest struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
+
+func NewJoinRequestService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainJoinRequest.IJoinRequestUsecase {
+	return &serviceJoinRequest{chatStorageRepo: chatStorageRepo}
+}
+
+// SetPolicy replaces the join request policy of a group. It applies to requests arriving from now on; requests
+// that are already pending are decided with ReviewPending.
+func (service serviceJoinRequest) SetPolicy(ctx context.Context, request domainJoinRequest.SetPolicyRequest) (response domainJoinRequest.PolicyResponse, err error) {
+	request.AllowedPrefixes = normalizePhonePrefixes(request.AllowedPrefixes)
+	request.HookURL = strings.TrimSpace(request.HookURL)
+	if request.FailAction == "" {
+		request.FailAction = domainJoinRequest.DecisionReject
+	}
+	if err = validations.ValidateSetJoinRequestPolicy(ctx, request); err != nil {
+		return response, err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return response, err
+	}
+
+	policy := &domainChatStorage.JoinRequestPolicy{
+		GroupJID:        groupJID.String(),
+		Enabled:         request.Enabled,
+		AllowedPrefixes: request.AllowedPrefixes,
+		RequireContact:  request.RequireContact,
+		HookURL:         request.HookURL,
+		FailAction:      request.FailAction,
+	}
+	if err = service.chatStorageRepo.StoreJoinRequestPolicy(policy); err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to store join request policy: %v", err))
+	}
+
+	return toPolicyResponse(policy), nil
+}
+
+func (service serviceJoinRequest) GetPolicy(ctx context.Context, request domainJoinRequest.GroupRequest) (response domainJoinRequest.PolicyResponse, err error) {
+	policy, err := service.policy(ctx, request)
+	if err != nil {
+		return response, err
+	}
+	return toPolicyResponse(policy), nil
+}
+
+func (service serviceJoinRequest) DeletePolicy(ctx context.Context, request domainJoinRequest.GroupRequest) (err error) {
+	if err = validations.ValidateJoinRequestGroup(ctx, request); err != nil {
+		return err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.chatStorageRepo.DeleteJoinRequestPolicy(groupJID.String()); err != nil {
+		return pkgError.InternalServerError(fmt.Sprintf("failed to delete join request policy: %v", err))
+	}
+	return nil
+}
+
+// ReviewPending decides on every request that is pending right now, including the ones the policy left for an
+// admin before
+func (service serviceJoinRequest) ReviewPending(ctx context.Context, request domainJoinRequest.GroupRequest) (response domainJoinRequest.ReviewResponse, err error) {
+	policy, err := service.policy(ctx, request)
+	if err != nil {
+		return response, err
+	}
+	if !policy.Enabled {
+		return response, pkgError.ValidationError(fmt.Sprintf("join request policy of %s is disabled", policy.GroupJID))
+	}
+
+	client := whatsapp.GetClient()
+	utils.MustLogin(client)
+
+	decisions, err := whatsapp.ReviewJoinRequests(ctx, "", client, policy, false)
+	if err != nil {
+		return response, err
+	}
+
+	response.GroupID = policy.GroupJID
+	response.Decisions = decisions
+	return response, nil
+}
+
+func (service serviceJoinRequest) policy(ctx context.Context, request domainJoinRequest.GroupRequest) (*domainChatStorage.JoinRequestPolicy, error) {
+	if err := validations.ValidateJoinRequestGroup(ctx, request); err != nil {
+		return nil, err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := service.chatStorageRepo.GetJoinRequestPolicy(groupJID.String())
+	if err != nil {
+		return nil, pkgError.InternalServerError(fmt.Sprintf("failed to get join request policy: %v", err))
+	}
+	if policy == nil {
+		return nil, pkgError.NotFoundError(fmt.Sprintf("group %s has no join request policy", groupJID))
+	}
+	return policy, nil
+}
+
+// normalizePhonePrefixes accepts prefixes written as +62 or 62 and drops duplicates
+func normalizePhonePrefixes(prefixes []string) []string {
+	var normalized []string
+	for _, prefix := range prefixes {
+		prefix = strings.TrimPrefix(strings.TrimSpace(prefix), "+")
+		if !slices.Contains(normalized, prefix) {
+			normalized = append(normalized, prefix)
+		}
+	}
+	return normalized
+}
+
+func toPolicyResponse(policy *domainChatStorage.JoinRequestPolicy) domainJoinRequest.PolicyResponse {
+	return domainJoinRequest.PolicyResponse{
+		GroupID:         policy.GroupJID,
+		Enabled:         policy.Enabled,
+		AllowedPrefixes: nonNilStrings(policy.AllowedPrefixes),
+		RequireContact:  policy.RequireContact,
+		HookURL:         policy.HookURL,
+		FailAction:      policy.FailAction,
+		UpdatedAt:       policy.UpdatedAt,
+	}
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizePhonePrefixes(t *testing.T) {
+	got := normalizePhonePrefixes([]string{"+62", " 62", "1", " +44 "})
+	want := []string{"62", "1", "44"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizePhonePrefixes() = %v, want %v", got, want)
+	}
+
+	if got := normalizePhonePrefixes(nil); got != nil {
+		t.Errorf("normalizePhonePrefixes(nil) = %v, want nil", got)
+	}
+}
@@ -62,9 +62,13 @@ func (service serviceMessage) MarkAsRead(ctx context.Context, request domainMess
 	}
 
 	ids := []types.MessageID{request.MessageID}
-	if err = client.MarkRead(ctx, ids, time.Now(), dataWaRecipient, *client.Store.ID); err != nil {
+	readAt := time.Now()
+	if err = client.MarkRead(ctx, ids, readAt, dataWaRecipient, *client.Store.ID); err != nil {
 		return response, err
 	}
+	if err = service.chatStorageRepo.ForAgent(request.AgentID).MarkChatRead(dataWaRecipient.String(), ids, readAt); err != nil {
+		logrus.WithError(err).WithField("chat", dataWaRecipient.String()).Warn("Failed to store chat read state")
+	}
 
 	logrus.Info(map[string]any{
 		"phone":      request.Phone,
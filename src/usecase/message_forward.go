@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+// ForwardMessage re-sends a stored message to other chats. Media is forwarded by reference, reusing the original
+// upload, so nothing is downloaded or uploaded again.
+func (service serviceMessage) ForwardMessage(ctx context.Context, request domainMessage.ForwardRequest) (response domainMessage.ForwardResponse, err error) {
+	if err = validations.ValidateForwardMessage(ctx, request); err != nil {
+		return response, err
+	}
+	client, err := service.resolveClient(request.AgentID)
+	if err != nil {
+		return response, err
+	}
+
+	original, err := service.chatStorageRepo.ForAgent(request.AgentID).GetMessageByID(request.MessageID)
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to look up message: %v", err))
+	}
+	if original == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("message %s not found", request.MessageID))
+	}
+
+	msg, content, err := buildForwardMessage(original)
+	if err != nil {
+		return response, err
+	}
+
+	// wrapSendMessage only needs storage, so forwards are recorded like any other sent message
+	sender := serviceSend{chatStorageRepo: service.chatStorageRepo}
+
+	response.MessageID = request.MessageID
+	response.Results = make([]domainMessage.ForwardResult, 0, len(request.Phones))
+	for _, phone := range request.Phones {
+		result := domainMessage.ForwardResult{Phone: phone}
+		messageID, err := service.forwardTo(ctx, client, sender, request.AgentID, phone, msg, content)
+		if err != nil {
+			logrus.Warnf("Forwarding message %s to %s failed: %v", request.MessageID, phone, err)
+			result.Status = "failed"
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			result.Status = "sent"
+			result.MessageID = messageID
+			response.Sent++
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response, nil
+}
+
+func (service serviceMessage) forwardTo(
+	ctx context.Context,
+	client *whatsmeow.Client,
+	sender serviceSend,
+	agentID string,
+	phone string,
+	msg *waE2E.Message,
+	content string,
+) (messageID string, err error) {
+	// JID validation panics when the client drops mid-request; record it against this target instead
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	jid, err := utils.ValidateJidWithLogin(client, phone)
+	if err != nil {
+		return "", err
+	}
+	ts, err := sender.wrapSendMessage(ctx, client, agentID, jid, msg, content)
+	if err != nil {
+		return "", err
+	}
+	return ts.ID, nil
+}
+
+// buildForwardMessage rebuilds the outgoing proto for a stored message. The copy counts one more forward than the
+// stored message, the same as forwarding from the phone.
+func buildForwardMessage(message *domainChatStorage.Message) (*waE2E.Message, string, error) {
+	ctxInfo := &waE2E.ContextInfo{
+		IsForwarded:     proto.Bool(true),
+		ForwardingScore: proto.Uint32(message.ForwardingScore + 1),
+	}
+
+	if message.MediaType == "" {
+		if message.Content == "" {
+			return nil, "", pkgError.ValidationError(fmt.Sprintf("message %s has no content to forward", message.ID))
+		}
+		return &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text:        proto.String(message.Content),
+			ContextInfo: ctxInfo,
+		}}, message.Content, nil
+	}
+
+	if message.URL == "" || len(message.MediaKey) == 0 || len(message.FileEncSHA256) == 0 {
+		return nil, "", pkgError.ValidationError(fmt.Sprintf("message %s has no stored media reference to forward", message.ID))
+	}
+	directPath := message.DirectPath
+	if directPath == "" {
+		directPath = mediaDirectPath(message.URL)
+	}
+
+	switch message.MediaType {
+	case "image":
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+			URL:           proto.String(message.URL),
+			DirectPath:    proto.String(directPath),
+			MediaKey:      message.MediaKey,
+			Mimetype:      proto.String(storedMimetype(message, "image/jpeg")),
+			FileEncSHA256: message.FileEncSHA256,
+			FileSHA256:    message.FileSHA256,
+			FileLength:    proto.Uint64(message.FileLength),
+			Caption:       proto.String(message.Content),
+			ContextInfo:   ctxInfo,
+		}}, forwardContent("🖼️", "Image", message.Content), nil
+	case "video":
+		return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+			URL:           proto.String(message.URL),
+			DirectPath:    proto.String(directPath),
+			MediaKey:      message.MediaKey,
+			Mimetype:      proto.String(storedMimetype(message, "video/mp4")),
+			FileEncSHA256: message.FileEncSHA256,
+			FileSHA256:    message.FileSHA256,
+			FileLength:    proto.Uint64(message.FileLength),
+			Caption:       proto.String(message.Content),
+			ContextInfo:   ctxInfo,
+		}}, forwardContent("🎥", "Video", message.Content), nil
+	case "audio":
+		return &waE2E.Message{AudioMessage: &waE2E.AudioMessage{
+			URL:           proto.String(message.URL),
+			DirectPath:    proto.String(directPath),
+			MediaKey:      message.MediaKey,
+			Mimetype:      proto.String(storedMimetype(message, "audio/ogg; codecs=opus")),
+			FileEncSHA256: message.FileEncSHA256,
+			FileSHA256:    message.FileSHA256,
+			FileLength:    proto.Uint64(message.FileLength),
+			ContextInfo:   ctxInfo,
+		}}, "🎵 Audio", nil
+	case "document":
+		return &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+			URL:           proto.String(message.URL),
+			DirectPath:    proto.String(directPath),
+			MediaKey:      message.MediaKey,
+			Mimetype:      proto.String(storedMimetype(message, resolveDocumentMIME(message.Filename, nil))),
+			FileEncSHA256: message.FileEncSHA256,
+			FileSHA256:    message.FileSHA256,
+			FileLength:    proto.Uint64(message.FileLength),
+			FileName:      proto.String(message.Filename),
+			Title:         proto.String(message.Filename),
+			Caption:       proto.String(message.Content),
+			ContextInfo:   ctxInfo,
+		}}, forwardContent("📄", "Document", message.Content), nil
+	case "sticker":
+		return &waE2E.Message{StickerMessage: &waE2E.StickerMessage{
+			URL:           proto.String(message.URL),
+			DirectPath:    proto.String(directPath),
+			MediaKey:      message.MediaKey,
+			Mimetype:      proto.String(storedMimetype(message, "image/webp")),
+			FileEncSHA256: message.FileEncSHA256,
+			FileSHA256:    message.FileSHA256,
+			FileLength:    proto.Uint64(message.FileLength),
+			ContextInfo:   ctxInfo,
+		}}, "🎨 Sticker", nil
+	default:
+		return nil, "", pkgError.ValidationError(fmt.Sprintf("unsupported media type: %s", message.MediaType))
+	}
+}
+
+// storedMimetype returns the mimetype the media was stored with. Messages stored before mimetypes were kept fall
+// back to the usual type of their media.
+func storedMimetype(message *domainChatStorage.Message, fallback string) string {
+	if message.Mimetype != "" {
+		return message.Mimetype
+	}
+	return fallback
+}
+
+// mediaDirectPath derives the CDN direct path from a stored media URL, for media stored before direct paths were kept
+func mediaDirectPath(mediaURL string) string {
+	parsed, err := url.Parse(mediaURL)
+	if err != nil {
+		return ""
+	}
+	query := parsed.Query()
+	query.Del("mms3")
+	if encoded := query.Encode(); encoded != "" {
+		return parsed.Path + "?" + encoded
+	}
+	return parsed.Path
+}
+
+func forwardContent(emoji, label, caption string) string {
+	if caption == "" {
+		return emoji + " " + label
+	}
+	return emoji + " " + caption
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"testing"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+func TestBuildForwardMessageText(t *testing.T) {
+	msg, content, err := buildForwardMessage(&domainChatStorage.Message{ID: "3EB0A", Content: "*hello* _there_"})
+	if err != nil {
+		t.Fatalf("buildForwardMessage() error = %v", err)
+	}
+	text := msg.GetExtendedTextMessage()
+	if text.GetText() != "*hello* _there_" || content != "*hello* _there_" {
+		t.Fatalf("text = %q, content = %q", text.GetText(), content)
+	}
+	if !text.GetContextInfo().GetIsForwarded() || text.GetContextInfo().GetForwardingScore() != 1 {
+		t.Fatalf("expected forwarded context with score 1, got %+v", text.GetContextInfo())
+	}
+}
+
+func TestBuildForwardMessageReusesMediaReference(t *testing.T) {
+	stored := &domainChatStorage.Message{
+		ID:            "3EB0B",
+		Content:       "invoice",
+		MediaType:     "document",
+		Filename:      "invoice.pdf",
+		URL:           "https://mmg.whatsapp.net/v/t62.7119-24/123.enc?ccb=11-4&oh=abc&mms3=true",
+		MediaKey:      []byte("key"),
+		FileSHA256:    []byte("sha"),
+		FileEncSHA256: []byte("enc"),
+		FileLength:    2048,
+	}
+
+	msg, content, err := buildForwardMessage(stored)
+	if err != nil {
+		t.Fatalf("buildForwardMessage() error = %v", err)
+	}
+	doc := msg.GetDocumentMessage()
+	if doc.GetURL() != stored.URL || string(doc.GetMediaKey()) != "key" || string(doc.GetFileEncSHA256()) != "enc" {
+		t.Fatalf("media reference not reused: %+v", doc)
+	}
+	if doc.GetDirectPath() != "/v/t62.7119-24/123.enc?ccb=11-4&oh=abc" {
+		t.Fatalf("direct path = %q", doc.GetDirectPath())
+	}
+	if doc.GetMimetype() != "application/pdf" || doc.GetFileName() != "invoice.pdf" || doc.GetCaption() != "invoice" {
+		t.Fatalf("unexpected document fields: %+v", doc)
+	}
+	if content != "📄 invoice" {
+		t.Fatalf("content = %q", content)
+	}
+	if !doc.GetContextInfo().GetIsForwarded() {
+		t.Fatal("expected forwarded context")
+	}
+}
+
+func TestBuildForwardMessageRequiresMediaReference(t *testing.T) {
+	_, _, err := buildForwardMessage(&domainChatStorage.Message{ID: "3EB0C", MediaType: "image"})
+	if err == nil {
+		t.Fatal("expected error for media without stored reference")
+	}
+}
+
+func TestBuildForwardMessageUsesStoredDetails(t *testing.T) {
+	stored := &domainChatStorage.Message{
+		ID:              "3EB0D",
+		MediaType:       "video",
+		URL:             "https://mmg.whatsapp.net/v/t62.7161-24/456.enc?ccb=11-4&mms3=true",
+		DirectPath:      "/v/t62.7161-24/456.enc?ccb=11-4&oh=xyz",
+		Mimetype:        "video/quicktime",
+		MediaKey:        []byte("key"),
+		FileEncSHA256:   []byte("enc"),
+		ForwardingScore: 4,
+	}
+
+	msg, _, err := buildForwardMessage(stored)
+	if err != nil {
+		t.Fatalf("buildForwardMessage() error = %v", err)
+	}
+	video := msg.GetVideoMessage()
+	if video.GetMimetype() != "video/quicktime" || video.GetDirectPath() != stored.DirectPath {
+		t.Fatalf("stored details not used: %+v", video)
+	}
+	if video.GetContextInfo().GetForwardingScore() != 5 {
+		t.Fatalf("forwarding score = %d, want 5", video.GetContextInfo().GetForwardingScore())
+	}
+}
+
+func TestBuildForwardMessageSentTextWithEmoji(t *testing.T) {
+	msg, content, err := buildForwardMessage(&domainChatStorage.Message{ID: "3EB0E", Content: "📄 Invoice ready", IsFromMe: true})
+	if err != nil {
+		t.Fatalf("buildForwardMessage() error = %v", err)
+	}
+	if msg.GetExtendedTextMessage().GetText() != "📄 Invoice ready" || content != "📄 Invoice ready" {
+		t.Fatalf("text = %q, content = %q", msg.GetExtendedTextMessage().GetText(), content)
+	}
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+// PinMessage pins a message to the top of a chat for everyone in it, or unpins it again
+func (service serviceMessage) PinMessage(ctx context.Context, request domainMessage.PinMessageRequest) (response domainMessage.GenericResponse, err error) {
+	if request.IsPinned && request.Duration == "" {
+		request.Duration = domainMessage.PinDuration7Days
+	}
+	if err = validations.ValidatePinMessage(ctx, request); err != nil {
+		return response, err
+	}
+	client, err := service.resolveClient(request.AgentID)
+	if err != nil {
+		return response, err
+	}
+	dataWaRecipient, err := utils.ValidateJidWithLogin(client, request.Phone)
+	if err != nil {
+		return response, err
+	}
+
+	repo := service.chatStorageRepo.ForAgent(request.AgentID)
+
+	// The key has to name the original sender, which only the stored copy of the message knows
+	key := &waCommon.MessageKey{
+		RemoteJID: proto.String(dataWaRecipient.String()),
+		FromMe:    proto.Bool(true),
+		ID:        proto.String(request.MessageID),
+	}
+	if stored, lookupErr := repo.GetMessageByID(request.MessageID); lookupErr == nil && stored != nil && stored.ChatJID == dataWaRecipient.String() {
+		key = storedMessageKey(dataWaRecipient, stored)
+	}
+
+	duration := pinDuration(request.Duration)
+	if !request.IsPinned {
+		duration = 0
+	}
+	pinnedAt := time.Now()
+
+	ts, err := client.SendMessage(ctx, dataWaRecipient, buildPinMessage(key, request.IsPinned, duration, pinnedAt))
+	if err != nil {
+		return response, err
+	}
+
+	if request.IsPinned {
+		err = repo.StoreMessagePin(&domainChatStorage.MessagePin{
+			ChatJID:   dataWaRecipient.String(),
+			MessageID: request.MessageID,
+			PinnedBy:  client.Store.ID.ToNonAD().String(),
+			PinnedAt:  pinnedAt,
+			ExpiresAt: pinnedAt.Add(duration),
+		})
+	} else {
+		err = repo.DeleteMessagePin(dataWaRecipient.String(), request.MessageID)
+	}
+	if err != nil {
+		// The pin is already applied on WhatsApp; only the stored copy is behind
+		logrus.WithError(err).WithField("chat", dataWaRecipient.String()).Warn("Failed to store message pin")
+	}
+
+	response.MessageID = request.MessageID
+	if request.IsPinned {
+		response.Status = fmt.Sprintf("Message pinned for %s in %s (server timestamp: %s)", request.Duration, request.Phone, ts.Timestamp)
+	} else {
+		response.Status = fmt.Sprintf("Message unpinned in %s (server timestamp: %s)", request.Phone, ts.Timestamp)
+	}
+	return response, nil
+}
+
+// pinDuration converts one of the supported pin durations to how long WhatsApp keeps the message pinned
+func pinDuration(duration string) time.Duration {
+	switch duration {
+	case domainMessage.PinDuration24Hours:
+		return 24 * time.Hour
+	case domainMessage.PinDuration30Days:
+		return 30 * 24 * time.Hour
+	default:
+		return 7 * 24 * time.Hour
+	}
+}
+
+// buildPinMessage builds the pin-in-chat message. The pin duration travels in the message context info rather
+// than in the pin itself, and is zero when unpinning.
+func buildPinMessage(key *waCommon.MessageKey, pinned bool, duration time.Duration, now time.Time) *waE2E.Message {
+	pinType := waE2E.PinInChatMessage_UNPIN_FOR_ALL
+	if pinned {
+		pinType = waE2E.PinInChatMessage_PIN_FOR_ALL
+	}
+	return &waE2E.Message{
+		PinInChatMessage: &waE2E.PinInChatMessage{
+			Key:               key,
+			Type:              pinType.Enum(),
+			SenderTimestampMS: proto.Int64(now.UnixMilli()),
+		},
+		MessageContextInfo: &waE2E.MessageContextInfo{
+			MessageAddOnDurationInSecs: proto.Uint32(uint32(duration / time.Second)),
+		},
+	}
+}
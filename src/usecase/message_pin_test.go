@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPinDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		domainMessage.PinDuration24Hours: 24 * time.Hour,
+		domainMessage.PinDuration7Days:   7 * 24 * time.Hour,
+		domainMessage.PinDuration30Days:  30 * 24 * time.Hour,
+		"":                               7 * 24 * time.Hour,
+	}
+	for duration, want := range cases {
+		if got := pinDuration(duration); got != want {
+			t.Errorf("pinDuration(%q) = %s, want %s", duration, got, want)
+		}
+	}
+}
+
+func TestBuildPinMessage(t *testing.T) {
+	key := &waCommon.MessageKey{
+		RemoteJID: proto.String("120363025246125486@g.us"),
+		FromMe:    proto.Bool(false),
+		ID:        proto.String("3EB0PIN"),
+	}
+	now := time.UnixMilli(1700000000000)
+
+	msg := buildPinMessage(key, true, 24*time.Hour, now)
+	pin := msg.GetPinInChatMessage()
+	if pin.GetType() != waE2E.PinInChatMessage_PIN_FOR_ALL {
+		t.Fatalf("type = %s, want PIN_FOR_ALL", pin.GetType())
+	}
+	if pin.GetKey().GetID() != "3EB0PIN" || pin.GetSenderTimestampMS() != now.UnixMilli() {
+		t.Fatalf("unexpected pin %v", pin)
+	}
+	if got := msg.GetMessageContextInfo().GetMessageAddOnDurationInSecs(); got != 86400 {
+		t.Fatalf("duration = %d, want 86400", got)
+	}
+
+	msg = buildPinMessage(key, false, 0, now)
+	if msg.GetPinInChatMessage().GetType() != waE2E.PinInChatMessage_UNPIN_FOR_ALL {
+		t.Fatalf("type = %s, want UNPIN_FOR_ALL", msg.GetPinInChatMessage().GetType())
+	}
+	if got := msg.GetMessageContextInfo().GetMessageAddOnDurationInSecs(); got != 0 {
+		t.Fatalf("duration = %d, want 0", got)
+	}
+}
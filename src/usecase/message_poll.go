@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+)
+
+// GetPollResults tallies the stored votes of a poll. Votes are decrypted and recorded as they arrive, so only votes
+// received while this device was connected are counted.
+func (service serviceMessage) GetPollResults(ctx context.Context, request domainMessage.PollResultsRequest) (response domainMessage.PollResultsResponse, err error) {
+	if err = validations.ValidatePollResults(ctx, request); err != nil {
+		return response, err
+	}
+
+	repo := service.chatStorageRepo.ForAgent(request.AgentID)
+	poll, err := repo.GetPoll(request.MessageID)
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to look up poll: %v", err))
+	}
+	if poll == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("poll %s not found", request.MessageID))
+	}
+
+	votes, err := repo.GetPollVotes(poll.MessageID, poll.ChatJID)
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to load poll votes: %v", err))
+	}
+	return tallyPollVotes(poll, votes), nil
+}
+
+// tallyPollVotes counts every voter's current selection per option, keeping the poll's option order.
+// Withdrawn votes (empty selections) are not counted.
+func tallyPollVotes(poll *domainChatStorage.Poll, votes []*domainChatStorage.PollVote) domainMessage.PollResultsResponse {
+	response := domainMessage.PollResultsResponse{
+		MessageID:       poll.MessageID,
+		ChatJID:         poll.ChatJID,
+		Question:        poll.Question,
+		SelectableCount: poll.SelectableCount,
+		Options:         make([]domainMessage.PollOptionResult, len(poll.Options)),
+	}
+
+	index := make(map[string]int, len(poll.Options))
+	for i, option := range poll.Options {
+		index[option] = i
+		response.Options[i] = domainMessage.PollOptionResult{Option: option, Voters: []string{}}
+	}
+
+	for _, vote := range votes {
+		if len(vote.SelectedOptions) == 0 {
+			continue
+		}
+		response.TotalVoters++
+		for _, option := range vote.SelectedOptions {
+			i, ok := index[option]
+			if !ok {
+				continue
+			}
+			response.Options[i].Votes++
+			response.Options[i].Voters = append(response.Options[i].Voters, vote.Voter)
+		}
+	}
+	return response
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"testing"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+)
+
+func TestTallyPollVotes(t *testing.T) {
+	poll := &domainChatStorage.Poll{
+		MessageID:       "3EB0POLL",
+		ChatJID:         "120363025246125486@g.us",
+		Question:        "Coming on Friday?",
+		Options:         []string{"Yes", "No", "Maybe"},
+		SelectableCount: 1,
+	}
+	votes := []*domainChatStorage.PollVote{
+		{Voter: "6281111@s.whatsapp.net", SelectedOptions: []string{"Yes"}},
+		{Voter: "6282222@s.whatsapp.net", SelectedOptions: []string{"Maybe"}},
+		{Voter: "6283333@s.whatsapp.net", SelectedOptions: []string{"Yes"}},
+		{Voter: "6284444@s.whatsapp.net", SelectedOptions: []string{}}, // withdrawn
+		{Voter: "6285555@s.whatsapp.net", SelectedOptions: []string{"Removed option"}},
+	}
+
+	results := tallyPollVotes(poll, votes)
+
+	if results.TotalVoters != 4 {
+		t.Fatalf("total voters = %d, want 4", results.TotalVoters)
+	}
+	want := map[string]int{"Yes": 2, "No": 0, "Maybe": 1}
+	for i, option := range results.Options {
+		if option.Option != poll.Options[i] {
+			t.Fatalf("option %d = %q, want %q", i, option.Option, poll.Options[i])
+		}
+		if option.Votes != want[option.Option] || len(option.Voters) != want[option.Option] {
+			t.Fatalf("option %q has %d votes (%v), want %d", option.Option, option.Votes, option.Voters, want[option.Option])
+		}
+	}
+	if results.Options[0].Voters[1] != "6283333@s.whatsapp.net" {
+		t.Fatalf("unexpected voters for Yes: %v", results.Options[0].Voters)
+	}
+}
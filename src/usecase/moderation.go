@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+)
+
+type serviceModeration struct {
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
+
+func NewModerationService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainModeration.IModerationUsecase {
+	return &serviceModeration{chatStorageRepo: chatStorageRepo}
+}
+
+// SetRules replaces the auto-moderation rules of a group. They apply to the next incoming message; deleting
+// messages and removing participants only works while this account is admin of the group.
+func (service serviceModeration) SetRules(ctx context.Context, request domainModeration.SetRulesRequest) (response domainModeration.RulesResponse, err error) {
+	if err = validations.ValidateSetModerationRules(ctx, request); err != nil {
+		return response, err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return response, err
+	}
+
+	rules := buildModerationRules(groupJID.String(), request)
+	if err = service.chatStorageRepo.StoreModerationRules(rules); err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to store moderation rules: %v", err))
+	}
+
+	return toRulesResponse(rules), nil
+}
+
+func (service serviceModeration) GetRules(ctx context.Context, request domainModeration.GroupRequest) (response domainModeration.RulesResponse, err error) {
+	if err = validations.ValidateModerationGroup(ctx, request); err != nil {
+		return response, err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return response, err
+	}
+
+	rules, err := service.chatStorageRepo.GetModerationRules(groupJID.String())
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to get moderation rules: %v", err))
+	}
+	if rules == nil {
+		return response, pkgError.NotFoundError(fmt.Sprintf("group %s has no moderation rules", groupJID))
+	}
+
+	return toRulesResponse(rules), nil
+}
+
+// DeleteRules turns auto-moderation off for a group and clears the strikes of its participants
+func (service serviceModeration) DeleteRules(ctx context.Context, request domainModeration.GroupRequest) (err error) {
+	if err = validations.ValidateModerationGroup(ctx, request); err != nil {
+		return err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return err
+	}
+
+	if err = service.chatStorageRepo.DeleteModerationRules(groupJID.String()); err != nil {
+		return pkgError.InternalServerError(fmt.Sprintf("failed to delete moderation rules: %v", err))
+	}
+	return nil
+}
+
+func (service serviceModeration) GetAuditLog(ctx context.Context, request domainModeration.AuditLogRequest) (response domainModeration.AuditLogResponse, err error) {
+	if err = validations.ValidateModerationAuditLog(ctx, &request); err != nil {
+		return response, err
+	}
+
+	groupJID, err := parseGroupJID(request.GroupID)
+	if err != nil {
+		return response, err
+	}
+
+	entries, err := service.chatStorageRepo.GetModerationAudit(groupJID.String(), request.Limit, request.Offset)
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("failed to get moderation audit log: %v", err))
+	}
+
+	response = domainModeration.AuditLogResponse{
+		GroupID: groupJID.String(),
+		Entries: make([]domainModeration.AuditEntry, 0, len(entries)),
+		Limit:   request.Limit,
+		Offset:  request.Offset,
+	}
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, domainModeration.AuditEntry{
+			ID:          entry.ID,
+			MessageID:   entry.MessageID,
+			Participant: entry.Participant,
+			Rule:        entry.Rule,
+			Detail:      entry.Detail,
+			Action:      entry.Action,
+			Success:     entry.Success,
+			Error:       entry.Error,
+			CreatedAt:   entry.CreatedAt,
+		})
+	}
+
+	return response, nil
+}
+
+// buildModerationRules cleans up a validated request into the rules that are stored
+func buildModerationRules(groupJID string, request domainModeration.SetRulesRequest) *domainChatStorage.ModerationRules {
+	rules := &domainChatStorage.ModerationRules{
+		GroupJID:           groupJID,
+		Enabled:            request.Enabled,
+		BlockLinks:         request.BlockLinks,
+		BannedPatterns:     request.BannedPatterns,
+		FloodMaxMessages:   request.FloodMaxMessages,
+		FloodWindowSeconds: request.FloodWindowSeconds,
+		WarningMessage:     strings.TrimSpace(request.WarningMessage),
+		MaxStrikes:         request.MaxStrikes,
+	}
+
+	for _, domain := range request.AllowedDomains {
+		if domain = normalizeAllowedDomain(domain); domain != "" && !slices.Contains(rules.AllowedDomains, domain) {
+			rules.AllowedDomains = append(rules.AllowedDomains, domain)
+		}
+	}
+	for _, keyword := range request.BannedKeywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" && !slices.Contains(rules.BannedKeywords, keyword) {
+			rules.BannedKeywords = append(rules.BannedKeywords, keyword)
+		}
+	}
+	for _, mediaType := range request.BlockedMediaTypes {
+		if !slices.Contains(rules.BlockedMediaTypes, mediaType) {
+			rules.BlockedMediaTypes = append(rules.BlockedMediaTypes, mediaType)
+		}
+	}
+	for _, action := range request.Actions {
+		if !slices.Contains(rules.Actions, action) {
+			rules.Actions = append(rules.Actions, action)
+		}
+	}
+
+	if rules.MaxStrikes == 0 && slices.Contains(rules.Actions, domainModeration.ActionRemove) {
+		rules.MaxStrikes = domainModeration.DefaultMaxStrikes
+	}
+	return rules
+}
+
+// normalizeAllowedDomain reduces an allowlist entry such as https://www.Example.com/path to example.com
+func normalizeAllowedDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	domain = strings.TrimPrefix(domain, "https://")
+	domain = strings.TrimPrefix(domain, "http://")
+	domain, _, _ = strings.Cut(domain, "/")
+	domain = strings.TrimPrefix(domain, "www.")
+	return strings.Trim(domain, ".")
+}
+
+func toRulesResponse(rules *domainChatStorage.ModerationRules) domainModeration.RulesResponse {
+	return domainModeration.RulesResponse{
+		GroupID:            rules.GroupJID,
+		Enabled:            rules.Enabled,
+		BlockLinks:         rules.BlockLinks,
+		AllowedDomains:     nonNilStrings(rules.AllowedDomains),
+		BannedKeywords:     nonNilStrings(rules.BannedKeywords),
+		BannedPatterns:     nonNilStrings(rules.BannedPatterns),
+		FloodMaxMessages:   rules.FloodMaxMessages,
+		FloodWindowSeconds: rules.FloodWindowSeconds,
+		BlockedMediaTypes:  nonNilStrings(rules.BlockedMediaTypes),
+		Actions:            nonNilStrings(rules.Actions),
+		WarningMessage:     rules.WarningMessage,
+		MaxStrikes:         rules.MaxStrikes,
+		UpdatedAt:          rules.UpdatedAt,
+	}
+}
+
+// nonNilStrings keeps empty lists as [] instead of null in responses
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+)
+
+func TestBuildModerationRules(t *testing.T) {
+	rules := buildModerationRules("120363025246125486@g.us", domainModeration.SetRulesRequest{
+		Enabled:        true,
+		BlockLinks:     true,
+		AllowedDomains: []string{"https://www.Example.com/about", "example.com", " docs.site.org "},
+		BannedKeywords: []string{" Casino", "casino", "Spam"},
+		Actions:        []string{domainModeration.ActionDelete, domainModeration.ActionRemove, domainModeration.ActionDelete},
+		WarningMessage: "  No spam please ",
+	})
+
+	if !reflect.DeepEqual(rules.AllowedDomains, []string{"example.com", "docs.site.org"}) {
+		t.Errorf("allowed domains = %v", rules.AllowedDomains)
+	}
+	if !reflect.DeepEqual(rules.BannedKeywords, []string{"casino", "spam"}) {
+		t.Errorf("banned keywords = %v", rules.BannedKeywords)
+	}
+	if !reflect.DeepEqual(rules.Actions, []string{domainModeration.ActionDelete, domainModeration.ActionRemove}) {
+		t.Errorf("actions = %v", rules.Actions)
+	}
+	if rules.WarningMessage != "No spam please" {
+		t.Errorf("warning message = %q", rules.WarningMessage)
+	}
+	if rules.MaxStrikes != domainModeration.DefaultMaxStrikes {
+		t.Errorf("max strikes = %d, want default %d", rules.MaxStrikes, domainModeration.DefaultMaxStrikes)
+	}
+
+	rules = buildModerationRules("120363025246125486@g.us", domainModeration.SetRulesRequest{
+		Actions: []string{domainModeration.ActionWarn},
+	})
+	if rules.MaxStrikes != 0 {
+		t.Errorf("max strikes without remove action = %d, want 0", rules.MaxStrikes)
+	}
+	if response := toRulesResponse(rules); response.AllowedDomains == nil || response.BannedPatterns == nil {
+		t.Error("empty lists must be returned as [] instead of null")
+	}
+}
@@ -2,11 +2,29 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
 
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/helpers"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// Creating a channel needs its terms of service to be accepted first, which is otherwise done on the phone
+const (
+	newsletterTOSNotice = "20601218"
+	newsletterTOSStage  = "5"
 )
 
 type serviceNewsletter struct{}
@@ -27,3 +45,374 @@ func (service serviceNewsletter) Unfollow(ctx context.Context, request domainNew
 
 	return whatsapp.GetClient().UnfollowNewsletter(ctx, JID)
 }
+
+func (service serviceNewsletter) Create(ctx context.Context, request domainNewsletter.CreateRequest) (response domainNewsletter.NewsletterInfo, err error) {
+	if err = validations.ValidateCreateNewsletter(ctx, request); err != nil {
+		return response, err
+	}
+	client := whatsapp.GetClient()
+	utils.MustLogin(client)
+
+	picture, err := newsletterPicture(request.Picture)
+	if err != nil {
+		return response, err
+	}
+
+	// Accepting the terms again is harmless, so there is no need to remember whether it was done before
+	if err = client.AcceptTOSNotice(ctx, newsletterTOSNotice, newsletterTOSStage); err != nil {
+		return response, fmt.Errorf("failed to accept channel terms: %w", err)
+	}
+
+	metadata, err := client.CreateNewsletter(ctx, whatsmeow.CreateNewsletterParams{
+		Name:        request.Name,
+		Description: request.Description,
+		Picture:     picture,
+	})
+	if err != nil {
+		return response, err
+	}
+
+	return toNewsletterInfo(metadata), nil
+}
+
+func (service serviceNewsletter) Update(ctx context.Context, request domainNewsletter.UpdateRequest) (response domainNewsletter.NewsletterInfo, err error) {
+	if err = validations.ValidateUpdateNewsletter(ctx, request); err != nil {
+		return response, err
+	}
+
+	JID, err := service.newsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	update := whatsapp.NewsletterUpdate{}
+	if request.Name != "" {
+		update.Name = &request.Name
+	}
+	if request.Description != "" {
+		update.Description = &request.Description
+	}
+	if update.Picture, err = newsletterPicture(request.Picture); err != nil {
+		return response, err
+	}
+
+	if _, err = whatsapp.UpdateNewsletter(ctx, whatsapp.GetClient(), JID, update); err != nil {
+		return response, err
+	}
+
+	metadata, err := whatsapp.GetClient().GetNewsletterInfo(ctx, JID)
+	if err != nil {
+		return response, err
+	}
+	return toNewsletterInfo(metadata), nil
+}
+
+func (service serviceNewsletter) Delete(ctx context.Context, request domainNewsletter.NewsletterRequest) (err error) {
+	if err = validations.ValidateNewsletter(ctx, request); err != nil {
+		return err
+	}
+
+	JID, err := service.newsletterJID(request.NewsletterID)
+	if err != nil {
+		return err
+	}
+
+	return whatsapp.DeleteNewsletter(ctx, whatsapp.GetClient(), JID)
+}
+
+func (service serviceNewsletter) Follow(ctx context.Context, request domainNewsletter.FollowRequest) (response domainNewsletter.NewsletterInfo, err error) {
+	if err = validations.ValidateFollowNewsletter(ctx, request); err != nil {
+		return response, err
+	}
+	client := whatsapp.GetClient()
+	utils.MustLogin(client)
+
+	invited, err := client.GetNewsletterInfoWithInvite(ctx, strings.TrimSpace(request.InviteLink))
+	if err != nil {
+		return response, err
+	}
+	if err = client.FollowNewsletter(ctx, invited.ID); err != nil {
+		return response, err
+	}
+
+	// The invite lookup has no viewer details, which are only known once we follow the channel
+	metadata, err := client.GetNewsletterInfo(ctx, invited.ID)
+	if err != nil {
+		logrus.WithError(err).WithField("newsletter_id", invited.ID.String()).Warn("Failed to get followed channel info")
+		metadata = invited
+	}
+	return toNewsletterInfo(metadata), nil
+}
+
+func (service serviceNewsletter) Info(ctx context.Context, request domainNewsletter.InfoRequest) (response domainNewsletter.NewsletterInfo, err error) {
+	if err = validations.ValidateNewsletterInfo(ctx, request); err != nil {
+		return response, err
+	}
+
+	var metadata *types.NewsletterMetadata
+	if request.NewsletterID != "" {
+		JID, err := service.newsletterJID(request.NewsletterID)
+		if err != nil {
+			return response, err
+		}
+		metadata, err = whatsapp.GetClient().GetNewsletterInfo(ctx, JID)
+		if err != nil {
+			return response, err
+		}
+	} else {
+		utils.MustLogin(whatsapp.GetClient())
+		metadata, err = whatsapp.GetClient().GetNewsletterInfoWithInvite(ctx, strings.TrimSpace(request.InviteLink))
+		if err != nil {
+			return response, err
+		}
+	}
+	if metadata == nil {
+		return response, pkgError.NotFoundError("channel not found")
+	}
+
+	return toNewsletterInfo(metadata), nil
+}
+
+func (service serviceNewsletter) Messages(ctx context.Context, request domainNewsletter.MessagesRequest) (response domainNewsletter.MessagesResponse, err error) {
+	if err = validations.ValidateNewsletterMessages(ctx, &request); err != nil {
+		return response, err
+	}
+
+	JID, err := service.newsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	messages, err := whatsapp.GetClient().GetNewsletterMessages(ctx, JID, &whatsmeow.GetNewsletterMessagesParams{
+		Count:  request.Limit,
+		Before: types.MessageServerID(request.Before),
+	})
+	if err != nil {
+		return response, err
+	}
+
+	response.NewsletterID = JID.String()
+	response.Messages = make([]domainNewsletter.Message, 0, len(messages))
+	for _, message := range messages {
+		mediaType, _, _, _, _, _, _ := utils.ExtractMediaInfo(message.Message)
+		response.Messages = append(response.Messages, domainNewsletter.Message{
+			ServerID:       message.MessageServerID,
+			MessageID:      message.MessageID,
+			Type:           message.Type,
+			Timestamp:      message.Timestamp,
+			Text:           utils.ExtractMessageTextFromProto(message.Message),
+			MediaType:      mediaType,
+			ViewsCount:     message.ViewsCount,
+			ReactionCounts: nonNilCounts(message.ReactionCounts),
+		})
+	}
+	return response, nil
+}
+
+// Send posts to a channel. Channel media is uploaded unencrypted and the upload handle travels with the message.
+func (service serviceNewsletter) Send(ctx context.Context, request domainNewsletter.SendRequest) (response domainNewsletter.SendResponse, err error) {
+	if err = validations.ValidateSendNewsletter(ctx, request); err != nil {
+		return response, err
+	}
+
+	JID, err := service.newsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+	client := whatsapp.GetClient()
+
+	metadata, err := client.GetNewsletterInfo(ctx, JID)
+	if err != nil {
+		return response, err
+	}
+	if !canPostToNewsletter(metadata) {
+		return response, pkgError.ValidationError(fmt.Sprintf("only the owner or an admin of %s can post to it", JID))
+	}
+
+	msg, mediaHandle, err := service.buildPost(ctx, client, request)
+	if err != nil {
+		return response, err
+	}
+
+	ts, err := client.SendMessage(ctx, JID, msg, whatsmeow.SendRequestExtra{MediaHandle: mediaHandle})
+	if err != nil {
+		return response, err
+	}
+
+	response.MessageID = ts.ID
+	response.ServerID = ts.ServerID
+	response.Status = fmt.Sprintf("Post sent to %s (server timestamp: %s)", JID, ts.Timestamp)
+	return response, nil
+}
+
+func (service serviceNewsletter) buildPost(ctx context.Context, client *whatsmeow.Client, request domainNewsletter.SendRequest) (msg *waE2E.Message, mediaHandle string, err error) {
+	var media []byte
+	var mediaType whatsmeow.MediaType
+	switch {
+	case request.Image != nil:
+		media, mediaType = helpers.MultipartFormFileHeaderToBytes(request.Image), whatsmeow.MediaImage
+	case request.ImageURL != nil:
+		if media, _, err = utils.DownloadImageFromURL(*request.ImageURL); err != nil {
+			return nil, "", pkgError.InternalServerError(fmt.Sprintf("failed to download image from URL %v", err))
+		}
+		mediaType = whatsmeow.MediaImage
+	case request.Video != nil:
+		media, mediaType = helpers.MultipartFormFileHeaderToBytes(request.Video), whatsmeow.MediaVideo
+	case request.VideoURL != nil:
+		if media, _, err = utils.DownloadVideoFromURL(*request.VideoURL); err != nil {
+			return nil, "", pkgError.InternalServerError(fmt.Sprintf("failed to download video from URL %v", err))
+		}
+		mediaType = whatsmeow.MediaVideo
+	default:
+		return &waE2E.Message{Conversation: proto.String(request.Message)}, "", nil
+	}
+
+	uploaded, err := client.UploadNewsletter(ctx, media, mediaType)
+	if err != nil {
+		return nil, "", pkgError.InternalServerError(fmt.Sprintf("failed to upload media: %v", err))
+	}
+
+	// Channel media is not encrypted, so there is no media key or encrypted hash
+	if mediaType == whatsmeow.MediaImage {
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+			Caption:    proto.String(request.Message),
+			URL:        proto.String(uploaded.URL),
+			DirectPath: proto.String(uploaded.DirectPath),
+			Mimetype:   proto.String(http.DetectContentType(media)),
+			FileSHA256: uploaded.FileSHA256,
+			FileLength: proto.Uint64(uploaded.FileLength),
+		}}, uploaded.Handle, nil
+	}
+	return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+		Caption:    proto.String(request.Message),
+		URL:        proto.String(uploaded.URL),
+		DirectPath: proto.String(uploaded.DirectPath),
+		Mimetype:   proto.String(http.DetectContentType(media)),
+		FileSHA256: uploaded.FileSHA256,
+		FileLength: proto.Uint64(uploaded.FileLength),
+	}}, uploaded.Handle, nil
+}
+
+func (service serviceNewsletter) Mute(ctx context.Context, request domainNewsletter.MuteRequest) (err error) {
+	if err = validations.ValidateMuteNewsletter(ctx, request); err != nil {
+		return err
+	}
+
+	JID, err := service.newsletterJID(request.NewsletterID)
+	if err != nil {
+		return err
+	}
+
+	return whatsapp.GetClient().NewsletterToggleMute(ctx, JID, request.Mute)
+}
+
+// Stats reads the current view and reaction counts of the latest posts, without their content
+func (service serviceNewsletter) Stats(ctx context.Context, request domainNewsletter.StatsRequest) (response domainNewsletter.StatsResponse, err error) {
+	if err = validations.ValidateNewsletterStats(ctx, &request); err != nil {
+		return response, err
+	}
+
+	JID, err := service.newsletterJID(request.NewsletterID)
+	if err != nil {
+		return response, err
+	}
+
+	params := &whatsmeow.GetNewsletterUpdatesParams{Count: request.Limit}
+	if request.Since != "" {
+		params.Since, _ = time.Parse(time.RFC3339, request.Since)
+	}
+
+	updates, err := whatsapp.GetClient().GetNewsletterMessageUpdates(ctx, JID, params)
+	if err != nil {
+		return response, err
+	}
+
+	response = summarizeNewsletterStats(updates)
+	response.NewsletterID = JID.String()
+	return response, nil
+}
+
+func (service serviceNewsletter) newsletterJID(newsletterID string) (types.JID, error) {
+	JID, err := utils.ValidateJidWithLogin(whatsapp.GetClient(), newsletterID)
+	if err != nil {
+		return JID, err
+	}
+	if JID.Server != types.NewsletterServer {
+		return JID, pkgError.ValidationError(fmt.Sprintf("%s is not a channel", newsletterID))
+	}
+	return JID, nil
+}
+
+// newsletterPicture turns an uploaded picture into the square JPEG WhatsApp expects, like group photos
+func newsletterPicture(file *multipart.FileHeader) ([]byte, error) {
+	if file == nil {
+		return nil, nil
+	}
+	processed, err := utils.ProcessGroupPhoto(file)
+	if err != nil {
+		return nil, pkgError.ValidationError(err.Error())
+	}
+	return processed.Bytes(), nil
+}
+
+func canPostToNewsletter(metadata *types.NewsletterMetadata) bool {
+	if metadata == nil || metadata.ViewerMeta == nil {
+		return false
+	}
+	return metadata.ViewerMeta.Role == types.NewsletterRoleOwner || metadata.ViewerMeta.Role == types.NewsletterRoleAdmin
+}
+
+func toNewsletterInfo(metadata *types.NewsletterMetadata) domainNewsletter.NewsletterInfo {
+	if metadata == nil {
+		return domainNewsletter.NewsletterInfo{}
+	}
+
+	thread := metadata.ThreadMeta
+	info := domainNewsletter.NewsletterInfo{
+		NewsletterID:    metadata.ID.String(),
+		Name:            thread.Name.Text,
+		Description:     thread.Description.Text,
+		SubscriberCount: thread.SubscriberCount,
+		Verified:        thread.VerificationState == types.NewsletterVerificationStateVerified,
+		State:           string(metadata.State.Type),
+		PictureURL:      thread.Preview.URL,
+		CreatedAt:       thread.CreationTime.Time,
+	}
+	if thread.InviteCode != "" {
+		info.InviteLink = whatsmeow.NewsletterLinkPrefix + thread.InviteCode
+	}
+	if thread.Picture != nil && thread.Picture.URL != "" {
+		info.PictureURL = thread.Picture.URL
+	}
+	if metadata.ViewerMeta != nil {
+		info.Role = string(metadata.ViewerMeta.Role)
+		info.Muted = metadata.ViewerMeta.Mute == types.NewsletterMuteOn
+	}
+	return info
+}
+
+func summarizeNewsletterStats(updates []*types.NewsletterMessage) domainNewsletter.StatsResponse {
+	response := domainNewsletter.StatsResponse{
+		Messages: make([]domainNewsletter.MessageStats, 0, len(updates)),
+	}
+	for _, update := range updates {
+		response.TotalViews += update.ViewsCount
+		for _, count := range update.ReactionCounts {
+			response.TotalReactions += count
+		}
+		response.Messages = append(response.Messages, domainNewsletter.MessageStats{
+			ServerID:       update.MessageServerID,
+			Timestamp:      update.Timestamp,
+			ViewsCount:     update.ViewsCount,
+			ReactionCounts: nonNilCounts(update.ReactionCounts),
+		})
+	}
+	return response
+}
+
+func nonNilCounts(counts map[string]int) map[string]int {
+	if counts == nil {
+		return map[string]int{}
+	}
+	return counts
+}
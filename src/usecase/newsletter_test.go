@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"go.mau.fi/util/jsontime"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestToNewsletterInfo(t *testing.T) {
+	created := time.Unix(1735689600, 0)
+	info := toNewsletterInfo(&types.NewsletterMetadata{
+		ID:    types.NewJID("120363144038483540", types.NewsletterServer),
+		State: types.WrappedNewsletterState{Type: types.NewsletterStateActive},
+		ThreadMeta: types.NewsletterThreadMetadata{
+			CreationTime:      jsontime.UnixString{Time: created},
+			InviteCode:        "0029Va4K0PZ5a245NkngBA2M",
+			Name:              types.NewsletterText{Text: "Product updates"},
+			SubscriberCount:   1200,
+			VerificationState: types.NewsletterVerificationStateVerified,
+			Preview:           types.ProfilePictureInfo{URL: "https://example.com/preview.jpg"},
+		},
+		ViewerMeta: &types.NewsletterViewerMetadata{Mute: types.NewsletterMuteOn, Role: types.NewsletterRoleOwner},
+	})
+
+	if info.NewsletterID != "120363144038483540@newsletter" || info.Name != "Product updates" {
+		t.Errorf("info = %+v", info)
+	}
+	if info.InviteLink != "https://whatsapp.com/channel/0029Va4K0PZ5a245NkngBA2M" {
+		t.Errorf("invite link = %q", info.InviteLink)
+	}
+	if !info.Verified || !info.Muted || info.Role != "owner" || info.State != "active" {
+		t.Errorf("flags = %+v", info)
+	}
+	if info.PictureURL != "https://example.com/preview.jpg" || !info.CreatedAt.Equal(created) {
+		t.Errorf("picture = %q, created = %v", info.PictureURL, info.CreatedAt)
+	}
+}
+
+func TestCanPostToNewsletter(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *types.NewsletterMetadata
+		want     bool
+	}{
+		{name: "owner", metadata: &types.NewsletterMetadata{ViewerMeta: &types.NewsletterViewerMetadata{Role: types.NewsletterRoleOwner}}, want: true},
+		{name: "admin", metadata: &types.NewsletterMetadata{ViewerMeta: &types.NewsletterViewerMetadata{Role: types.NewsletterRoleAdmin}}, want: true},
+		{name: "follower", metadata: &types.NewsletterMetadata{ViewerMeta: &types.NewsletterViewerMetadata{Role: types.NewsletterRoleSubscriber}}},
+		{name: "no viewer details", metadata: &types.NewsletterMetadata{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canPostToNewsletter(tt.metadata); got != tt.want {
+				t.Errorf("canPostToNewsletter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeNewsletterStats(t *testing.T) {
+	stats := summarizeNewsletterStats([]*types.NewsletterMessage{
+		{MessageServerID: 101, ViewsCount: 500, ReactionCounts: map[string]int{"👍": 20, "❤️": 5}},
+		{MessageServerID: 102, ViewsCount: 250},
+	})
+
+	if stats.TotalViews != 750 || stats.TotalReactions != 25 {
+		t.Errorf("totals = %d views, %d reactions", stats.TotalViews, stats.TotalReactions)
+	}
+	if len(stats.Messages) != 2 || stats.Messages[1].ReactionCounts == nil {
+		t.Errorf("messages = %+v", stats.Messages)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"mime"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"os/exec"
@@ -63,6 +64,10 @@ func (service serviceSend) resolveClient(agentID string) (*whatsmeow.Client, err
 
 // wrapSendMessage wraps the message sending process with message ID saving
 func (service serviceSend) wrapSendMessage(ctx context.Context, client *whatsmeow.Client, agentID string, recipient types.JID, msg *waE2E.Message, content string) (whatsmeow.SendResponse, error) {
+	if err := refuseBlockedRecipient(ctx, client, recipient); err != nil {
+		return whatsmeow.SendResponse{}, err
+	}
+
 	ts, err := client.SendMessage(ctx, recipient, msg)
 	if err != nil {
 		return whatsmeow.SendResponse{}, err
@@ -71,9 +76,10 @@ func (service serviceSend) wrapSendMessage(ctx context.Context, client *whatsmeo
 	metrics.IncMessagesSent()
 
 	// Store the sent message using chatstorage
-	senderJID := ""
+	senderJID, senderLID := "", ""
 	if client.Store != nil && client.Store.ID != nil {
 		senderJID = client.Store.ID.String()
+		senderLID = client.Store.LID.String()
 	}
 
 	// Store message asynchronously with timeout
@@ -82,7 +88,9 @@ func (service serviceSend) wrapSendMessage(ctx context.Context, client *whatsmeo
 		storeCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
 
-		if err := service.chatStorageRepo.ForAgent(agentID).StoreSentMessageWithContext(storeCtx, ts.ID, senderJID, recipient.String(), content, ts.Timestamp); err != nil {
+		// A LID recipient is stored under its phone number chat, where its incoming messages are kept too
+		chatJID, chatLID := whatsapp.ChatJIDs(storeCtx, client, recipient)
+		if err := service.chatStorageRepo.ForAgent(agentID).StoreSentMessageWithContext(storeCtx, ts.ID, senderJID, senderLID, chatJID.String(), chatLID.String(), content, ts.Timestamp, msg); err != nil {
 			if errors.Is(err, context.DeadlineExceeded) {
 				logrus.Warn("Timeout storing sent message")
 			} else {
@@ -94,6 +102,27 @@ func (service serviceSend) wrapSendMessage(ctx context.Context, client *whatsmeo
 	return ts, nil
 }
 
+// refuseBlockedRecipient stops a send to a user we blocked, which WhatsApp would otherwise accept and never
+// deliver. A blocklist that cannot be loaded does not hold sends back.
+func refuseBlockedRecipient(ctx context.Context, client *whatsmeow.Client, recipient types.JID) error {
+	if !config.WhatsappRefuseBlocked {
+		return nil
+	}
+	if recipient.Server != types.DefaultUserServer && recipient.Server != types.HiddenUserServer {
+		return nil
+	}
+
+	blocked, err := whatsapp.IsBlocked(ctx, client, recipient)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to load blocklist, sending without checking it")
+		return nil
+	}
+	if blocked {
+		return pkgError.RecipientBlockedError(fmt.Sprintf("%s is blocked, unblock them before sending", recipient.ToNonAD()))
+	}
+	return nil
+}
+
 func (service serviceSend) SendText(ctx context.Context, request domainSend.MessageRequest) (response domainSend.GenericResponse, err error) {
 	err = validations.ValidateSendMessage(ctx, request)
 	if err != nil {
@@ -208,6 +237,64 @@ func (service serviceSend) SendImage(ctx context.Context, request domainSend.Ima
 		return response, err
 	}
 
+	dataWaImage, dataWaThumbnail, err := service.prepareImage(request.Image, request.ImageURL, request.Compress)
+	if err != nil {
+		return response, err
+	}
+
+	// Send to WA server
+	dataWaCaption := request.Caption
+	uploadedImage, err := service.uploadMedia(ctx, client, whatsmeow.MediaImage, dataWaImage, dataWaRecipient)
+	if err != nil {
+		fmt.Printf("failed to upload file: %v", err)
+		return response, err
+	}
+
+	msg := &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+		JPEGThumbnail: dataWaThumbnail,
+		Caption:       proto.String(dataWaCaption),
+		URL:           proto.String(uploadedImage.URL),
+		DirectPath:    proto.String(uploadedImage.DirectPath),
+		MediaKey:      uploadedImage.MediaKey,
+		Mimetype:      proto.String(http.DetectContentType(dataWaImage)),
+		FileEncSHA256: uploadedImage.FileEncSHA256,
+		FileSHA256:    uploadedImage.FileSHA256,
+		FileLength:    proto.Uint64(uint64(len(dataWaImage))),
+		ViewOnce:      proto.Bool(request.ViewOnce),
+	}}
+
+	if request.BaseRequest.IsForwarded {
+		msg.ImageMessage.ContextInfo = &waE2E.ContextInfo{
+			IsForwarded:     proto.Bool(true),
+			ForwardingScore: proto.Uint32(100),
+		}
+	}
+
+	// Set duration expiration
+	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
+		if msg.ImageMessage.ContextInfo == nil {
+			msg.ImageMessage.ContextInfo = &waE2E.ContextInfo{}
+		}
+		msg.ImageMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
+	}
+
+	caption := "🖼️ Image"
+	if request.Caption != "" {
+		caption = "🖼️ " + request.Caption
+	}
+	ts, err := service.wrapSendMessage(ctx, client, request.AgentID, dataWaRecipient, msg, caption)
+	if err != nil {
+		return response, err
+	}
+
+	response.MessageID = ts.ID
+	response.Status = fmt.Sprintf("Message sent to %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
+	return response, nil
+}
+
+// prepareImage loads the image from the upload or URL, compresses it when asked and renders the JPEG thumbnail.
+// Temporary files are cleaned up in the background; only the bytes are handed back.
+func (service serviceSend) prepareImage(image *multipart.FileHeader, imageURL *string, compress bool) (imageData []byte, thumbnail []byte, err error) {
 	var (
 		imagePath      string
 		imageThumbnail string
@@ -215,21 +302,29 @@ func (service serviceSend) SendImage(ctx context.Context, request domainSend.Ima
 		deletedItems   []string
 		oriImagePath   string
 	)
+	defer func() {
+		go func() {
+			errDelete := utils.RemoveFile(0, deletedItems...)
+			if errDelete != nil {
+				fmt.Println("error when deleting picture: ", errDelete)
+			}
+		}()
+	}()
 
-	if request.ImageURL != nil && *request.ImageURL != "" {
+	if imageURL != nil && *imageURL != "" {
 		// Download image from URL
-		imageData, fileName, err := utils.DownloadImageFromURL(*request.ImageURL)
+		downloaded, fileName, err := utils.DownloadImageFromURL(*imageURL)
 		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download image from URL %v", err))
+			return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to download image from URL %v", err))
 		}
 
 		// Check if the downloaded image is WebP and convert to PNG if needed
-		mimeType := http.DetectContentType(imageData)
+		mimeType := http.DetectContentType(downloaded)
 		if mimeType == "image/webp" {
 			// Convert WebP to PNG
-			webpImage, err := imaging.Decode(bytes.NewReader(imageData))
+			webpImage, err := imaging.Decode(bytes.NewReader(downloaded))
 			if err != nil {
-				return response, pkgError.InternalServerError(fmt.Sprintf("failed to decode WebP image %v", err))
+				return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to decode WebP image %v", err))
 			}
 
 			// Change file extension to PNG
@@ -243,52 +338,52 @@ func (service serviceSend) SendImage(ctx context.Context, request domainSend.Ima
 			var pngBuffer bytes.Buffer
 			err = imaging.Encode(&pngBuffer, webpImage, imaging.PNG)
 			if err != nil {
-				return response, pkgError.InternalServerError(fmt.Sprintf("failed to convert WebP to PNG %v", err))
+				return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to convert WebP to PNG %v", err))
 			}
-			imageData = pngBuffer.Bytes()
+			downloaded = pngBuffer.Bytes()
 		}
 
 		oriImagePath = fmt.Sprintf("%s/%s", config.PathSendItems, fileName)
 		imageName = fileName
-		err = os.WriteFile(oriImagePath, imageData, 0644)
+		err = os.WriteFile(oriImagePath, downloaded, 0644)
 		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to save downloaded image %v", err))
+			return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to save downloaded image %v", err))
 		}
-	} else if request.Image != nil {
+	} else if image != nil {
 		// Save image to server
-		oriImagePath = fmt.Sprintf("%s/%s", config.PathSendItems, request.Image.Filename)
-		err = fasthttp.SaveMultipartFile(request.Image, oriImagePath)
+		oriImagePath = fmt.Sprintf("%s/%s", config.PathSendItems, image.Filename)
+		err = fasthttp.SaveMultipartFile(image, oriImagePath)
 		if err != nil {
-			return response, err
+			return nil, nil, err
 		}
-		imageName = request.Image.Filename
+		imageName = image.Filename
 	}
 	deletedItems = append(deletedItems, oriImagePath)
 
 	/* Generate thumbnail with smalled image size */
 	srcImage, err := imaging.Open(oriImagePath)
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to open image file '%s' for thumbnail generation: %v. Possible causes: file not found, unsupported format, or permission denied.", oriImagePath, err))
+		return nil, nil, pkgError.InternalServerError(fmt.Sprintf("Failed to open image file '%s' for thumbnail generation: %v. Possible causes: file not found, unsupported format, or permission denied.", oriImagePath, err))
 	}
 
 	// Resize Thumbnail
 	resizedImage := imaging.Resize(srcImage, 100, 0, imaging.Lanczos)
 	imageThumbnail = fmt.Sprintf("%s/thumbnails-%s", config.PathSendItems, imageName)
 	if err = imaging.Save(resizedImage, imageThumbnail); err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to save thumbnail %v", err))
+		return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to save thumbnail %v", err))
 	}
 	deletedItems = append(deletedItems, imageThumbnail)
 
-	if request.Compress {
+	if compress {
 		// Resize image
 		openImageBuffer, err := imaging.Open(oriImagePath)
 		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("Failed to open image file '%s' for compression: %v. Possible causes: file not found, unsupported format, or permission denied.", oriImagePath, err))
+			return nil, nil, pkgError.InternalServerError(fmt.Sprintf("Failed to open image file '%s' for compression: %v. Possible causes: file not found, unsupported format, or permission denied.", oriImagePath, err))
 		}
 		newImage := imaging.Resize(openImageBuffer, 600, 0, imaging.Lanczos)
 		newImagePath := fmt.Sprintf("%s/new-%s", config.PathSendItems, imageName)
 		if err = imaging.Save(newImage, newImagePath); err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to save image %v", err))
+			return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to save image %v", err))
 		}
 		deletedItems = append(deletedItems, newImagePath)
 		imagePath = newImagePath
@@ -296,68 +391,15 @@ func (service serviceSend) SendImage(ctx context.Context, request domainSend.Ima
 		imagePath = oriImagePath
 	}
 
-	// Send to WA server
-	dataWaCaption := request.Caption
-	dataWaImage, err := os.ReadFile(imagePath)
-	if err != nil {
-		return response, err
-	}
-	uploadedImage, err := service.uploadMedia(ctx, client, whatsmeow.MediaImage, dataWaImage, dataWaRecipient)
-	if err != nil {
-		fmt.Printf("failed to upload file: %v", err)
-		return response, err
-	}
-	dataWaThumbnail, err := os.ReadFile(imageThumbnail)
+	imageData, err = os.ReadFile(imagePath)
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to read thumbnail %v", err))
-	}
-
-	msg := &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
-		JPEGThumbnail: dataWaThumbnail,
-		Caption:       proto.String(dataWaCaption),
-		URL:           proto.String(uploadedImage.URL),
-		DirectPath:    proto.String(uploadedImage.DirectPath),
-		MediaKey:      uploadedImage.MediaKey,
-		Mimetype:      proto.String(http.DetectContentType(dataWaImage)),
-		FileEncSHA256: uploadedImage.FileEncSHA256,
-		FileSHA256:    uploadedImage.FileSHA256,
-		FileLength:    proto.Uint64(uint64(len(dataWaImage))),
-		ViewOnce:      proto.Bool(request.ViewOnce),
-	}}
-
-	if request.BaseRequest.IsForwarded {
-		msg.ImageMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
-		}
-	}
-
-	// Set duration expiration
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.ImageMessage.ContextInfo == nil {
-			msg.ImageMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.ImageMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	}
-
-	caption := "🖼️ Image"
-	if request.Caption != "" {
-		caption = "🖼️ " + request.Caption
+		return nil, nil, err
 	}
-	ts, err := service.wrapSendMessage(ctx, client, request.AgentID, dataWaRecipient, msg, caption)
-	go func() {
-		errDelete := utils.RemoveFile(0, deletedItems...)
-		if errDelete != nil {
-			fmt.Println("error when deleting picture: ", errDelete)
-		}
-	}()
+	thumbnail, err = os.ReadFile(imageThumbnail)
 	if err != nil {
-		return response, err
+		return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to read thumbnail %v", err))
 	}
-
-	response.MessageID = ts.ID
-	response.Status = fmt.Sprintf("Message sent to %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
-	return response, nil
+	return imageData, thumbnail, nil
 }
 
 func (service serviceSend) SendFile(ctx context.Context, request domainSend.FileRequest) (response domainSend.GenericResponse, err error) {
@@ -456,8 +498,67 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 		return response, err
 	}
 
+	dataWaVideo, dataWaThumbnail, err := service.prepareVideo(ctx, request.Video, request.VideoURL, request.VideoPath, request.Compress)
+	if err != nil {
+		return response, err
+	}
+
+	//Send to WA server
+	domainSend.ReportProgress(ctx, domainSend.JobStatusUploading)
+	uploaded, err := service.uploadMedia(ctx, client, whatsmeow.MediaVideo, dataWaVideo, dataWaRecipient)
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to upload file: %v", err))
+	}
+
+	msg := &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+		URL:                 proto.String(uploaded.URL),
+		Mimetype:            proto.String(http.DetectContentType(dataWaVideo)),
+		Caption:             proto.String(request.Caption),
+		FileLength:          proto.Uint64(uploaded.FileLength),
+		FileSHA256:          uploaded.FileSHA256,
+		FileEncSHA256:       uploaded.FileEncSHA256,
+		MediaKey:            uploaded.MediaKey,
+		DirectPath:          proto.String(uploaded.DirectPath),
+		ViewOnce:            proto.Bool(request.ViewOnce),
+		JPEGThumbnail:       dataWaThumbnail,
+		ThumbnailEncSHA256:  dataWaThumbnail,
+		ThumbnailSHA256:     dataWaThumbnail,
+		ThumbnailDirectPath: proto.String(uploaded.DirectPath),
+	}}
+
+	if request.BaseRequest.IsForwarded {
+		msg.VideoMessage.ContextInfo = &waE2E.ContextInfo{
+			IsForwarded:     proto.Bool(true),
+			ForwardingScore: proto.Uint32(100),
+		}
+	}
+
+	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
+		if msg.VideoMessage.ContextInfo == nil {
+			msg.VideoMessage.ContextInfo = &waE2E.ContextInfo{}
+		}
+		msg.VideoMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
+	}
+
+	caption := "🎥 Video"
+	if request.Caption != "" {
+		caption = "🎥 " + request.Caption
+	}
+	ts, err := service.wrapSendMessage(ctx, client, request.AgentID, dataWaRecipient, msg, caption)
+	if err != nil {
+		return response, err
+	}
+
+	response.MessageID = ts.ID
+	response.Status = fmt.Sprintf("Video sent to %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
+	return response, nil
+}
+
+// prepareVideo stores the video from the upload, URL or an already stored path, renders its thumbnail and
+// compresses it when asked. Progress is reported through ctx for async jobs.
+func (service serviceSend) prepareVideo(ctx context.Context, video *multipart.FileHeader, videoURL *string, storedPath string, compress bool) (videoData []byte, thumbnail []byte, err error) {
 	var (
-		videoPath      string
+		videoFilePath  string
 		videoThumbnail string
 		deletedItems   []string
 	)
@@ -475,33 +576,37 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 	var oriVideoPath string
 
 	// Determine source of video (URL or uploaded file)
-	if request.VideoURL != nil && *request.VideoURL != "" {
+	if videoURL != nil && *videoURL != "" {
+		domainSend.ReportProgress(ctx, domainSend.JobStatusDownloading)
 		// Download video bytes
-		videoBytes, fileName, errDownload := utils.DownloadVideoFromURL(*request.VideoURL)
+		videoBytes, fileName, errDownload := utils.DownloadVideoFromURL(*videoURL)
 		if errDownload != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to download video from URL %v", errDownload))
+			return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to download video from URL %v", errDownload))
 		}
 		// Build file path to save the downloaded video temporarily
 		oriVideoPath = fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+fileName)
 		if errWrite := os.WriteFile(oriVideoPath, videoBytes, 0644); errWrite != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to store downloaded video in server %v", errWrite))
+			return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to store downloaded video in server %v", errWrite))
 		}
-	} else if request.Video != nil {
+	} else if storedPath != "" {
+		// Video was already stored by the async job submission
+		oriVideoPath = storedPath
+	} else if video != nil {
 		// Save uploaded video to server
-		oriVideoPath = fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+request.Video.Filename)
-		err = fasthttp.SaveMultipartFile(request.Video, oriVideoPath)
+		oriVideoPath = fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+video.Filename)
+		err = fasthttp.SaveMultipartFile(video, oriVideoPath)
 		if err != nil {
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to store video in server %v", err))
+			return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to store video in server %v", err))
 		}
 	} else {
 		// This should not happen due to validation, but guard anyway
-		return response, pkgError.ValidationError("either Video or VideoURL must be provided")
+		return nil, nil, pkgError.ValidationError("either Video or VideoURL must be provided")
 	}
 
 	// Check if ffmpeg is installed
 	_, err = exec.LookPath("ffmpeg")
 	if err != nil {
-		return response, pkgError.InternalServerError("ffmpeg not installed")
+		return nil, nil, pkgError.InternalServerError("ffmpeg not installed")
 	}
 
 	// Generate thumbnail using ffmpeg
@@ -509,18 +614,18 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 	cmdThumbnail := exec.Command("ffmpeg", "-i", oriVideoPath, "-ss", "00:00:01.000", "-vframes", "1", thumbnailVideoPath)
 	err = cmdThumbnail.Run()
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to create thumbnail %v", err))
+		return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to create thumbnail %v", err))
 	}
 
 	// Resize Thumbnail
 	srcImage, err := imaging.Open(thumbnailVideoPath)
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to open generated video thumbnail image '%s': %v. Possible causes: file not found, unsupported format, or permission denied.", thumbnailVideoPath, err))
+		return nil, nil, pkgError.InternalServerError(fmt.Sprintf("Failed to open generated video thumbnail image '%s': %v. Possible causes: file not found, unsupported format, or permission denied.", thumbnailVideoPath, err))
 	}
 	resizedImage := imaging.Resize(srcImage, 100, 0, imaging.Lanczos)
 	thumbnailResizeVideoPath := fmt.Sprintf("%s/thumbnails-%s", config.PathSendItems, generateUUID+".png")
 	if err = imaging.Save(resizedImage, thumbnailResizeVideoPath); err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("failed to save thumbnail %v", err))
+		return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to save thumbnail %v", err))
 	}
 
 	deletedItems = append(deletedItems, thumbnailVideoPath)
@@ -528,7 +633,8 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 	videoThumbnail = thumbnailResizeVideoPath
 
 	// Compress if requested
-	if request.Compress {
+	if compress {
+		domainSend.ReportProgress(ctx, domainSend.JobStatusCompressing)
 		compresVideoPath := fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+".mp4")
 
 		// Use proper compression settings to reduce file size
@@ -553,72 +659,25 @@ func (service serviceSend) SendVideo(ctx context.Context, request domainSend.Vid
 		output, err := cmdCompress.CombinedOutput()
 		if err != nil {
 			logrus.Errorf("ffmpeg compression failed: %v, output: %s", err, string(output))
-			return response, pkgError.InternalServerError(fmt.Sprintf("failed to compress video: %v", err))
+			return nil, nil, pkgError.InternalServerError(fmt.Sprintf("failed to compress video: %v", err))
 		}
 
-		videoPath = compresVideoPath
+		videoFilePath = compresVideoPath
 		deletedItems = append(deletedItems, compresVideoPath)
 	} else {
-		videoPath = oriVideoPath
+		videoFilePath = oriVideoPath
 	}
 	deletedItems = append(deletedItems, oriVideoPath)
 
-	//Send to WA server
-	dataWaVideo, err := os.ReadFile(videoPath)
-	if err != nil {
-		return response, err
-	}
-	uploaded, err := service.uploadMedia(ctx, client, whatsmeow.MediaVideo, dataWaVideo, dataWaRecipient)
+	dataWaVideo, err := os.ReadFile(videoFilePath)
 	if err != nil {
-		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to upload file: %v", err))
+		return nil, nil, err
 	}
 	dataWaThumbnail, err := os.ReadFile(videoThumbnail)
 	if err != nil {
-		return response, err
-	}
-
-	msg := &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
-		URL:                 proto.String(uploaded.URL),
-		Mimetype:            proto.String(http.DetectContentType(dataWaVideo)),
-		Caption:             proto.String(request.Caption),
-		FileLength:          proto.Uint64(uploaded.FileLength),
-		FileSHA256:          uploaded.FileSHA256,
-		FileEncSHA256:       uploaded.FileEncSHA256,
-		MediaKey:            uploaded.MediaKey,
-		DirectPath:          proto.String(uploaded.DirectPath),
-		ViewOnce:            proto.Bool(request.ViewOnce),
-		JPEGThumbnail:       dataWaThumbnail,
-		ThumbnailEncSHA256:  dataWaThumbnail,
-		ThumbnailSHA256:     dataWaThumbnail,
-		ThumbnailDirectPath: proto.String(uploaded.DirectPath),
-	}}
-
-	if request.BaseRequest.IsForwarded {
-		msg.VideoMessage.ContextInfo = &waE2E.ContextInfo{
-			IsForwarded:     proto.Bool(true),
-			ForwardingScore: proto.Uint32(100),
-		}
-	}
-
-	if request.BaseRequest.Duration != nil && *request.BaseRequest.Duration > 0 {
-		if msg.VideoMessage.ContextInfo == nil {
-			msg.VideoMessage.ContextInfo = &waE2E.ContextInfo{}
-		}
-		msg.VideoMessage.ContextInfo.Expiration = proto.Uint32(uint32(*request.BaseRequest.Duration))
-	}
-
-	caption := "🎥 Video"
-	if request.Caption != "" {
-		caption = "🎥 " + request.Caption
-	}
-	ts, err := service.wrapSendMessage(ctx, client, request.AgentID, dataWaRecipient, msg, caption)
-	if err != nil {
-		return response, err
+		return nil, nil, err
 	}
-
-	response.MessageID = ts.ID
-	response.Status = fmt.Sprintf("Video sent to %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
-	return response, nil
+	return dataWaVideo, dataWaThumbnail, nil
 }
 
 func (service serviceSend) SendContact(ctx context.Context, request domainSend.ContactRequest) (response domainSend.GenericResponse, err error) {
@@ -830,6 +889,16 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 		audioMimeType = http.DetectContentType(audioBytes)
 	}
 
+	var note voiceNote
+	if request.PTT {
+		note, err = service.prepareVoiceNote(ctx, audioBytes)
+		if err != nil {
+			return response, err
+		}
+		audioBytes = note.Data
+		audioMimeType = voiceNoteMimeType
+	}
+
 	// upload to WhatsApp servers
 	audioUploaded, err := service.uploadMedia(ctx, client, whatsmeow.MediaAudio, audioBytes, dataWaRecipient)
 	if err != nil {
@@ -849,6 +918,12 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 		},
 	}
 
+	if request.PTT {
+		msg.AudioMessage.PTT = proto.Bool(true)
+		msg.AudioMessage.Seconds = proto.Uint32(note.Seconds)
+		msg.AudioMessage.Waveform = note.Waveform
+	}
+
 	if request.BaseRequest.IsForwarded {
 		msg.AudioMessage.ContextInfo = &waE2E.ContextInfo{
 			IsForwarded:     proto.Bool(true),
@@ -864,6 +939,9 @@ func (service serviceSend) SendAudio(ctx context.Context, request domainSend.Aud
 	}
 
 	content := "🎵 Audio"
+	if request.PTT {
+		content = "🎤 Voice note"
+	}
 
 	ts, err := service.wrapSendMessage(ctx, client, request.AgentID, dataWaRecipient, msg, content)
 	if err != nil {
@@ -905,6 +983,23 @@ func (service serviceSend) SendPoll(ctx context.Context, request domainSend.Poll
 		return response, err
 	}
 
+	// Keep the options so incoming votes can be decrypted into option names
+	senderJID := ""
+	if client.Store != nil && client.Store.ID != nil {
+		senderJID = client.Store.ID.String()
+	}
+	if err := service.chatStorageRepo.ForAgent(request.AgentID).StorePoll(&domainChatStorage.Poll{
+		MessageID:       ts.ID,
+		ChatJID:         dataWaRecipient.String(),
+		Creator:         senderJID,
+		Question:        request.Question,
+		Options:         request.Options,
+		SelectableCount: uint32(request.MaxAnswer),
+		CreatedAt:       ts.Timestamp,
+	}); err != nil {
+		logrus.Warnf("Failed to store poll %s: %v", ts.ID, err)
+	}
+
 	response.MessageID = ts.ID
 	response.Status = fmt.Sprintf("Send poll success %s (server timestamp: %s)", request.BaseRequest.Phone, ts.Timestamp.String())
 	return response, nil
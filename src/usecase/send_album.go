@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// albumMedia is an album item that has been processed and uploaded, ready to be sent
+type albumMedia struct {
+	item      domainSend.AlbumItem
+	data      []byte
+	thumbnail []byte
+	uploaded  whatsmeow.UploadResponse
+}
+
+// SendAlbum sends an album header followed by every item linked to it, which WhatsApp renders as one grouped album.
+// All media is processed and uploaded before anything is sent, so a bad item never leaves a half-sent album.
+func (service serviceSend) SendAlbum(ctx context.Context, request domainSend.AlbumRequest) (response domainSend.AlbumResponse, err error) {
+	err = validations.ValidateSendAlbum(ctx, request)
+	if err != nil {
+		return response, err
+	}
+	client, err := service.resolveClient(request.AgentID)
+	if err != nil {
+		return response, err
+	}
+	dataWaRecipient, err := utils.ValidateJidWithLogin(client, request.BaseRequest.Phone)
+	if err != nil {
+		return response, err
+	}
+	if dataWaRecipient.Server == types.NewsletterServer {
+		return response, pkgError.ValidationError("albums cannot be sent to newsletters")
+	}
+
+	media := make([]albumMedia, 0, len(request.Items))
+	var imageCount, videoCount uint32
+	for i, item := range request.Items {
+		prepared, err := service.prepareAlbumItem(ctx, client, dataWaRecipient, item, request.Compress)
+		if err != nil {
+			return response, fmt.Errorf("items[%d]: %w", i, err)
+		}
+		if item.Type == domainSend.AlbumItemVideo {
+			videoCount++
+		} else {
+			imageCount++
+		}
+		media = append(media, prepared)
+	}
+
+	albumMsg := &waE2E.Message{AlbumMessage: &waE2E.AlbumMessage{
+		ExpectedImageCount: proto.Uint32(imageCount),
+		ExpectedVideoCount: proto.Uint32(videoCount),
+		ContextInfo:        albumContextInfo(request.BaseRequest),
+	}}
+	ts, err := service.wrapSendMessage(ctx, client, request.AgentID, dataWaRecipient, albumMsg, fmt.Sprintf("🖼️ Album (%d items)", len(media)))
+	if err != nil {
+		return response, err
+	}
+	response.AlbumID = ts.ID
+
+	albumKey := &waCommon.MessageKey{
+		RemoteJID: proto.String(dataWaRecipient.String()),
+		FromMe:    proto.Bool(true),
+		ID:        proto.String(ts.ID),
+	}
+	// Items are sent one after another so the album keeps the requested order
+	response.MessageIDs = make([]string, 0, len(media))
+	for i, prepared := range media {
+		msg, content := buildAlbumItemMessage(prepared, request.BaseRequest)
+		msg.MessageContextInfo = albumItemAssociation(albumKey, i)
+
+		itemTS, err := service.wrapSendMessage(ctx, client, request.AgentID, dataWaRecipient, msg, content)
+		if err != nil {
+			return response, pkgError.InternalServerError(fmt.Sprintf("album item %d failed after %d of %d were sent: %v", i, i, len(media), err))
+		}
+		response.MessageIDs = append(response.MessageIDs, itemTS.ID)
+	}
+
+	response.Status = fmt.Sprintf("Album of %d items sent to %s (server timestamp: %s)", len(media), request.BaseRequest.Phone, ts.Timestamp.String())
+	return response, nil
+}
+
+func (service serviceSend) prepareAlbumItem(ctx context.Context, client *whatsmeow.Client, recipient types.JID, item domainSend.AlbumItem, compress bool) (prepared albumMedia, err error) {
+	prepared.item = item
+
+	mediaType := whatsmeow.MediaImage
+	if item.Type == domainSend.AlbumItemVideo {
+		mediaType = whatsmeow.MediaVideo
+		prepared.data, prepared.thumbnail, err = service.prepareVideo(ctx, item.Upload, item.URL, "", compress)
+	} else {
+		prepared.data, prepared.thumbnail, err = service.prepareImage(item.Upload, item.URL, compress)
+	}
+	if err != nil {
+		return prepared, err
+	}
+
+	prepared.uploaded, err = service.uploadMedia(ctx, client, mediaType, prepared.data, recipient)
+	if err != nil {
+		return prepared, pkgError.WaUploadMediaError(fmt.Sprintf("failed to upload %s: %v", item.Type, err))
+	}
+	return prepared, nil
+}
+
+// albumContextInfo carries the forwarded flag and disappearing timer shared by the album and its items
+func albumContextInfo(base domainSend.BaseRequest) *waE2E.ContextInfo {
+	if !base.IsForwarded && (base.Duration == nil || *base.Duration <= 0) {
+		return nil
+	}
+	ctxInfo := &waE2E.ContextInfo{}
+	if base.IsForwarded {
+		ctxInfo.IsForwarded = proto.Bool(true)
+		ctxInfo.ForwardingScore = proto.Uint32(100)
+	}
+	if base.Duration != nil && *base.Duration > 0 {
+		ctxInfo.Expiration = proto.Uint32(uint32(*base.Duration))
+	}
+	return ctxInfo
+}
+
+// albumItemAssociation links an item to the album header so clients group it
+func albumItemAssociation(albumKey *waCommon.MessageKey, index int) *waE2E.MessageContextInfo {
+	return &waE2E.MessageContextInfo{
+		MessageAssociation: &waE2E.MessageAssociation{
+			AssociationType:  waE2E.MessageAssociation_MEDIA_ALBUM.Enum(),
+			ParentMessageKey: albumKey,
+			MessageIndex:     proto.Int32(int32(index)),
+		},
+	}
+}
+
+func buildAlbumItemMessage(prepared albumMedia, base domainSend.BaseRequest) (*waE2E.Message, string) {
+	uploaded := prepared.uploaded
+	caption := prepared.item.Caption
+
+	if prepared.item.Type == domainSend.AlbumItemVideo {
+		content := "🎥 Video"
+		if caption != "" {
+			content = "🎥 " + caption
+		}
+		return &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+			URL:           proto.String(uploaded.URL),
+			Mimetype:      proto.String(http.DetectContentType(prepared.data)),
+			Caption:       proto.String(caption),
+			FileLength:    proto.Uint64(uploaded.FileLength),
+			FileSHA256:    uploaded.FileSHA256,
+			FileEncSHA256: uploaded.FileEncSHA256,
+			MediaKey:      uploaded.MediaKey,
+			DirectPath:    proto.String(uploaded.DirectPath),
+			JPEGThumbnail: prepared.thumbnail,
+			ContextInfo:   albumContextInfo(base),
+		}}, content
+	}
+
+	content := "🖼️ Image"
+	if caption != "" {
+		content = "🖼️ " + caption
+	}
+	return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+		JPEGThumbnail: prepared.thumbnail,
+		Caption:       proto.String(caption),
+		URL:           proto.String(uploaded.URL),
+		DirectPath:    proto.String(uploaded.DirectPath),
+		MediaKey:      uploaded.MediaKey,
+		Mimetype:      proto.String(http.DetectContentType(prepared.data)),
+		FileEncSHA256: uploaded.FileEncSHA256,
+		FileSHA256:    uploaded.FileSHA256,
+		FileLength:    proto.Uint64(uint64(len(prepared.data))),
+		ContextInfo:   albumContextInfo(base),
+	}}, content
+}
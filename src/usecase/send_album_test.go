@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"testing"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waCommon"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAlbumItemAssociation(t *testing.T) {
+	albumKey := &waCommon.MessageKey{ID: proto.String("3EB0ALBUM"), FromMe: proto.Bool(true)}
+
+	association := albumItemAssociation(albumKey, 2).GetMessageAssociation()
+	if association.GetAssociationType() != waE2E.MessageAssociation_MEDIA_ALBUM {
+		t.Fatalf("association type = %v, want MEDIA_ALBUM", association.GetAssociationType())
+	}
+	if association.GetParentMessageKey().GetID() != "3EB0ALBUM" || association.GetMessageIndex() != 2 {
+		t.Fatalf("unexpected association %+v", association)
+	}
+}
+
+func TestBuildAlbumItemMessage(t *testing.T) {
+	duration := 86400
+	base := domainSend.BaseRequest{Duration: &duration}
+	uploaded := whatsmeow.UploadResponse{URL: "https://mmg.whatsapp.net/x", DirectPath: "/x", FileLength: 4}
+
+	msg, content := buildAlbumItemMessage(albumMedia{
+		item:     domainSend.AlbumItem{Type: domainSend.AlbumItemVideo, Caption: "Demo"},
+		data:     []byte{0, 0, 0, 0},
+		uploaded: uploaded,
+	}, base)
+	if msg.GetVideoMessage() == nil || msg.GetVideoMessage().GetCaption() != "Demo" || content != "🎥 Demo" {
+		t.Fatalf("unexpected video item %+v (content %q)", msg, content)
+	}
+	if msg.GetVideoMessage().GetContextInfo().GetExpiration() != 86400 {
+		t.Fatal("expected disappearing timer on album item")
+	}
+
+	msg, content = buildAlbumItemMessage(albumMedia{
+		item:     domainSend.AlbumItem{Type: domainSend.AlbumItemImage},
+		data:     []byte{0xFF, 0xD8, 0xFF},
+		uploaded: uploaded,
+	}, domainSend.BaseRequest{})
+	if msg.GetImageMessage() == nil || content != "🖼️ Image" {
+		t.Fatalf("unexpected image item %+v (content %q)", msg, content)
+	}
+	if msg.GetImageMessage().GetContextInfo() != nil {
+		t.Fatal("expected no context info without forward flag or timer")
+	}
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/ui/rest/helpers"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+var batchVariablePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// applyBatchVariables fills {{name}} placeholders with the recipient's variables. Unknown placeholders are kept as-is.
+func applyBatchVariables(text string, variables map[string]string) string {
+	if len(variables) == 0 || text == "" {
+		return text
+	}
+	return batchVariablePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := batchVariablePattern.FindStringSubmatch(placeholder)[1]
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// batchContextInfo builds the per-recipient ContextInfo shared by every batch message type.
+func batchContextInfo(base domainSend.BatchBaseRequest, defaultExpiration uint32) *waE2E.ContextInfo {
+	ctxInfo := &waE2E.ContextInfo{}
+	if base.IsForwarded {
+		ctxInfo.IsForwarded = proto.Bool(true)
+		ctxInfo.ForwardingScore = proto.Uint32(100)
+	}
+	if base.Duration != nil && *base.Duration > 0 {
+		ctxInfo.Expiration = proto.Uint32(uint32(*base.Duration))
+	} else if defaultExpiration > 0 {
+		ctxInfo.Expiration = proto.Uint32(defaultExpiration)
+	}
+	return ctxInfo
+}
+
+// sendBatch resolves every recipient and sends the message built for it, collecting a result per recipient.
+// A failing recipient never aborts the rest of the batch.
+func (service serviceSend) sendBatch(
+	ctx context.Context,
+	client *whatsmeow.Client,
+	base domainSend.BatchBaseRequest,
+	build func(recipient domainSend.BatchRecipient, jid types.JID) (msg *waE2E.Message, content string, err error),
+) (response domainSend.BatchResponse) {
+	response.Total = len(base.Recipients)
+	response.Results = make([]domainSend.BatchResult, 0, len(base.Recipients))
+
+	for _, recipient := range base.Recipients {
+		result := domainSend.BatchResult{Phone: recipient.Phone}
+		messageID, err := service.sendBatchRecipient(ctx, client, base.AgentID, recipient, build)
+		if err != nil {
+			logrus.Warnf("Batch send to %s failed: %v", recipient.Phone, err)
+			result.Status = "failed"
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			result.Status = "sent"
+			result.MessageID = messageID
+			response.Sent++
+		}
+		response.Results = append(response.Results, result)
+	}
+	return response
+}
+
+func (service serviceSend) sendBatchRecipient(
+	ctx context.Context,
+	client *whatsmeow.Client,
+	agentID string,
+	recipient domainSend.BatchRecipient,
+	build func(recipient domainSend.BatchRecipient, jid types.JID) (msg *waE2E.Message, content string, err error),
+) (messageID string, err error) {
+	// JID validation panics when the client drops mid-batch; record it against this recipient instead
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	jid, err := utils.ValidateJidWithLogin(client, recipient.Phone)
+	if err != nil {
+		return "", err
+	}
+	msg, content, err := build(recipient, jid)
+	if err != nil {
+		return "", err
+	}
+	ts, err := service.wrapSendMessage(ctx, client, agentID, jid, msg, content)
+	if err != nil {
+		return "", err
+	}
+	return ts.ID, nil
+}
+
+func (service serviceSend) SendTextBatch(ctx context.Context, request domainSend.BatchMessageRequest) (response domainSend.BatchResponse, err error) {
+	err = validations.ValidateSendMessageBatch(ctx, request)
+	if err != nil {
+		return response, err
+	}
+	client, err := service.resolveClient(request.AgentID)
+	if err != nil {
+		return response, err
+	}
+
+	response = service.sendBatch(ctx, client, request.BatchBaseRequest, func(recipient domainSend.BatchRecipient, jid types.JID) (*waE2E.Message, string, error) {
+		text := applyBatchVariables(request.Message, recipient.Variables)
+		ctxInfo := batchContextInfo(request.BatchBaseRequest, service.getDefaultEphemeralExpiration(recipient.Phone))
+		if mentions := service.getMentionFromText(ctx, client, text); len(mentions) > 0 {
+			ctxInfo.MentionedJID = mentions
+		}
+		return &waE2E.Message{ExtendedTextMessage: &waE2E.ExtendedTextMessage{
+			Text:        proto.String(text),
+			ContextInfo: ctxInfo,
+		}}, text, nil
+	})
+	return response, nil
+}
+
+func (service serviceSend) SendImageBatch(ctx context.Context, request domainSend.BatchImageRequest) (response domainSend.BatchResponse, err error) {
+	err = validations.ValidateSendImageBatch(ctx, request)
+	if err != nil {
+		return response, err
+	}
+	client, err := service.resolveClient(request.AgentID)
+	if err != nil {
+		return response, err
+	}
+
+	dataWaImage, dataWaThumbnail, err := service.prepareImage(request.Image, request.ImageURL, request.Compress)
+	if err != nil {
+		return response, err
+	}
+
+	// Upload once; every recipient reuses the same media reference
+	uploadedImage, err := client.Upload(ctx, dataWaImage, whatsmeow.MediaImage)
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to upload image: %v", err))
+	}
+	mimeType := http.DetectContentType(dataWaImage)
+
+	response = service.sendBatch(ctx, client, request.BatchBaseRequest, func(recipient domainSend.BatchRecipient, jid types.JID) (*waE2E.Message, string, error) {
+		if jid.Server == types.NewsletterServer {
+			return nil, "", pkgError.ValidationError("newsletters are not supported in batch sends")
+		}
+		caption := applyBatchVariables(request.Caption, recipient.Variables)
+		content := "🖼️ Image"
+		if caption != "" {
+			content = "🖼️ " + caption
+		}
+		return &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+			JPEGThumbnail: dataWaThumbnail,
+			Caption:       proto.String(caption),
+			URL:           proto.String(uploadedImage.URL),
+			DirectPath:    proto.String(uploadedImage.DirectPath),
+			MediaKey:      uploadedImage.MediaKey,
+			Mimetype:      proto.String(mimeType),
+			FileEncSHA256: uploadedImage.FileEncSHA256,
+			FileSHA256:    uploadedImage.FileSHA256,
+			FileLength:    proto.Uint64(uint64(len(dataWaImage))),
+			ViewOnce:      proto.Bool(request.ViewOnce),
+			ContextInfo:   batchContextInfo(request.BatchBaseRequest, service.getDefaultEphemeralExpiration(recipient.Phone)),
+		}}, content, nil
+	})
+	return response, nil
+}
+
+func (service serviceSend) SendFileBatch(ctx context.Context, request domainSend.BatchFileRequest) (response domainSend.BatchResponse, err error) {
+	err = validations.ValidateSendFileBatch(ctx, request)
+	if err != nil {
+		return response, err
+	}
+	client, err := service.resolveClient(request.AgentID)
+	if err != nil {
+		return response, err
+	}
+
+	fileBytes := helpers.MultipartFormFileHeaderToBytes(request.File)
+	fileMimeType := resolveDocumentMIME(request.File.Filename, fileBytes)
+
+	// Upload once; every recipient reuses the same media reference
+	uploadedFile, err := client.Upload(ctx, fileBytes, whatsmeow.MediaDocument)
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to upload file: %v", err))
+	}
+
+	response = service.sendBatch(ctx, client, request.BatchBaseRequest, func(recipient domainSend.BatchRecipient, jid types.JID) (*waE2E.Message, string, error) {
+		if jid.Server == types.NewsletterServer {
+			return nil, "", pkgError.ValidationError("newsletters are not supported in batch sends")
+		}
+		caption := applyBatchVariables(request.Caption, recipient.Variables)
+		content := "📄 Document"
+		if caption != "" {
+			content = "📄 " + caption
+		}
+		return &waE2E.Message{DocumentMessage: &waE2E.DocumentMessage{
+			URL:           proto.String(uploadedFile.URL),
+			Mimetype:      proto.String(fileMimeType),
+			Title:         proto.String(request.File.Filename),
+			FileSHA256:    uploadedFile.FileSHA256,
+			FileLength:    proto.Uint64(uploadedFile.FileLength),
+			MediaKey:      uploadedFile.MediaKey,
+			FileName:      proto.String(request.File.Filename),
+			FileEncSHA256: uploadedFile.FileEncSHA256,
+			DirectPath:    proto.String(uploadedFile.DirectPath),
+			Caption:       proto.String(caption),
+			ContextInfo:   batchContextInfo(request.BatchBaseRequest, service.getDefaultEphemeralExpiration(recipient.Phone)),
+		}}, content, nil
+	})
+	return response, nil
+}
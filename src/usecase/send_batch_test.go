@@ -0,0 +1,38 @@
+package usecase
+
+import "testing"
+
+func TestApplyBatchVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		variables map[string]string
+		want      string
+	}{
+		{
+			name:      "Replaces known variables",
+			text:      "Hi {{name}}, your order {{ order_id }} is ready",
+			variables: map[string]string{"name": "Budi", "order_id": "A-17"},
+			want:      "Hi Budi, your order A-17 is ready",
+		},
+		{
+			name:      "Keeps unknown placeholders",
+			text:      "Hi {{name}}, code {{code}}",
+			variables: map[string]string{"name": "Budi"},
+			want:      "Hi Budi, code {{code}}",
+		},
+		{
+			name: "No variables",
+			text: "Hi {{name}}",
+			want: "Hi {{name}}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := applyBatchVariables(tt.text, tt.variables); got != tt.want {
+				t.Fatalf("applyBatchVariables() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
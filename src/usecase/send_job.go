@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	fiberUtils "github.com/gofiber/fiber/v2/utils"
+	"github.com/sirupsen/logrus"
+	"github.com/valyala/fasthttp"
+)
+
+const sendJobQueueSize = 100
+
+var submitJobCallback = whatsapp.SubmitCallback
+
+type sendJobTask struct {
+	jobID string
+	run   func(ctx context.Context) (domainSend.GenericResponse, error)
+}
+
+type serviceSendJob struct {
+	sendService domainSend.ISendUsecase
+	queue       chan sendJobTask
+
+	mu   sync.RWMutex
+	jobs map[string]*domainSend.Job
+}
+
+func NewSendJobService(sendService domainSend.ISendUsecase, workers int) domainSend.ISendJobUsecase {
+	if workers < 1 {
+		workers = 1
+	}
+	service := &serviceSendJob{
+		sendService: sendService,
+		queue:       make(chan sendJobTask, sendJobQueueSize),
+		jobs:        make(map[string]*domainSend.Job),
+	}
+	for i := 0; i < workers; i++ {
+		go service.worker()
+	}
+	return service
+}
+
+func (service *serviceSendJob) SubmitVideo(ctx context.Context, request domainSend.VideoRequest, callbackURL string) (job domainSend.Job, err error) {
+	// Reject bad input up front so callers get a 4xx instead of a failed job
+	if err = validations.ValidateSendVideo(ctx, request); err != nil {
+		return job, err
+	}
+	if err = validations.ValidateCallbackURL(callbackURL); err != nil {
+		return job, err
+	}
+
+	// The multipart upload only lives as long as the HTTP request, so persist it before queueing
+	if request.Video != nil {
+		videoPath := fmt.Sprintf("%s/%s", config.PathSendItems, fiberUtils.UUIDv4()+request.Video.Filename)
+		if err = fasthttp.SaveMultipartFile(request.Video, videoPath); err != nil {
+			return job, pkgError.InternalServerError(fmt.Sprintf("failed to store video in server %v", err))
+		}
+		request.Video = nil
+		request.VideoPath = videoPath
+	}
+
+	job, err = service.enqueue(request.AgentID, "video", callbackURL, func(ctx context.Context) (domainSend.GenericResponse, error) {
+		defer removeJobFile(request.VideoPath)
+		return service.sendService.SendVideo(ctx, request)
+	})
+	if err != nil {
+		removeJobFile(request.VideoPath)
+	}
+	return job, err
+}
+
+// GetJob only returns jobs submitted by the same agent. Jobs of other agents look missing so their existence
+// does not leak. The callback secret is only handed out on submit.
+func (service *serviceSendJob) GetJob(_ context.Context, agentID, jobID string) (job domainSend.Job, err error) {
+	service.mu.RLock()
+	defer service.mu.RUnlock()
+
+	stored, ok := service.jobs[jobID]
+	if !ok || stored.AgentID != agentID {
+		return job, pkgError.NotFoundError(fmt.Sprintf("job %s not found", jobID))
+	}
+	job = *stored
+	job.CallbackSecret = ""
+	return job, nil
+}
+
+func (service *serviceSendJob) enqueue(agentID, jobType, callbackURL string, run func(ctx context.Context) (domainSend.GenericResponse, error)) (domainSend.Job, error) {
+	var callbackSecret string
+	if callbackURL != "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return domainSend.Job{}, pkgError.InternalServerError(fmt.Sprintf("failed to generate callback secret %v", err))
+		}
+		callbackSecret = hex.EncodeToString(secret)
+	}
+
+	now := time.Now()
+	job := &domainSend.Job{
+		ID:             fiberUtils.UUIDv4(),
+		AgentID:        agentID,
+		Type:           jobType,
+		Status:         domainSend.JobStatusQueued,
+		CallbackURL:    callbackURL,
+		CallbackSecret: callbackSecret,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	service.mu.Lock()
+	service.sweepLocked(now)
+	service.jobs[job.ID] = job
+	snapshot := *job
+	service.mu.Unlock()
+
+	select {
+	case service.queue <- sendJobTask{jobID: job.ID, run: run}:
+		return snapshot, nil
+	default:
+		service.mu.Lock()
+		delete(service.jobs, job.ID)
+		service.mu.Unlock()
+		return domainSend.Job{}, pkgError.InternalServerError("send job queue is full, please retry later")
+	}
+}
+
+func (service *serviceSendJob) worker() {
+	for task := range service.queue {
+		service.process(task)
+	}
+}
+
+func (service *serviceSendJob) process(task sendJobTask) {
+	ctx := domainSend.WithProgress(context.Background(), func(status domainSend.JobStatus) {
+		service.update(task.jobID, func(job *domainSend.Job) { job.Status = status })
+	})
+
+	response, err := func() (response domainSend.GenericResponse, err error) {
+		// Senders report failures by panicking in a few places; keep the worker alive
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+		return task.run(ctx)
+	}()
+
+	job := service.update(task.jobID, func(job *domainSend.Job) {
+		if err != nil {
+			job.Status = domainSend.JobStatusFailed
+			job.Error = err.Error()
+			return
+		}
+		job.Status = domainSend.JobStatusSent
+		job.MessageID = response.MessageID
+	})
+
+	if err != nil {
+		logrus.Warnf("Send job %s failed: %v", task.jobID, err)
+	}
+	if job.CallbackURL != "" {
+		go service.notify(job)
+	}
+}
+
+func (service *serviceSendJob) update(jobID string, mutate func(job *domainSend.Job)) domainSend.Job {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	job, ok := service.jobs[jobID]
+	if !ok {
+		return domainSend.Job{}
+	}
+	mutate(job)
+	job.UpdatedAt = time.Now()
+	return *job
+}
+
+// notify signs the callback with the secret returned when the job was submitted, so receivers can tell the result
+// belongs to a job they created.
+func (service *serviceSendJob) notify(job domainSend.Job) {
+	secret := job.CallbackSecret
+	job.CallbackSecret = ""
+	payload := map[string]any{
+		"event":     "send.job",
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"payload":   job,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := submitJobCallback(ctx, payload, job.CallbackURL, secret); err != nil {
+		logrus.Warnf("Failed to notify callback for send job %s: %v", job.ID, err)
+	}
+}
+
+// sweepLocked drops finished jobs older than the retention window. Callers must hold mu.
+func (service *serviceSendJob) sweepLocked(now time.Time) {
+	for id, job := range service.jobs {
+		finished := job.Status == domainSend.JobStatusSent || job.Status == domainSend.JobStatusFailed
+		if finished && now.Sub(job.UpdatedAt) > config.AppSendJobRetention {
+			delete(service.jobs, id)
+		}
+	}
+}
+
+func removeJobFile(path string) {
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		logrus.Warnf("Failed to cleanup async send file %s: %v", path, err)
+	}
+}
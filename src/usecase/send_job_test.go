@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
+)
+
+func waitForJob(t *testing.T, service domainSend.ISendJobUsecase, jobID string) domainSend.Job {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := service.GetJob(context.Background(), "agent-1", jobID)
+		if err != nil {
+			t.Fatalf("GetJob() error = %v", err)
+		}
+		if job.Status == domainSend.JobStatusSent || job.Status == domainSend.JobStatusFailed {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish in time", jobID)
+	return domainSend.Job{}
+}
+
+func TestSendJobReportsProgressAndResult(t *testing.T) {
+	service := NewSendJobService(nil, 1).(*serviceSendJob)
+
+	jobIDs := make(chan string, 1)
+	progressed := make(chan domainSend.JobStatus, 1)
+	job, err := service.enqueue("agent-1", "video", "", func(ctx context.Context) (domainSend.GenericResponse, error) {
+		domainSend.ReportProgress(ctx, domainSend.JobStatusCompressing)
+		current, _ := service.GetJob(ctx, "agent-1", <-jobIDs)
+		progressed <- current.Status
+		return domainSend.GenericResponse{MessageID: "3EB0ABC"}, nil
+	})
+	if err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+	if job.Status != domainSend.JobStatusQueued {
+		t.Fatalf("new job status = %q, want %q", job.Status, domainSend.JobStatusQueued)
+	}
+	jobIDs <- job.ID
+
+	if status := <-progressed; status != domainSend.JobStatusCompressing {
+		t.Fatalf("in-flight job status = %q, want %q", status, domainSend.JobStatusCompressing)
+	}
+
+	finished := waitForJob(t, service, job.ID)
+	if finished.Status != domainSend.JobStatusSent {
+		t.Fatalf("job status = %q, want %q", finished.Status, domainSend.JobStatusSent)
+	}
+	if finished.MessageID != "3EB0ABC" {
+		t.Fatalf("job message_id = %q, want %q", finished.MessageID, "3EB0ABC")
+	}
+}
+
+func TestSendJobRecordsFailure(t *testing.T) {
+	service := NewSendJobService(nil, 1).(*serviceSendJob)
+
+	job, err := service.enqueue("agent-1", "video", "", func(context.Context) (domainSend.GenericResponse, error) {
+		return domainSend.GenericResponse{}, errors.New("ffmpeg not installed")
+	})
+	if err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	finished := waitForJob(t, service, job.ID)
+	if finished.Status != domainSend.JobStatusFailed {
+		t.Fatalf("job status = %q, want %q", finished.Status, domainSend.JobStatusFailed)
+	}
+	if finished.Error != "ffmpeg not installed" {
+		t.Fatalf("job error = %q, want %q", finished.Error, "ffmpeg not installed")
+	}
+}
+
+func TestSendJobRecoversFromPanic(t *testing.T) {
+	service := NewSendJobService(nil, 1).(*serviceSendJob)
+
+	job, _ := service.enqueue("agent-1", "video", "", func(context.Context) (domainSend.GenericResponse, error) {
+		panic("boom")
+	})
+
+	if finished := waitForJob(t, service, job.ID); finished.Status != domainSend.JobStatusFailed {
+		t.Fatalf("job status = %q, want %q", finished.Status, domainSend.JobStatusFailed)
+	}
+}
+
+func TestSendJobGetUnknown(t *testing.T) {
+	service := NewSendJobService(nil, 1)
+	if _, err := service.GetJob(context.Background(), "agent-1", "missing"); err == nil {
+		t.Fatal("expected error for unknown job")
+	}
+}
+
+func TestSendJobHiddenFromOtherAgents(t *testing.T) {
+	service := NewSendJobService(nil, 1).(*serviceSendJob)
+
+	job, err := service.enqueue("agent-1", "video", "", func(context.Context) (domainSend.GenericResponse, error) {
+		return domainSend.GenericResponse{MessageID: "3EB0ABC"}, nil
+	})
+	if err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+
+	if _, err := service.GetJob(context.Background(), "agent-2", job.ID); err == nil {
+		t.Fatal("expected another agent not to see the job")
+	}
+	if _, err := service.GetJob(context.Background(), "", job.ID); err == nil {
+		t.Fatal("expected a request without agent not to see the job of an agent")
+	}
+	waitForJob(t, service, job.ID)
+}
+
+func TestSendJobSignsCallbackWithJobSecret(t *testing.T) {
+	service := NewSendJobService(nil, 1).(*serviceSendJob)
+
+	type delivery struct {
+		payload map[string]any
+		url     string
+		secret  string
+	}
+	delivered := make(chan delivery, 1)
+	originalSubmit := submitJobCallback
+	submitJobCallback = func(_ context.Context, payload map[string]any, url, secret string) error {
+		delivered <- delivery{payload: payload, url: url, secret: secret}
+		return nil
+	}
+	defer func() { submitJobCallback = originalSubmit }()
+
+	job, err := service.enqueue("agent-1", "video", "https://example.com/jobs", func(context.Context) (domainSend.GenericResponse, error) {
+		return domainSend.GenericResponse{MessageID: "3EB0ABC"}, nil
+	})
+	if err != nil {
+		t.Fatalf("enqueue() error = %v", err)
+	}
+	if len(job.CallbackSecret) != 64 {
+		t.Fatalf("submitted job callback_secret = %q, want a generated secret", job.CallbackSecret)
+	}
+
+	select {
+	case got := <-delivered:
+		if got.url != "https://example.com/jobs" || got.secret != job.CallbackSecret {
+			t.Errorf("callback sent to %q with secret %q, want the job URL and secret", got.url, got.secret)
+		}
+		if sent := got.payload["payload"].(domainSend.Job); sent.CallbackSecret != "" {
+			t.Error("callback payload should not carry the secret")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("callback was not delivered")
+	}
+
+	if stored, _ := service.GetJob(context.Background(), "agent-1", job.ID); stored.CallbackSecret != "" {
+		t.Error("GetJob() should not return the callback secret")
+	}
+}
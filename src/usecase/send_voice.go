@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	fiberUtils "github.com/gofiber/fiber/v2/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// voiceNoteMimeType is what WhatsApp clients send for recorded voice messages
+	voiceNoteMimeType = "audio/ogg; codecs=opus"
+	// voiceNoteWaveformSamples is the number of bars WhatsApp draws for a voice note
+	voiceNoteWaveformSamples = 64
+	// voiceNoteAnalysisRate is the sample rate used to measure duration and waveform
+	voiceNoteAnalysisRate = 8000
+)
+
+// voiceNote is audio ready to be sent with PTT=true
+type voiceNote struct {
+	Data     []byte
+	Seconds  uint32
+	Waveform []byte
+}
+
+// prepareVoiceNote transcodes any audio FFmpeg can read into mono OGG/Opus and measures its duration and waveform
+func (service serviceSend) prepareVoiceNote(ctx context.Context, audioBytes []byte) (note voiceNote, err error) {
+	if _, err = exec.LookPath("ffmpeg"); err != nil {
+		return note, pkgError.InternalServerError("ffmpeg not installed")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	generateUUID := fiberUtils.UUIDv4()
+	inputPath := fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+".audio")
+	outputPath := fmt.Sprintf("%s/%s", config.PathSendItems, generateUUID+".ogg")
+	defer func() {
+		for _, path := range []string{inputPath, outputPath} {
+			if errRemove := os.Remove(path); errRemove != nil && !os.IsNotExist(errRemove) {
+				logrus.Warnf("Failed to cleanup voice note file %s: %v", path, errRemove)
+			}
+		}
+	}()
+
+	if err = os.WriteFile(inputPath, audioBytes, 0644); err != nil {
+		return note, pkgError.InternalServerError(fmt.Sprintf("failed to store audio in server %v", err))
+	}
+
+	// -vn: drop cover art, -ac 1 -ar 48000: mono at Opus' native rate, -application voip: tuned for speech
+	cmdTranscode := exec.CommandContext(ctx, "ffmpeg", "-i", inputPath,
+		"-vn",
+		"-ac", "1",
+		"-ar", "48000",
+		"-c:a", "libopus",
+		"-b:a", "32k",
+		"-application", "voip",
+		"-y",
+		outputPath)
+	if output, err := cmdTranscode.CombinedOutput(); err != nil {
+		logrus.Errorf("ffmpeg voice note transcoding failed: %v, output: %s", err, string(output))
+		return note, pkgError.InternalServerError(fmt.Sprintf("failed to transcode audio to opus: %v", err))
+	}
+
+	// Decode the result to raw 16-bit PCM to measure it
+	var pcm, stderr bytes.Buffer
+	cmdDecode := exec.CommandContext(ctx, "ffmpeg", "-i", outputPath,
+		"-f", "s16le",
+		"-ac", "1",
+		"-ar", fmt.Sprint(voiceNoteAnalysisRate),
+		"-")
+	cmdDecode.Stdout = &pcm
+	cmdDecode.Stderr = &stderr
+	if err = cmdDecode.Run(); err != nil {
+		logrus.Errorf("ffmpeg voice note decoding failed: %v, output: %s", err, stderr.String())
+		return note, pkgError.InternalServerError(fmt.Sprintf("failed to analyze voice note: %v", err))
+	}
+
+	samples := make([]int16, pcm.Len()/2)
+	if err = binary.Read(&pcm, binary.LittleEndian, samples); err != nil {
+		return note, pkgError.InternalServerError(fmt.Sprintf("failed to analyze voice note: %v", err))
+	}
+
+	note.Data, err = os.ReadFile(outputPath)
+	if err != nil {
+		return note, pkgError.InternalServerError(fmt.Sprintf("failed to read voice note: %v", err))
+	}
+	note.Seconds = uint32(math.Ceil(float64(len(samples)) / voiceNoteAnalysisRate))
+	note.Waveform = computeWaveform(samples, voiceNoteWaveformSamples)
+	return note, nil
+}
+
+// computeWaveform splits the samples into equal buckets and scales each bucket's mean amplitude to 0-100,
+// relative to the loudest bucket, which is the range WhatsApp uses to draw voice note bars.
+func computeWaveform(samples []int16, buckets int) []byte {
+	waveform := make([]byte, buckets)
+	if len(samples) == 0 {
+		return waveform
+	}
+
+	levels := make([]float64, buckets)
+	peak := 0.0
+	for i := range levels {
+		start := i * len(samples) / buckets
+		end := (i + 1) * len(samples) / buckets
+		if end <= start {
+			continue
+		}
+		sum := 0.0
+		for _, sample := range samples[start:end] {
+			sum += math.Abs(float64(sample))
+		}
+		levels[i] = sum / float64(end-start)
+		peak = math.Max(peak, levels[i])
+	}
+	if peak == 0 {
+		return waveform
+	}
+
+	for i, level := range levels {
+		waveform[i] = byte(math.Round(level / peak * 100))
+	}
+	return waveform
+}
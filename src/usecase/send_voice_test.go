@@ -0,0 +1,41 @@
+package usecase
+
+import "testing"
+
+func TestComputeWaveformScalesToLoudestBucket(t *testing.T) {
+	samples := make([]int16, 0, 400)
+	for i := 0; i < 100; i++ {
+		samples = append(samples, 1000, -1000) // quiet first half
+	}
+	for i := 0; i < 100; i++ {
+		samples = append(samples, 4000, -4000) // loud second half
+	}
+
+	waveform := computeWaveform(samples, 4)
+	want := []byte{25, 25, 100, 100}
+	for i := range want {
+		if waveform[i] != want[i] {
+			t.Fatalf("waveform = %v, want %v", waveform, want)
+		}
+	}
+}
+
+func TestComputeWaveformSilence(t *testing.T) {
+	waveform := computeWaveform(make([]int16, 100), voiceNoteWaveformSamples)
+	if len(waveform) != voiceNoteWaveformSamples {
+		t.Fatalf("waveform length = %d, want %d", len(waveform), voiceNoteWaveformSamples)
+	}
+	for _, level := range waveform {
+		if level != 0 {
+			t.Fatalf("expected silent waveform, got %v", waveform)
+		}
+	}
+}
+
+func TestComputeWaveformShortInput(t *testing.T) {
+	// Fewer samples than buckets must not panic or divide by zero
+	waveform := computeWaveform([]int16{100, 200}, voiceNoteWaveformSamples)
+	if len(waveform) != voiceNoteWaveformSamples {
+		t.Fatalf("waveform length = %d, want %d", len(waveform), voiceNoteWaveformSamples)
+	}
+}
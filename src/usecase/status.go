@@ -0,0 +1,318 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/domains/app"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// statusLifetime is how long a status stays visible on WhatsApp
+const statusLifetime = 24 * time.Hour
+
+type serviceStatus struct {
+	// send shares the media pipeline and sent-message storage with regular sends
+	send            serviceSend
+	chatStorageRepo domainChatStorage.IChatStorageRepository
+}
+
+func NewStatusService(appService app.IAppUsecase, chatStorageRepo domainChatStorage.IChatStorageRepository, clientManager *whatsapp.ClientManager) domainStatus.IStatusUsecase {
+	return &serviceStatus{
+		send: serviceSend{
+			appService:      appService,
+			chatStorageRepo: chatStorageRepo,
+			clientManager:   clientManager,
+		},
+		chatStorageRepo: chatStorageRepo,
+	}
+}
+
+func (service serviceStatus) PostText(ctx context.Context, request domainStatus.TextRequest) (response domainStatus.GenericResponse, err error) {
+	if err = validations.ValidatePostStatusText(ctx, request); err != nil {
+		return response, err
+	}
+	client, err := service.prepareClient(ctx, request.BaseRequest)
+	if err != nil {
+		return response, err
+	}
+
+	textMessage := &waE2E.ExtendedTextMessage{
+		Text: proto.String(request.Text),
+	}
+	if request.BackgroundColor != "" {
+		argb, err := parseARGBColor(request.BackgroundColor)
+		if err != nil {
+			return response, pkgError.ValidationError(err.Error())
+		}
+		textMessage.BackgroundArgb = proto.Uint32(argb)
+	}
+	if request.Font != nil {
+		textMessage.Font = waE2E.ExtendedTextMessage_FontType(*request.Font).Enum()
+	}
+
+	return service.post(ctx, client, request.BaseRequest, &waE2E.Message{ExtendedTextMessage: textMessage}, request.Text)
+}
+
+func (service serviceStatus) PostImage(ctx context.Context, request domainStatus.ImageRequest) (response domainStatus.GenericResponse, err error) {
+	if err = validations.ValidatePostStatusImage(ctx, request); err != nil {
+		return response, err
+	}
+	client, err := service.prepareClient(ctx, request.BaseRequest)
+	if err != nil {
+		return response, err
+	}
+
+	dataWaImage, dataWaThumbnail, err := service.send.prepareImage(request.Image, request.ImageURL, request.Compress)
+	if err != nil {
+		return response, err
+	}
+	uploaded, err := client.Upload(ctx, dataWaImage, whatsmeow.MediaImage)
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to upload image: %v", err))
+	}
+
+	msg := &waE2E.Message{ImageMessage: &waE2E.ImageMessage{
+		JPEGThumbnail: dataWaThumbnail,
+		Caption:       proto.String(request.Caption),
+		URL:           proto.String(uploaded.URL),
+		DirectPath:    proto.String(uploaded.DirectPath),
+		MediaKey:      uploaded.MediaKey,
+		Mimetype:      proto.String(http.DetectContentType(dataWaImage)),
+		FileEncSHA256: uploaded.FileEncSHA256,
+		FileSHA256:    uploaded.FileSHA256,
+		FileLength:    proto.Uint64(uint64(len(dataWaImage))),
+	}}
+
+	content := "🖼️ Image"
+	if request.Caption != "" {
+		content = "🖼️ " + request.Caption
+	}
+	return service.post(ctx, client, request.BaseRequest, msg, content)
+}
+
+func (service serviceStatus) PostVideo(ctx context.Context, request domainStatus.VideoRequest) (response domainStatus.GenericResponse, err error) {
+	if err = validations.ValidatePostStatusVideo(ctx, request); err != nil {
+		return response, err
+	}
+	client, err := service.prepareClient(ctx, request.BaseRequest)
+	if err != nil {
+		return response, err
+	}
+
+	dataWaVideo, dataWaThumbnail, err := service.send.prepareVideo(ctx, request.Video, request.VideoURL, "", request.Compress)
+	if err != nil {
+		return response, err
+	}
+	uploaded, err := client.Upload(ctx, dataWaVideo, whatsmeow.MediaVideo)
+	if err != nil {
+		return response, pkgError.InternalServerError(fmt.Sprintf("Failed to upload video: %v", err))
+	}
+
+	msg := &waE2E.Message{VideoMessage: &waE2E.VideoMessage{
+		URL:           proto.String(uploaded.URL),
+		Mimetype:      proto.String(http.DetectContentType(dataWaVideo)),
+		Caption:       proto.String(request.Caption),
+		FileLength:    proto.Uint64(uploaded.FileLength),
+		FileSHA256:    uploaded.FileSHA256,
+		FileEncSHA256: uploaded.FileEncSHA256,
+		MediaKey:      uploaded.MediaKey,
+		DirectPath:    proto.String(uploaded.DirectPath),
+		JPEGThumbnail: dataWaThumbnail,
+	}}
+
+	content := "🎥 Video"
+	if request.Caption != "" {
+		content = "🎥 " + request.Caption
+	}
+	return service.post(ctx, client, request.BaseRequest, msg, content)
+}
+
+func (service serviceStatus) ListStatuses(_ context.Context, request domainStatus.ListRequest) (response domainStatus.ListResponse, err error) {
+	if request.Limit <= 0 {
+		request.Limit = 50
+	}
+	if request.Sender != "" {
+		utils.SanitizePhone(&request.Sender)
+	}
+
+	since := time.Now().Add(-statusLifetime)
+	isFromMe := false
+	messages, err := service.chatStorageRepo.ForAgent(request.AgentID).GetMessages(&domainChatStorage.MessageFilter{
+		ChatJID:   types.StatusBroadcastJID.String(),
+		StartTime: &since,
+		IsFromMe:  &isFromMe,
+		Sender:    request.Sender,
+		Limit:     request.Limit,
+		Offset:    request.Offset,
+	})
+	if err != nil {
+		return response, err
+	}
+
+	response.Data = make([]domainStatus.StatusItem, 0, len(messages))
+	for _, message := range messages {
+		response.Data = append(response.Data, domainStatus.StatusItem{
+			ID:        message.ID,
+			Sender:    message.Sender,
+			Content:   message.Content,
+			MediaType: message.MediaType,
+			Timestamp: message.Timestamp,
+		})
+	}
+	return response, nil
+}
+
+func (service serviceStatus) prepareClient(_ context.Context, request domainStatus.BaseRequest) (*whatsmeow.Client, error) {
+	client, err := service.send.resolveClient(request.AgentID)
+	if err != nil {
+		return nil, err
+	}
+	utils.MustLogin(client)
+	return client, nil
+}
+
+// statusAudienceMu keeps posts with an audience from switching the status privacy under each other
+var statusAudienceMu sync.Mutex
+
+func (service serviceStatus) post(ctx context.Context, client *whatsmeow.Client, request domainStatus.BaseRequest, msg *waE2E.Message, content string) (response domainStatus.GenericResponse, err error) {
+	if request.AudienceType != "" {
+		statusAudienceMu.Lock()
+		defer statusAudienceMu.Unlock()
+
+		restore, err := applyStatusAudience(ctx, client, request)
+		if err != nil {
+			return response, err
+		}
+		defer restore()
+	}
+
+	ts, err := service.send.wrapSendMessage(ctx, client, request.AgentID, types.StatusBroadcastJID, msg, content)
+	if err != nil {
+		return response, err
+	}
+
+	response.MessageID = ts.ID
+	response.Status = fmt.Sprintf("Status posted (server timestamp: %s)", ts.Timestamp.String())
+	return response, nil
+}
+
+// applyStatusAudience makes the account status privacy match the requested audience for one post. whatsmeow fans a
+// status out to the audience of the status privacy at send time and cannot override it per post, so the setting is
+// switched before sending and the returned function puts the previous one back.
+func applyStatusAudience(ctx context.Context, client *whatsmeow.Client, request domainStatus.BaseRequest) (restore func(), err error) {
+	options, err := client.GetStatusPrivacy(ctx)
+	if err != nil {
+		return nil, pkgError.InternalServerError(fmt.Sprintf("failed to get status privacy: %v", err))
+	}
+
+	want := types.StatusPrivacy{Type: statusAudienceTypes[request.AudienceType]}
+	if want.Type != types.StatusPrivacyTypeContacts {
+		for _, raw := range request.AudienceJIDs {
+			jid, err := utils.ParseJID(raw)
+			if err != nil {
+				return nil, err
+			}
+			want.List = append(want.List, jid)
+		}
+	}
+
+	previous := statusPrivacyToRestore(options, want)
+	if previous == nil {
+		return func() {}, nil
+	}
+	if err := whatsapp.SetStatusPrivacy(ctx, client, want); err != nil {
+		return nil, pkgError.InternalServerError(fmt.Sprintf("failed to set status audience: %v", err))
+	}
+	return func() {
+		for _, privacy := range previous {
+			if err := whatsapp.SetStatusPrivacy(context.Background(), client, privacy); err != nil {
+				logrus.Warnf("Failed to restore %s status privacy: %v", privacy.Type, err)
+			}
+		}
+	}, nil
+}
+
+var statusAudienceTypes = map[string]types.StatusPrivacyType{
+	domainStatus.AudienceContacts: types.StatusPrivacyTypeContacts,
+	domainStatus.AudienceAllow:    types.StatusPrivacyTypeWhitelist,
+	domainStatus.AudienceDeny:     types.StatusPrivacyTypeBlacklist,
+}
+
+// statusPrivacyToRestore returns the settings to put back, in order, after posting with the wanted audience, or nil
+// when the account already uses it. The stored list of the wanted type is overwritten too, so it is restored before
+// the previous default is selected again.
+func statusPrivacyToRestore(options []types.StatusPrivacy, want types.StatusPrivacy) []types.StatusPrivacy {
+	if len(options) == 0 {
+		options = whatsmeow.DefaultStatusPrivacy
+	}
+	current := options[0]
+	if current.Type == want.Type && sameJIDs(current.List, want.List) {
+		return nil
+	}
+
+	var previous []types.StatusPrivacy
+	for _, option := range options {
+		if option.Type == want.Type && option.Type != current.Type {
+			previous = append(previous, option)
+		}
+	}
+	return append(previous, current)
+}
+
+func sameJIDs(current, requested []types.JID) bool {
+	users := make([]string, len(requested))
+	for i, jid := range requested {
+		users[i] = jid.String()
+	}
+	return sameJIDUsers(current, users)
+}
+
+func sameJIDUsers(current []types.JID, requested []string) bool {
+	if len(current) != len(requested) {
+		return false
+	}
+	users := make(map[string]bool, len(current))
+	for _, jid := range current {
+		users[jid.User] = true
+	}
+	for _, raw := range requested {
+		jid, err := utils.ParseJID(raw)
+		if err != nil || !users[jid.User] {
+			return false
+		}
+	}
+	return true
+}
+
+// parseARGBColor converts #RRGGBB (opaque) or #AARRGGBB into the ARGB integer WhatsApp expects
+func parseARGBColor(color string) (uint32, error) {
+	hex := strings.TrimPrefix(color, "#")
+	switch len(hex) {
+	case 6:
+		hex = "FF" + hex
+	case 8:
+	default:
+		return 0, fmt.Errorf("invalid color %q, use #RRGGBB or #AARRGGBB", color)
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid color %q, use #RRGGBB or #AARRGGBB", color)
+	}
+	return uint32(value), nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestParseARGBColor(t *testing.T) {
+	tests := []struct {
+		color   string
+		want    uint32
+		wantErr bool
+	}{
+		{color: "#FF7F50", want: 0xFFFF7F50},
+		{color: "#80FF7F50", want: 0x80FF7F50},
+		{color: "#ff7f50", want: 0xFFFF7F50},
+		{color: "#FFF", wantErr: true},
+		{color: "#GGGGGG", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			got, err := parseARGBColor(tt.color)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseARGBColor(%q) error = %v, wantErr %v", tt.color, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("parseARGBColor(%q) = %#x, want %#x", tt.color, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameJIDUsers(t *testing.T) {
+	current := []types.JID{
+		types.NewJID("6289685028129", types.DefaultUserServer),
+		types.NewJID("6289685028130", types.DefaultUserServer),
+	}
+
+	if !sameJIDUsers(current, []string{"6289685028130@s.whatsapp.net", "6289685028129"}) {
+		t.Fatal("expected the same users in a different order to match")
+	}
+	if sameJIDUsers(current, []string{"6289685028129@s.whatsapp.net"}) {
+		t.Fatal("expected a shorter list not to match")
+	}
+	if sameJIDUsers(current, []string{"6289685028129@s.whatsapp.net", "6289685028131@s.whatsapp.net"}) {
+		t.Fatal("expected a different user not to match")
+	}
+}
+
+func TestStatusPrivacyToRestore(t *testing.T) {
+	alice := types.NewJID("6289685028129", types.DefaultUserServer)
+	bob := types.NewJID("6289685028130", types.DefaultUserServer)
+	contacts := types.StatusPrivacy{Type: types.StatusPrivacyTypeContacts, IsDefault: true}
+	storedAllow := types.StatusPrivacy{Type: types.StatusPrivacyTypeWhitelist, List: []types.JID{bob}}
+
+	// Already the account setting, nothing to switch
+	if got := statusPrivacyToRestore([]types.StatusPrivacy{contacts}, types.StatusPrivacy{Type: types.StatusPrivacyTypeContacts}); got != nil {
+		t.Fatalf("statusPrivacyToRestore() = %v, want nil for the current audience", got)
+	}
+
+	// Allowing other contacts overwrites the stored allow list, put it back before the default
+	got := statusPrivacyToRestore([]types.StatusPrivacy{contacts, storedAllow}, types.StatusPrivacy{Type: types.StatusPrivacyTypeWhitelist, List: []types.JID{alice}})
+	if len(got) != 2 || got[0].Type != types.StatusPrivacyTypeWhitelist || got[0].List[0] != bob || got[1].Type != types.StatusPrivacyTypeContacts {
+		t.Fatalf("statusPrivacyToRestore() = %v, want the stored allow list then contacts", got)
+	}
+
+	// A deny list on an account defaulting to contacts only needs the default back
+	got = statusPrivacyToRestore(nil, types.StatusPrivacy{Type: types.StatusPrivacyTypeBlacklist, List: []types.JID{alice}})
+	if len(got) != 1 || got[0].Type != types.StatusPrivacyTypeContacts {
+		t.Fatalf("statusPrivacyToRestore() = %v, want the contacts default", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"image"
 	"time"
 
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -21,10 +22,11 @@ import (
 
 type serviceUser struct {
 	// Remove the WaCli field - we'll use the global client instead
+	chatStorageRepo domainChatStorage.IChatStorageRepository
 }
 
-func NewUserService() domainUser.IUserUsecase {
-	return &serviceUser{}
+func NewUserService(chatStorageRepo domainChatStorage.IChatStorageRepository) domainUser.IUserUsecase {
+	return &serviceUser{chatStorageRepo: chatStorageRepo}
 }
 
 func (service serviceUser) Info(ctx context.Context, request domainUser.InfoRequest) (response domainUser.InfoResponse, err error) {
@@ -153,11 +155,7 @@ func (service serviceUser) MyPrivacySetting(ctx context.Context) (response domai
 		return
 	}
 
-	response.GroupAdd = string(resp.GroupAdd)
-	response.Status = string(resp.Status)
-	response.ReadReceipts = string(resp.ReadReceipts)
-	response.Profile = string(resp.Profile)
-	return response, nil
+	return toPrivacySettingResponse(*resp), nil
 }
 
 func (service serviceUser) MyListContacts(ctx context.Context) (response domainUser.MyListContactsResponse, err error) {
@@ -268,8 +266,11 @@ func (service serviceUser) BusinessProfile(ctx context.Context, request domainUs
 		return response, err
 	}
 
-	// Convert profile to response format
-	response.JID = dataWaRecipient.String()
+	return toBusinessProfileResponse(dataWaRecipient, profile), nil
+}
+
+func toBusinessProfileResponse(jid types.JID, profile *types.BusinessProfile) (response domainUser.BusinessProfileResponse) {
+	response.JID = jid.String()
 	response.Email = profile.Email
 	response.Address = profile.Address
 
@@ -301,5 +302,5 @@ func (service serviceUser) BusinessProfile(ctx context.Context, request domainUs
 		})
 	}
 
-	return response, nil
+	return response
 }
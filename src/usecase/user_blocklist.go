@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+
+	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func (service serviceUser) Blocklist(ctx context.Context) (response domainUser.BlocklistResponse, err error) {
+	client := whatsapp.GetClient()
+	utils.MustLogin(client)
+
+	blocklist, err := client.GetBlocklist(ctx)
+	if err != nil {
+		return response, err
+	}
+	whatsapp.StoreBlocklist(client, blocklist)
+
+	return toBlocklistResponse(blocklist), nil
+}
+
+func (service serviceUser) Block(ctx context.Context, request domainUser.BlockRequest) (response domainUser.BlocklistResponse, err error) {
+	return service.updateBlocklist(ctx, request, events.BlocklistChangeActionBlock)
+}
+
+func (service serviceUser) Unblock(ctx context.Context, request domainUser.BlockRequest) (response domainUser.BlocklistResponse, err error) {
+	return service.updateBlocklist(ctx, request, events.BlocklistChangeActionUnblock)
+}
+
+// updateBlocklist blocks or unblocks a user and returns the blocklist as WhatsApp reports it afterwards
+func (service serviceUser) updateBlocklist(ctx context.Context, request domainUser.BlockRequest, action events.BlocklistChangeAction) (response domainUser.BlocklistResponse, err error) {
+	if err = validations.ValidateBlockUser(ctx, request); err != nil {
+		return response, err
+	}
+	client := whatsapp.GetClient()
+	dataWaRecipient, err := utils.ValidateJidWithLogin(client, request.Phone)
+	if err != nil {
+		return response, err
+	}
+
+	blocklist, err := client.UpdateBlocklist(ctx, dataWaRecipient.ToNonAD(), action)
+	if err != nil {
+		return response, err
+	}
+	whatsapp.StoreBlocklist(client, blocklist)
+
+	return toBlocklistResponse(blocklist), nil
+}
+
+func toBlocklistResponse(blocklist *types.Blocklist) domainUser.BlocklistResponse {
+	response := domainUser.BlocklistResponse{JIDs: make([]string, 0)}
+	if blocklist == nil {
+		return response
+	}
+	for _, jid := range blocklist.JIDs {
+		response.JIDs = append(response.JIDs, jid.ToNonAD().String())
+	}
+	return response
+}
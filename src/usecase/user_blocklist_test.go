@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestToBlocklistResponse(t *testing.T) {
+	response := toBlocklistResponse(&types.Blocklist{JIDs: []types.JID{
+		types.NewADJID("6281234567890", 0, 2),
+		types.NewJID("123456789", types.HiddenUserServer),
+	}})
+	want := []string{"6281234567890@s.whatsapp.net", "123456789@lid"}
+	if !reflect.DeepEqual(response.JIDs, want) {
+		t.Errorf("toBlocklistResponse() = %v, want %v", response.JIDs, want)
+	}
+
+	if empty := toBlocklistResponse(nil); empty.JIDs == nil || len(empty.JIDs) != 0 {
+		t.Errorf("toBlocklistResponse(nil) = %v, want an empty list", empty.JIDs)
+	}
+}
+
+func TestRefuseBlockedRecipientSkipsUnblockableChats(t *testing.T) {
+	// Neither case may reach the client, which is nil here
+	ctx := context.Background()
+	previous := config.WhatsappRefuseBlocked
+	defer func() { config.WhatsappRefuseBlocked = previous }()
+
+	config.WhatsappRefuseBlocked = true
+	if err := refuseBlockedRecipient(ctx, nil, types.NewJID("120363000000000000", types.GroupServer)); err != nil {
+		t.Errorf("refuseBlockedRecipient() of a group = %v", err)
+	}
+
+	config.WhatsappRefuseBlocked = false
+	if err := refuseBlockedRecipient(ctx, nil, types.NewJID("6281234567890", types.DefaultUserServer)); err != nil {
+		t.Errorf("refuseBlockedRecipient() with the check disabled = %v", err)
+	}
+}
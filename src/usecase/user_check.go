@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"github.com/sirupsen/logrus"
+)
+
+// CheckBulk checks which of a list of phone numbers are on WhatsApp. Numbers checked recently are answered from
+// the cache; the rest are queried in chunks, so a failed chunk only fails its own numbers.
+func (service serviceUser) CheckBulk(ctx context.Context, request domainUser.BulkCheckRequest) (response domainUser.BulkCheckResponse, err error) {
+	if err = validations.ValidateBulkCheck(ctx, request); err != nil {
+		return response, err
+	}
+	client := whatsapp.GetClient()
+	utils.MustLogin(client)
+
+	inputs := request.Phones
+	if request.File != nil {
+		if inputs, err = readBulkCheckFile(request); err != nil {
+			return response, err
+		}
+	}
+	if len(inputs) > domainUser.MaxBulkCheckNumbers {
+		return response, pkgError.ValidationError(fmt.Sprintf("file: must not list more than %d numbers", domainUser.MaxBulkCheckNumbers))
+	}
+
+	results, phones := prepareBulkCheck(inputs)
+	numbers, err := whatsapp.CheckNumbers(ctx, client, service.chatStorageRepo, phones)
+	if err != nil {
+		logrus.WithError(err).Warn("Some numbers of a bulk check could not be checked")
+	}
+
+	return summarizeBulkCheck(results, numbers), nil
+}
+
+func readBulkCheckFile(request domainUser.BulkCheckRequest) ([]string, error) {
+	file, err := request.File.Open()
+	if err != nil {
+		return nil, pkgError.InternalServerError(fmt.Sprintf("failed to open uploaded file: %v", err))
+	}
+	defer file.Close()
+
+	rows, err := parsePhoneCSV(file)
+	if err != nil {
+		return nil, pkgError.ValidationError(fmt.Sprintf("file: %v", err))
+	}
+	if len(rows) == 0 {
+		return nil, pkgError.ValidationError("file: no phone numbers found")
+	}
+
+	inputs := make([]string, len(rows))
+	for i, row := range rows {
+		inputs[i] = row.Input
+	}
+	return inputs, nil
+}
+
+// prepareBulkCheck normalizes every input and returns the distinct numbers to check. Inputs that are not phone
+// numbers are marked invalid right away.
+func prepareBulkCheck(inputs []string) ([]domainUser.BulkCheckResult, []string) {
+	results := make([]domainUser.BulkCheckResult, len(inputs))
+	seen := make(map[string]bool, len(inputs))
+	var phones []string
+	for i, input := range inputs {
+		results[i].Input = input
+		phone, ok := normalizePhoneNumber(input)
+		if !ok {
+			results[i].Status = domainUser.CheckStatusInvalid
+			results[i].Message = "not a valid phone number"
+			continue
+		}
+		results[i].Phone = phone
+		if !seen[phone] {
+			seen[phone] = true
+			phones = append(phones, phone)
+		}
+	}
+	return results, phones
+}
+
+// summarizeBulkCheck fills in the outcome of every valid number. Numbers without a check were in a chunk that
+// failed and can be sent again.
+func summarizeBulkCheck(results []domainUser.BulkCheckResult, numbers map[string]*domainChatStorage.WhatsAppNumber) domainUser.BulkCheckResponse {
+	response := domainUser.BulkCheckResponse{Total: len(results), Results: results}
+	for i := range results {
+		result := &results[i]
+		if result.Status == domainUser.CheckStatusInvalid {
+			response.Invalid++
+			continue
+		}
+
+		number, ok := numbers[result.Phone]
+		switch {
+		case !ok:
+			result.Status = domainUser.CheckStatusFailed
+			result.Message = "could not check the number on WhatsApp"
+			response.Failed++
+		case number.IsRegistered:
+			result.Status = domainUser.CheckStatusRegistered
+			result.JID = number.JID
+			result.LID = number.LID
+			result.IsBusiness = number.IsBusiness
+			result.CheckedAt = &number.CheckedAt
+			response.Registered++
+		default:
+			result.Status = domainUser.CheckStatusNotRegistered
+			result.CheckedAt = &number.CheckedAt
+			response.NotRegistered++
+		}
+	}
+	return response
+}
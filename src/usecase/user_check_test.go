@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
+	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+)
+
+func TestPrepareBulkCheck(t *testing.T) {
+	results, phones := prepareBulkCheck([]string{"+62 812-3456-7890", "abc", "6281234567890", "447700900123"})
+
+	if len(phones) != 2 || phones[0] != "6281234567890" || phones[1] != "447700900123" {
+		t.Errorf("phones = %v, want each number once in input order", phones)
+	}
+	if results[1].Status != domainUser.CheckStatusInvalid {
+		t.Errorf("status of an invalid input = %q", results[1].Status)
+	}
+	if results[0].Phone != "6281234567890" || results[2].Phone != "6281234567890" {
+		t.Errorf("repeated numbers should both keep their result: %+v", results)
+	}
+}
+
+func TestSummarizeBulkCheck(t *testing.T) {
+	results, _ := prepareBulkCheck([]string{"6281234567890", "6289999999999", "447700900123", "x"})
+	checkedAt := time.Now()
+	response := summarizeBulkCheck(results, map[string]*domainChatStorage.WhatsAppNumber{
+		"6281234567890": {Phone: "6281234567890", IsRegistered: true, JID: "6281234567890@s.whatsapp.net", LID: "123@lid", IsBusiness: true, CheckedAt: checkedAt},
+		"6289999999999": {Phone: "6289999999999", CheckedAt: checkedAt},
+	})
+
+	if response.Total != 4 || response.Registered != 1 || response.NotRegistered != 1 || response.Failed != 1 || response.Invalid != 1 {
+		t.Errorf("counts = %+v", response)
+	}
+	registered := response.Results[0]
+	if registered.Status != domainUser.CheckStatusRegistered || registered.LID != "123@lid" || !registered.IsBusiness {
+		t.Errorf("registered result = %+v", registered)
+	}
+	if response.Results[2].Status != domainUser.CheckStatusFailed {
+		t.Errorf("number without a check = %+v, want failed", response.Results[2])
+	}
+}
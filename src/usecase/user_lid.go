@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// LIDMapping looks up the phone number of a LID or the LID of a phone number. The LID of a phone number is asked
+// from WhatsApp when it is not known yet; the phone number of a LID can only come from what this device has seen.
+func (service serviceUser) LIDMapping(ctx context.Context, request domainUser.LIDMappingRequest) (response domainUser.LIDMappingResponse, err error) {
+	if err = validations.ValidateLIDMapping(ctx, request); err != nil {
+		return response, err
+	}
+	client := whatsapp.GetClient()
+	utils.MustLogin(client)
+
+	jid, err := utils.ParseJID(request.JID)
+	if err != nil {
+		return response, pkgError.ValidationError(err.Error())
+	}
+
+	pair := whatsapp.ResolveJID(ctx, client, jid)
+	if pair.LID.IsEmpty() && !pair.PN.IsEmpty() {
+		info, err := client.GetUserInfo(ctx, []types.JID{pair.PN})
+		if err != nil {
+			return response, err
+		}
+		pair.LID = info[pair.PN].LID.ToNonAD()
+	}
+	if pair.PN.IsEmpty() || pair.LID.IsEmpty() {
+		return response, pkgError.NotFoundError(fmt.Sprintf("no LID mapping known for %s", jid.ToNonAD()))
+	}
+
+	return toLIDMappingResponse(pair), nil
+}
+
+func toLIDMappingResponse(pair utils.JIDPair) domainUser.LIDMappingResponse {
+	return domainUser.LIDMappingResponse{
+		PN:  pair.PN.String(),
+		LID: pair.LID.String(),
+	}
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestToLIDMappingResponse(t *testing.T) {
+	response := toLIDMappingResponse(utils.JIDPair{
+		PN:  types.NewJID("6281234567890", types.DefaultUserServer),
+		LID: types.NewJID("123456789012345", types.HiddenUserServer),
+	})
+	if response.PN != "6281234567890@s.whatsapp.net" || response.LID != "123456789012345@lid" {
+		t.Errorf("toLIDMappingResponse() = %+v, want both forms as JIDs", response)
+	}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"go.mau.fi/whatsmeow/types"
+)
+
+type privacyChange struct {
+	setting types.PrivacySettingType
+	value   types.PrivacySetting
+}
+
+// SetPrivacySetting applies the given privacy settings one by one and returns all settings as they are afterwards.
+// WhatsApp has no call to change several at once, so a failure leaves the settings before it applied.
+func (service serviceUser) SetPrivacySetting(ctx context.Context, request domainUser.SetPrivacySettingRequest) (response domainUser.MyPrivacySettingResponse, err error) {
+	if err = validations.ValidateSetPrivacySetting(ctx, request); err != nil {
+		return response, err
+	}
+	client := whatsapp.GetClient()
+	utils.MustLogin(client)
+
+	settings, err := client.TryFetchPrivacySettings(ctx, false)
+	if err != nil {
+		return response, err
+	}
+	current := *settings
+
+	for _, change := range privacyChanges(request) {
+		if current, err = client.SetPrivacySetting(ctx, change.setting, change.value); err != nil {
+			return response, fmt.Errorf("failed to set %s privacy to %s: %w", change.setting, change.value, err)
+		}
+	}
+
+	if request.DefaultDisappearingSeconds != nil {
+		timer := time.Duration(*request.DefaultDisappearingSeconds) * time.Second
+		if err = client.SetDefaultDisappearingTimer(ctx, timer); err != nil {
+			return response, fmt.Errorf("failed to set default disappearing timer: %w", err)
+		}
+	}
+
+	response = toPrivacySettingResponse(current)
+	response.DefaultDisappearingSeconds = request.DefaultDisappearingSeconds
+	return response, nil
+}
+
+// privacyChanges lists the settings a request changes, always in the same order
+func privacyChanges(request domainUser.SetPrivacySettingRequest) []privacyChange {
+	var changes []privacyChange
+	for _, field := range []struct {
+		setting types.PrivacySettingType
+		value   *string
+	}{
+		{types.PrivacySettingTypeGroupAdd, request.GroupAdd},
+		{types.PrivacySettingTypeLastSeen, request.LastSeen},
+		{types.PrivacySettingTypeStatus, request.Status},
+		{types.PrivacySettingTypeProfile, request.Profile},
+		{types.PrivacySettingTypeReadReceipts, request.ReadReceipts},
+		{types.PrivacySettingTypeOnline, request.Online},
+		{types.PrivacySettingTypeCallAdd, request.CallAdd},
+	} {
+		if field.value != nil {
+			changes = append(changes, privacyChange{setting: field.setting, value: types.PrivacySetting(*field.value)})
+		}
+	}
+	return changes
+}
+
+func toPrivacySettingResponse(settings types.PrivacySettings) domainUser.MyPrivacySettingResponse {
+	return domainUser.MyPrivacySettingResponse{
+		GroupAdd:     string(settings.GroupAdd),
+		LastSeen:     string(settings.LastSeen),
+		Status:       string(settings.Status),
+		Profile:      string(settings.Profile),
+		ReadReceipts: string(settings.ReadReceipts),
+		Online:       string(settings.Online),
+		CallAdd:      string(settings.CallAdd),
+	}
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestPrivacyChanges(t *testing.T) {
+	contacts := domainUser.PrivacyContacts
+	known := domainUser.PrivacyKnown
+
+	got := privacyChanges(domainUser.SetPrivacySettingRequest{CallAdd: &known, LastSeen: &contacts})
+	want := []privacyChange{
+		{setting: types.PrivacySettingTypeLastSeen, value: types.PrivacySettingContacts},
+		{setting: types.PrivacySettingTypeCallAdd, value: types.PrivacySettingKnown},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("privacyChanges() = %v, want %v", got, want)
+	}
+
+	if got := privacyChanges(domainUser.SetPrivacySettingRequest{}); len(got) != 0 {
+		t.Errorf("privacyChanges() of an empty request = %v", got)
+	}
+}
+
+func TestToPrivacySettingResponse(t *testing.T) {
+	response := toPrivacySettingResponse(types.PrivacySettings{
+		LastSeen: types.PrivacySettingContacts,
+		Online:   types.PrivacySettingMatchLastSeen,
+		CallAdd:  types.PrivacySettingKnown,
+	})
+
+	if response.LastSeen != "contacts" || response.Online != "match_last_seen" || response.CallAdd != "known" {
+		t.Errorf("response = %+v", response)
+	}
+	if response.DefaultDisappearingSeconds != nil {
+		t.Errorf("default disappearing = %v, want unknown", *response.DefaultDisappearingSeconds)
+	}
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/infrastructure/whatsapp"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/validations"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// ChangeAbout sets the about text shown on our profile
+func (service serviceUser) ChangeAbout(ctx context.Context, request domainUser.ChangeAboutRequest) (err error) {
+	request.About = strings.TrimSpace(request.About)
+	if err = validations.ValidateChangeAbout(ctx, request); err != nil {
+		return err
+	}
+	client := whatsapp.GetClient()
+	utils.MustLogin(client)
+
+	return client.SetStatusMessage(ctx, request.About)
+}
+
+// UpdateBusinessProfile changes the business profile of our own account and returns it as WhatsApp reports it
+// afterwards. Only business accounts have a business profile; WhatsApp rejects the change for others.
+func (service serviceUser) UpdateBusinessProfile(ctx context.Context, request domainUser.UpdateBusinessProfileRequest) (response domainUser.BusinessProfileResponse, err error) {
+	if err = validations.ValidateUpdateBusinessProfile(ctx, request); err != nil {
+		return response, err
+	}
+	client := whatsapp.GetClient()
+	utils.MustLogin(client)
+
+	update, err := toBusinessProfileUpdate(request)
+	if err != nil {
+		return response, err
+	}
+	if err = whatsapp.UpdateBusinessProfile(ctx, client, update); err != nil {
+		return response, err
+	}
+
+	ownJID := client.Store.ID.ToNonAD()
+	profile, err := client.GetBusinessProfile(ctx, ownJID)
+	if err != nil {
+		return response, err
+	}
+	return toBusinessProfileResponse(ownJID, profile), nil
+}
+
+// toBusinessProfileUpdate converts business hours from the HH:MM form the profile is read in back to the form
+// WhatsApp stores, so a profile read from one account can be written to another as it is
+func toBusinessProfileUpdate(request domainUser.UpdateBusinessProfileRequest) (whatsapp.BusinessProfileUpdate, error) {
+	update := whatsapp.BusinessProfileUpdate{
+		Description:           request.Description,
+		Address:               request.Address,
+		Email:                 request.Email,
+		Websites:              request.Websites,
+		CategoryIDs:           request.CategoryIDs,
+		BusinessHoursTimeZone: request.BusinessHoursTimeZone,
+	}
+	if request.BusinessHours == nil {
+		return update, nil
+	}
+
+	update.BusinessHours = make([]types.BusinessHoursConfig, 0, len(request.BusinessHours))
+	for _, hours := range request.BusinessHours {
+		config := types.BusinessHoursConfig{DayOfWeek: hours.DayOfWeek, Mode: hours.Mode}
+		if hours.Mode == domainUser.BusinessHoursSpecificHours {
+			var err error
+			if config.OpenTime, err = utils.ParseBusinessHourTime(hours.OpenTime); err != nil {
+				return update, pkgError.ValidationError(err.Error())
+			}
+			if config.CloseTime, err = utils.ParseBusinessHourTime(hours.CloseTime); err != nil {
+				return update, pkgError.ValidationError(err.Error())
+			}
+		}
+		update.BusinessHours = append(update.BusinessHours, config)
+	}
+	return update, nil
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
+	"go.mau.fi/whatsmeow/types"
+)
+
+func TestToBusinessProfileUpdate(t *testing.T) {
+	update, err := toBusinessProfileUpdate(domainUser.UpdateBusinessProfileRequest{
+		BusinessHoursTimeZone: "Asia/Jakarta",
+		BusinessHours: []domainUser.BusinessProfileHoursConfig{
+			{DayOfWeek: "mon", Mode: domainUser.BusinessHoursSpecificHours, OpenTime: "09:00", CloseTime: "17:30"},
+			{DayOfWeek: "sat", Mode: domainUser.BusinessHoursAppointmentOnly},
+		},
+	})
+	if err != nil {
+		t.Fatalf("toBusinessProfileUpdate() error = %v", err)
+	}
+	want := []types.BusinessHoursConfig{
+		{DayOfWeek: "mon", Mode: "specific_hours", OpenTime: "900", CloseTime: "1730"},
+		{DayOfWeek: "sat", Mode: "appointment_only"},
+	}
+	if !reflect.DeepEqual(update.BusinessHours, want) {
+		t.Errorf("BusinessHours = %v, want %v", update.BusinessHours, want)
+	}
+
+	// Business hours that are left out stay as they are
+	if update, _ := toBusinessProfileUpdate(domainUser.UpdateBusinessProfileRequest{}); update.BusinessHours != nil {
+		t.Errorf("BusinessHours = %v, want nil", update.BusinessHours)
+	}
+}
+
+func TestToBusinessProfileResponseRoundTrip(t *testing.T) {
+	hours := []domainUser.BusinessProfileHoursConfig{
+		{DayOfWeek: "fri", Mode: domainUser.BusinessHoursSpecificHours, OpenTime: "08:15", CloseTime: "22:00"},
+	}
+	update, err := toBusinessProfileUpdate(domainUser.UpdateBusinessProfileRequest{BusinessHoursTimeZone: "UTC", BusinessHours: hours})
+	if err != nil {
+		t.Fatalf("toBusinessProfileUpdate() error = %v", err)
+	}
+
+	response := toBusinessProfileResponse(types.NewJID("6281234567890", types.DefaultUserServer), &types.BusinessProfile{
+		BusinessHoursTimeZone: update.BusinessHoursTimeZone,
+		BusinessHours:         update.BusinessHours,
+	})
+	if !reflect.DeepEqual(response.BusinessHours, hours) {
+		t.Errorf("business hours read back as %v, want %v", response.BusinessHours, hours)
+	}
+}
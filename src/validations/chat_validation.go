@@ -2,8 +2,10 @@ package validations
 
 import (
 	"context"
+	"time"
 
 	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
+	domainChatStorage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chatstorage"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -17,6 +19,7 @@ func ValidateListChats(ctx context.Context, request *domainChat.ListChatsRequest
 	err := validation.ValidateStructWithContext(ctx, request,
 		validation.Field(&request.Limit, validation.Min(1), validation.Max(100)),
 		validation.Field(&request.Offset, validation.Min(0)),
+		validation.Field(&request.SortBy, validation.In(domainChatStorage.ChatSortLastMessage, domainChatStorage.ChatSortUnread)),
 	)
 
 	if err != nil {
@@ -56,3 +59,106 @@ func ValidatePinChat(ctx context.Context, request *domainChat.PinChatRequest) er
 
 	return nil
 }
+
+func ValidateArchiveChat(ctx context.Context, request *domainChat.ArchiveChatRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ChatJID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateMuteChat(ctx context.Context, request *domainChat.MuteChatRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ChatJID, validation.Required),
+		validation.Field(&request.MuteUntil, validation.Date(time.RFC3339).Error("must be an RFC3339 timestamp")),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Muted && request.MuteUntil != "" {
+		muteUntil, _ := time.Parse(time.RFC3339, request.MuteUntil)
+		if !muteUntil.After(time.Now()) {
+			return pkgError.ValidationError("mute_until: must be in the future.")
+		}
+	}
+
+	return nil
+}
+
+func ValidateMarkChatAsRead(ctx context.Context, request *domainChat.MarkChatAsReadRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ChatJID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateClearChat(ctx context.Context, request *domainChat.ClearChatRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ChatJID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateDeleteChat(ctx context.Context, request *domainChat.DeleteChatRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ChatJID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+var disappearingTimers = []any{
+	domainChat.DisappearingTimerOff,
+	domainChat.DisappearingTimer24Hours,
+	domainChat.DisappearingTimer7Days,
+	domainChat.DisappearingTimer90Days,
+}
+
+// disappearingTimerRule accepts the timers WhatsApp offers, wherever a disappearing timer is set
+var disappearingTimerRule = validation.In(disappearingTimers...).Error("must be one of 0, 86400, 604800 or 7776000")
+
+func ValidateSetDisappearingTimer(ctx context.Context, request *domainChat.SetDisappearingTimerRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.ChatJID, validation.Required),
+		validation.Field(&request.TimerSeconds, disappearingTimerRule),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateSetDefaultDisappearingTimer(ctx context.Context, request *domainChat.SetDefaultDisappearingTimerRequest) error {
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.TimerSeconds, disappearingTimerRule),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
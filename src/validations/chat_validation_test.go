@@ -3,6 +3,7 @@ package validations
 import (
 	"context"
 	"testing"
+	"time"
 
 	domainChat "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/chat"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -58,6 +59,22 @@ func TestValidateListChats(t *testing.T) {
 			}},
 			err: pkgError.ValidationError("offset: must be no less than 0."),
 		},
+		{
+			name: "should success sorting by unread",
+			args: args{request: domainChat.ListChatsRequest{
+				Limit:  25,
+				SortBy: "unread",
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with unknown sort_by",
+			args: args{request: domainChat.ListChatsRequest{
+				Limit:  25,
+				SortBy: "name",
+			}},
+			err: pkgError.ValidationError("sort_by: must be a valid value."),
+		},
 	}
 
 	for _, tt := range tests {
@@ -183,3 +200,155 @@ func TestValidatePinChat(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSetDisappearingTimer(t *testing.T) {
+	type args struct {
+		request domainChat.SetDisappearingTimerRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with 90 day timer",
+			args: args{request: domainChat.SetDisappearingTimerRequest{
+				ChatJID:      "6289685028129@s.whatsapp.net",
+				TimerSeconds: domainChat.DisappearingTimer90Days,
+			}},
+			err: nil,
+		},
+		{
+			name: "should success clearing the timer",
+			args: args{request: domainChat.SetDisappearingTimerRequest{
+				ChatJID:      "120363024512399999@g.us",
+				TimerSeconds: domainChat.DisappearingTimerOff,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with unsupported timer",
+			args: args{request: domainChat.SetDisappearingTimerRequest{
+				ChatJID:      "6289685028129@s.whatsapp.net",
+				TimerSeconds: 3600,
+			}},
+			err: pkgError.ValidationError("timer_seconds: must be one of 0, 86400, 604800 or 7776000."),
+		},
+		{
+			name: "should error with empty chat_jid",
+			args: args{request: domainChat.SetDisappearingTimerRequest{
+				TimerSeconds: domainChat.DisappearingTimer7Days,
+			}},
+			err: pkgError.ValidationError("chat_jid: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetDisappearingTimer(context.Background(), &tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSetDefaultDisappearingTimer(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainChat.SetDefaultDisappearingTimerRequest
+		err     any
+	}{
+		{
+			name:    "should success with 24 hour timer",
+			request: domainChat.SetDefaultDisappearingTimerRequest{TimerSeconds: domainChat.DisappearingTimer24Hours},
+			err:     nil,
+		},
+		{
+			name:    "should success turning the default off",
+			request: domainChat.SetDefaultDisappearingTimerRequest{TimerSeconds: domainChat.DisappearingTimerOff},
+			err:     nil,
+		},
+		{
+			name:    "should error with unsupported timer",
+			request: domainChat.SetDefaultDisappearingTimerRequest{TimerSeconds: 60},
+			err:     pkgError.ValidationError("timer_seconds: must be one of 0, 86400, 604800 or 7776000."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetDefaultDisappearingTimer(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateArchiveChat(t *testing.T) {
+	err := ValidateArchiveChat(context.Background(), &domainChat.ArchiveChatRequest{ChatJID: "6289685028129@s.whatsapp.net", Archived: true})
+	assert.Nil(t, err)
+
+	err = ValidateArchiveChat(context.Background(), &domainChat.ArchiveChatRequest{Archived: true})
+	assert.Equal(t, pkgError.ValidationError("chat_jid: cannot be blank."), err)
+}
+
+func TestValidateMuteChat(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainChat.MuteChatRequest
+		err     any
+	}{
+		{
+			name:    "should success muting indefinitely",
+			request: domainChat.MuteChatRequest{ChatJID: "6289685028129@s.whatsapp.net", Muted: true},
+			err:     nil,
+		},
+		{
+			name: "should success muting until a future time",
+			request: domainChat.MuteChatRequest{
+				ChatJID:   "6289685028129@s.whatsapp.net",
+				Muted:     true,
+				MuteUntil: time.Now().Add(8 * time.Hour).Format(time.RFC3339),
+			},
+			err: nil,
+		},
+		{
+			name:    "should success unmuting",
+			request: domainChat.MuteChatRequest{ChatJID: "6289685028129@s.whatsapp.net", Muted: false},
+			err:     nil,
+		},
+		{
+			name:    "should error with invalid mute_until",
+			request: domainChat.MuteChatRequest{ChatJID: "6289685028129@s.whatsapp.net", Muted: true, MuteUntil: "tomorrow"},
+			err:     pkgError.ValidationError("mute_until: must be an RFC3339 timestamp."),
+		},
+		{
+			name:    "should error with mute_until in the past",
+			request: domainChat.MuteChatRequest{ChatJID: "6289685028129@s.whatsapp.net", Muted: true, MuteUntil: "2020-01-01T00:00:00Z"},
+			err:     pkgError.ValidationError("mute_until: must be in the future."),
+		},
+		{
+			name:    "should error with empty chat_jid",
+			request: domainChat.MuteChatRequest{Muted: true},
+			err:     pkgError.ValidationError("chat_jid: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMuteChat(context.Background(), &tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateChatJIDRequired(t *testing.T) {
+	blank := pkgError.ValidationError("chat_jid: cannot be blank.")
+
+	assert.Nil(t, ValidateMarkChatAsRead(context.Background(), &domainChat.MarkChatAsReadRequest{ChatJID: "6289685028129@s.whatsapp.net"}))
+	assert.Equal(t, blank, ValidateMarkChatAsRead(context.Background(), &domainChat.MarkChatAsReadRequest{Read: true}))
+
+	assert.Nil(t, ValidateClearChat(context.Background(), &domainChat.ClearChatRequest{ChatJID: "120363024512399999@g.us"}))
+	assert.Equal(t, blank, ValidateClearChat(context.Background(), &domainChat.ClearChatRequest{}))
+
+	assert.Nil(t, ValidateDeleteChat(context.Background(), &domainChat.DeleteChatRequest{ChatJID: "120363024512399999@g.us"}))
+	assert.Equal(t, blank, ValidateDeleteChat(context.Background(), &domainChat.DeleteChatRequest{}))
+}
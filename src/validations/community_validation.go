@@ -0,0 +1,59 @@
+package validations
+
+import (
+	"context"
+
+	domainCommunity "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/community"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func ValidateCreateCommunity(ctx context.Context, request domainCommunity.CreateCommunityRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Name, validation.Required, validation.RuneLength(1, 100)),
+		validation.Field(&request.Description, validation.RuneLength(0, 2048)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateCommunityInfo(ctx context.Context, request domainCommunity.CommunityInfoRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateGetSubGroups(ctx context.Context, request domainCommunity.GetSubGroupsRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateLinkGroups(ctx context.Context, request domainCommunity.LinkGroupsRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.CommunityID, validation.Required),
+		validation.Field(&request.GroupIDs, validation.Required, validation.Each(validation.Required)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package validations
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	domainCommunity "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/community"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCreateCommunity(t *testing.T) {
+	type args struct {
+		request domainCommunity.CreateCommunityRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with name and description",
+			args: args{request: domainCommunity.CreateCommunityRequest{
+				Name:        "Greenfield School",
+				Description: "All classes of Greenfield School",
+			}},
+			err: nil,
+		},
+		{
+			name: "should success without description",
+			args: args{request: domainCommunity.CreateCommunityRequest{Name: "Greenfield School"}},
+			err:  nil,
+		},
+		{
+			name: "should error with empty name",
+			args: args{request: domainCommunity.CreateCommunityRequest{Name: ""}},
+			err:  pkgError.ValidationError("name: cannot be blank."),
+		},
+		{
+			name: "should error with too long name",
+			args: args{request: domainCommunity.CreateCommunityRequest{Name: strings.Repeat("a", 101)}},
+			err:  pkgError.ValidationError("name: the length must be between 1 and 100."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateCommunity(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateLinkGroups(t *testing.T) {
+	type args struct {
+		request domainCommunity.LinkGroupsRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with community and groups",
+			args: args{request: domainCommunity.LinkGroupsRequest{
+				CommunityID: "120363025246125486@g.us",
+				GroupIDs:    []string{"120363025246125487@g.us", "120363025246125488"},
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty community id",
+			args: args{request: domainCommunity.LinkGroupsRequest{
+				GroupIDs: []string{"120363025246125487@g.us"},
+			}},
+			err: pkgError.ValidationError("community_id: cannot be blank."),
+		},
+		{
+			name: "should error without groups",
+			args: args{request: domainCommunity.LinkGroupsRequest{
+				CommunityID: "120363025246125486@g.us",
+			}},
+			err: pkgError.ValidationError("group_ids: cannot be blank."),
+		},
+		{
+			name: "should error with empty group id",
+			args: args{request: domainCommunity.LinkGroupsRequest{
+				CommunityID: "120363025246125486@g.us",
+				GroupIDs:    []string{"120363025246125487@g.us", ""},
+			}},
+			err: pkgError.ValidationError("group_ids: (1: cannot be blank.)."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLinkGroups(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateCommunityInfo(t *testing.T) {
+	assert.NoError(t, ValidateCommunityInfo(context.Background(), domainCommunity.CommunityInfoRequest{CommunityID: "120363025246125486@g.us"}))
+	assert.Equal(t, pkgError.ValidationError("community_id: cannot be blank."),
+		ValidateCommunityInfo(context.Background(), domainCommunity.CommunityInfoRequest{}))
+}
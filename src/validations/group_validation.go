@@ -7,6 +7,7 @@ import (
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
 )
 
 func ValidateJoinGroupWithLink(ctx context.Context, request domainGroup.JoinGroupWithLinkRequest) error {
@@ -112,6 +113,27 @@ func ValidateManageGroupRequestParticipants(ctx context.Context, request domainG
 	return nil
 }
 
+func ValidateImportParticipants(ctx context.Context, request domainGroup.ImportParticipantsRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.File, validation.Required),
+		validation.Field(&request.InviteMessage, validation.RuneLength(0, 1024)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.File.Size > maxParticipantImportSize {
+		return pkgError.ValidationError("file: must not be larger than 1MB")
+	}
+
+	return nil
+}
+
+// maxParticipantImportSize is far above what the participant limit of a group can fill with phone numbers
+const maxParticipantImportSize = 1 << 20
+
 func ValidateSetGroupPhoto(ctx context.Context, request domainGroup.SetGroupPhotoRequest) error {
 	err := validation.ValidateStructWithContext(ctx, &request,
 		validation.Field(&request.GroupID, validation.Required),
@@ -192,6 +214,46 @@ func ValidateSetGroupAnnounce(ctx context.Context, request domainGroup.SetGroupA
 	return nil
 }
 
+func ValidateSetGroupMemberAddMode(ctx context.Context, request domainGroup.SetGroupMemberAddModeRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.Mode, validation.Required,
+			validation.In(types.GroupMemberAddModeAdmin, types.GroupMemberAddModeAllMember).Error("must be admin_add or all_member_add")),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateSetGroupJoinApproval(ctx context.Context, request domainGroup.SetGroupJoinApprovalRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		// JoinApproval is a boolean, no additional validation needed
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateSetGroupDisappearingTimer(ctx context.Context, request domainGroup.SetGroupDisappearingTimerRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.TimerSeconds, disappearingTimerRule),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
 func ValidateSetGroupTopic(ctx context.Context, request domainGroup.SetGroupTopicRequest) error {
 	err := validation.ValidateStructWithContext(ctx, &request,
 		validation.Field(&request.GroupID, validation.Required),
@@ -2,12 +2,15 @@ package validations
 
 import (
 	"context"
+	"mime/multipart"
+	"strings"
 	"testing"
 
 	domainGroup "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/group"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/stretchr/testify/assert"
 	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
 )
 
 func TestValidateJoinGroupWithLink(t *testing.T) {
@@ -674,6 +677,155 @@ func TestValidateSetGroupTopic(t *testing.T) {
 	}
 }
 
+func TestValidateSetGroupMemberAddMode(t *testing.T) {
+	type args struct {
+		request domainGroup.SetGroupMemberAddModeRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with admin add mode",
+			args: args{request: domainGroup.SetGroupMemberAddModeRequest{
+				GroupID: "123456789@g.us",
+				Mode:    types.GroupMemberAddModeAdmin,
+			}},
+			err: nil,
+		},
+		{
+			name: "should success with all member add mode",
+			args: args{request: domainGroup.SetGroupMemberAddModeRequest{
+				GroupID: "123456789@g.us",
+				Mode:    types.GroupMemberAddModeAllMember,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with unknown mode",
+			args: args{request: domainGroup.SetGroupMemberAddModeRequest{
+				GroupID: "123456789@g.us",
+				Mode:    "everyone",
+			}},
+			err: pkgError.ValidationError("mode: must be admin_add or all_member_add."),
+		},
+		{
+			name: "should error with empty mode",
+			args: args{request: domainGroup.SetGroupMemberAddModeRequest{
+				GroupID: "123456789@g.us",
+			}},
+			err: pkgError.ValidationError("mode: cannot be blank."),
+		},
+		{
+			name: "should error with empty group id",
+			args: args{request: domainGroup.SetGroupMemberAddModeRequest{
+				Mode: types.GroupMemberAddModeAdmin,
+			}},
+			err: pkgError.ValidationError("group_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetGroupMemberAddMode(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSetGroupJoinApproval(t *testing.T) {
+	type args struct {
+		request domainGroup.SetGroupJoinApprovalRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success enabling join approval",
+			args: args{request: domainGroup.SetGroupJoinApprovalRequest{
+				GroupID:      "123456789@g.us",
+				JoinApproval: true,
+			}},
+			err: nil,
+		},
+		{
+			name: "should success disabling join approval",
+			args: args{request: domainGroup.SetGroupJoinApprovalRequest{
+				GroupID:      "123456789@g.us",
+				JoinApproval: false,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty group id",
+			args: args{request: domainGroup.SetGroupJoinApprovalRequest{
+				JoinApproval: true,
+			}},
+			err: pkgError.ValidationError("group_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetGroupJoinApproval(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSetGroupDisappearingTimer(t *testing.T) {
+	type args struct {
+		request domainGroup.SetGroupDisappearingTimerRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success turning the timer off",
+			args: args{request: domainGroup.SetGroupDisappearingTimerRequest{
+				GroupID:      "123456789@g.us",
+				TimerSeconds: 0,
+			}},
+			err: nil,
+		},
+		{
+			name: "should success with 7 days",
+			args: args{request: domainGroup.SetGroupDisappearingTimerRequest{
+				GroupID:      "123456789@g.us",
+				TimerSeconds: 604800,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with unsupported timer",
+			args: args{request: domainGroup.SetGroupDisappearingTimerRequest{
+				GroupID:      "123456789@g.us",
+				TimerSeconds: 3600,
+			}},
+			err: pkgError.ValidationError("timer_seconds: must be one of 0, 86400, 604800 or 7776000."),
+		},
+		{
+			name: "should error with empty group id",
+			args: args{request: domainGroup.SetGroupDisappearingTimerRequest{
+				TimerSeconds: 86400,
+			}},
+			err: pkgError.ValidationError("group_id: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetGroupDisappearingTimer(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
 func TestValidateGroupInfo(t *testing.T) {
 	type args struct {
 		request domainGroup.GroupInfoRequest
@@ -706,3 +858,61 @@ func TestValidateGroupInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateImportParticipants(t *testing.T) {
+	type args struct {
+		request domainGroup.ImportParticipantsRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with group id and file",
+			args: args{request: domainGroup.ImportParticipantsRequest{
+				GroupID: "123456789@g.us",
+				File:    &multipart.FileHeader{Filename: "numbers.csv", Size: 2048},
+			}},
+			err: nil,
+		},
+		{
+			name: "should error without file",
+			args: args{request: domainGroup.ImportParticipantsRequest{
+				GroupID: "123456789@g.us",
+			}},
+			err: pkgError.ValidationError("file: cannot be blank."),
+		},
+		{
+			name: "should error with empty group id",
+			args: args{request: domainGroup.ImportParticipantsRequest{
+				File: &multipart.FileHeader{Filename: "numbers.csv", Size: 2048},
+			}},
+			err: pkgError.ValidationError("group_id: cannot be blank."),
+		},
+		{
+			name: "should error with too large file",
+			args: args{request: domainGroup.ImportParticipantsRequest{
+				GroupID: "123456789@g.us",
+				File:    &multipart.FileHeader{Filename: "numbers.csv", Size: 2 << 20},
+			}},
+			err: pkgError.ValidationError("file: must not be larger than 1MB"),
+		},
+		{
+			name: "should error with too long invite message",
+			args: args{request: domainGroup.ImportParticipantsRequest{
+				GroupID:       "123456789@g.us",
+				File:          &multipart.FileHeader{Filename: "numbers.csv", Size: 2048},
+				InviteMessage: strings.Repeat("a", 1025),
+			}},
+			err: pkgError.ValidationError("invite_message: the length must be no more than 1024."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateImportParticipants(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
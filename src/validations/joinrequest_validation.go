@@ -0,0 +1,38 @@
+package validations
+
+import (
+	"context"
+
+	domainJoinRequest "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/joinrequest"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+func ValidateSetJoinRequestPolicy(ctx context.Context, request domainJoinRequest.SetPolicyRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.AllowedPrefixes, validation.Length(0, 300), validation.Each(validation.Required, is.Digit, validation.Length(1, 6))),
+		validation.Field(&request.HookURL, validation.By(publicHTTPURL)),
+		validation.Field(&request.FailAction, validation.In(domainJoinRequest.DecisionReject, domainJoinRequest.DecisionIgnore).
+			Error("must be reject or ignore")),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateJoinRequestGroup(ctx context.Context, request domainJoinRequest.GroupRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package validations
+
+import (
+	"context"
+	"testing"
+
+	domainJoinRequest "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/joinrequest"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSetJoinRequestPolicy(t *testing.T) {
+	type args struct {
+		request domainJoinRequest.SetPolicyRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with valid policy",
+			args: args{request: domainJoinRequest.SetPolicyRequest{
+				GroupID:         "120363025246125486@g.us",
+				Enabled:         true,
+				AllowedPrefixes: []string{"62", "1"},
+				RequireContact:  true,
+				HookURL:         "https://example.com/join-hook",
+				FailAction:      domainJoinRequest.DecisionReject,
+			}},
+			err: nil,
+		},
+		{
+			name: "should success without rules",
+			args: args{request: domainJoinRequest.SetPolicyRequest{
+				GroupID:    "120363025246125486@g.us",
+				FailAction: domainJoinRequest.DecisionIgnore,
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty group id",
+			args: args{request: domainJoinRequest.SetPolicyRequest{
+				FailAction: domainJoinRequest.DecisionReject,
+			}},
+			err: pkgError.ValidationError("group_id: cannot be blank."),
+		},
+		{
+			name: "should error with non digit prefix",
+			args: args{request: domainJoinRequest.SetPolicyRequest{
+				GroupID:         "120363025246125486@g.us",
+				AllowedPrefixes: []string{"62", "+1"},
+				FailAction:      domainJoinRequest.DecisionReject,
+			}},
+			err: pkgError.ValidationError("allowed_prefixes: (1: must contain digits only.)."),
+		},
+		{
+			name: "should error with invalid hook url",
+			args: args{request: domainJoinRequest.SetPolicyRequest{
+				GroupID:    "120363025246125486@g.us",
+				HookURL:    "not a url",
+				FailAction: domainJoinRequest.DecisionReject,
+			}},
+			err: pkgError.ValidationError("hook_url: must be an absolute http or https URL."),
+		},
+		{
+			name: "should error with internal hook url",
+			args: args{request: domainJoinRequest.SetPolicyRequest{
+				GroupID:    "120363025246125486@g.us",
+				HookURL:    "http://192.168.1.10/join-hook",
+				FailAction: domainJoinRequest.DecisionReject,
+			}},
+			err: pkgError.ValidationError("hook_url: must not point to a loopback, private or link-local address."),
+		},
+		{
+			name: "should error with approve as fail action",
+			args: args{request: domainJoinRequest.SetPolicyRequest{
+				GroupID:    "120363025246125486@g.us",
+				FailAction: domainJoinRequest.DecisionApprove,
+			}},
+			err: pkgError.ValidationError("fail_action: must be reject or ignore."),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetJoinRequestPolicy(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateJoinRequestGroup(t *testing.T) {
+	err := ValidateJoinRequestGroup(context.Background(), domainJoinRequest.GroupRequest{GroupID: "120363025246125486@g.us"})
+	assert.Nil(t, err)
+
+	err = ValidateJoinRequestGroup(context.Background(), domainJoinRequest.GroupRequest{})
+	assert.Equal(t, pkgError.ValidationError("group_id: cannot be blank."), err)
+}
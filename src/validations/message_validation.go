@@ -2,6 +2,7 @@ package validations
 
 import (
 	"context"
+	"fmt"
 
 	domainMessage "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/message"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
@@ -90,6 +91,23 @@ func ValidateStarMessage(ctx context.Context, request domainMessage.StarRequest)
 	return nil
 }
 
+func ValidatePinMessage(ctx context.Context, request domainMessage.PinMessageRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Phone, validation.Required),
+		validation.Field(&request.MessageID, validation.Required),
+		validation.Field(&request.Duration, validation.When(request.IsPinned,
+			validation.In(domainMessage.PinDuration24Hours, domainMessage.PinDuration7Days, domainMessage.PinDuration30Days).
+				Error("must be one of 24h, 7d or 30d"),
+		)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
 func ValidateDownloadMedia(ctx context.Context, request domainMessage.DownloadMediaRequest) error {
 	err := validation.ValidateStructWithContext(ctx, &request,
 		validation.Field(&request.Phone, validation.Required),
@@ -102,3 +120,42 @@ func ValidateDownloadMedia(ctx context.Context, request domainMessage.DownloadMe
 
 	return nil
 }
+
+// maxForwardTargets caps how many chats a single forward request can reach
+const maxForwardTargets = 50
+
+func ValidateForwardMessage(ctx context.Context, request domainMessage.ForwardRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.MessageID, validation.Required),
+		validation.Field(&request.Phones, validation.Required, validation.Length(1, maxForwardTargets)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	seen := make(map[string]bool, len(request.Phones))
+	for _, phone := range request.Phones {
+		if err := validatePhoneNumber(phone); err != nil {
+			return err
+		}
+		if seen[phone] {
+			return pkgError.ValidationError(fmt.Sprintf("duplicate target %s", phone))
+		}
+		seen[phone] = true
+	}
+
+	return nil
+}
+
+func ValidatePollResults(ctx context.Context, request domainMessage.PollResultsRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.MessageID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
@@ -291,3 +291,145 @@ func TestValidateStarMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePinMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainMessage.PinMessageRequest
+		err     any
+	}{
+		{
+			name: "should success pinning with duration",
+			request: domainMessage.PinMessageRequest{
+				Phone:     "6281234567890@s.whatsapp.net",
+				MessageID: "3EB0789ABC123456",
+				Duration:  domainMessage.PinDuration30Days,
+				IsPinned:  true,
+			},
+			err: nil,
+		},
+		{
+			name: "should success pinning without duration",
+			request: domainMessage.PinMessageRequest{
+				Phone:     "6281234567890@s.whatsapp.net",
+				MessageID: "3EB0789ABC123456",
+				IsPinned:  true,
+			},
+			err: nil,
+		},
+		{
+			name: "should success unpinning and ignore duration",
+			request: domainMessage.PinMessageRequest{
+				Phone:     "6281234567890@s.whatsapp.net",
+				MessageID: "3EB0789ABC123456",
+				Duration:  "1h",
+				IsPinned:  false,
+			},
+			err: nil,
+		},
+		{
+			name: "should error with unsupported duration",
+			request: domainMessage.PinMessageRequest{
+				Phone:     "6281234567890@s.whatsapp.net",
+				MessageID: "3EB0789ABC123456",
+				Duration:  "1h",
+				IsPinned:  true,
+			},
+			err: pkgError.ValidationError("duration: must be one of 24h, 7d or 30d."),
+		},
+		{
+			name: "should error with empty message id",
+			request: domainMessage.PinMessageRequest{
+				Phone:    "6281234567890@s.whatsapp.net",
+				IsPinned: true,
+			},
+			err: pkgError.ValidationError("message_id: cannot be blank."),
+		},
+		{
+			name: "should error with empty phone",
+			request: domainMessage.PinMessageRequest{
+				MessageID: "3EB0789ABC123456",
+				IsPinned:  true,
+			},
+			err: pkgError.ValidationError("phone: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePinMessage(context.Background(), tt.request)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateForwardMessage(t *testing.T) {
+	tooMany := make([]string, maxForwardTargets+1)
+	for i := range tooMany {
+		tooMany[i] = "628123456789" + string(rune('0'+i%10))
+	}
+
+	tests := []struct {
+		name    string
+		request domainMessage.ForwardRequest
+		err     any
+	}{
+		{
+			name: "should success with valid message id and targets",
+			request: domainMessage.ForwardRequest{
+				MessageID: "3EB0789ABC123456",
+				Phones:    []string{"6281234567890@s.whatsapp.net", "120363025246125486@g.us"},
+			},
+			err: nil,
+		},
+		{
+			name: "should error with empty message id",
+			request: domainMessage.ForwardRequest{
+				Phones: []string{"6281234567890@s.whatsapp.net"},
+			},
+			err: pkgError.ValidationError("message_id: cannot be blank."),
+		},
+		{
+			name: "should error without targets",
+			request: domainMessage.ForwardRequest{
+				MessageID: "3EB0789ABC123456",
+			},
+			err: pkgError.ValidationError("phones: cannot be blank."),
+		},
+		{
+			name: "should error with too many targets",
+			request: domainMessage.ForwardRequest{
+				MessageID: "3EB0789ABC123456",
+				Phones:    tooMany,
+			},
+			err: pkgError.ValidationError("phones: the length must be between 1 and 50."),
+		},
+		{
+			name: "should error with local phone format",
+			request: domainMessage.ForwardRequest{
+				MessageID: "3EB0789ABC123456",
+				Phones:    []string{"081234567890"},
+			},
+			err: pkgError.ValidationError("phone number must be in international format (should not start with 0). For Indonesian numbers, use 62xxx format instead of 08xxx"),
+		},
+		{
+			name: "should error with duplicate targets",
+			request: domainMessage.ForwardRequest{
+				MessageID: "3EB0789ABC123456",
+				Phones:    []string{"6281234567890@s.whatsapp.net", "6281234567890@s.whatsapp.net"},
+			},
+			err: pkgError.ValidationError("duplicate target 6281234567890@s.whatsapp.net"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateForwardMessage(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package validations
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func ValidateSetModerationRules(ctx context.Context, request domainModeration.SetRulesRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.Actions,
+			validation.When(request.Enabled, validation.Required),
+			validation.Each(validation.In(domainModeration.ActionDelete, domainModeration.ActionWarn, domainModeration.ActionRemove).
+				Error("must be delete, warn or remove")),
+		),
+		validation.Field(&request.AllowedDomains, validation.Each(validation.Required)),
+		validation.Field(&request.BannedKeywords, validation.Length(0, 500), validation.Each(validation.Required, validation.RuneLength(1, 100))),
+		validation.Field(&request.BannedPatterns, validation.Length(0, 50), validation.Each(validation.Required, validation.By(isRegexp))),
+		validation.Field(&request.FloodMaxMessages, validation.Min(0), validation.Max(1000)),
+		validation.Field(&request.FloodWindowSeconds,
+			validation.When(request.FloodMaxMessages > 0, validation.Required),
+			validation.Min(0), validation.Max(3600),
+		),
+		validation.Field(&request.BlockedMediaTypes, validation.Each(validation.In(
+			domainModeration.MediaImage, domainModeration.MediaVideo, domainModeration.MediaAudio, domainModeration.MediaDocument,
+			domainModeration.MediaSticker, domainModeration.MediaContact, domainModeration.MediaLocation, domainModeration.MediaPoll,
+		))),
+		validation.Field(&request.WarningMessage, validation.RuneLength(0, 1024)),
+		validation.Field(&request.MaxStrikes, validation.Min(0), validation.Max(100)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateModerationGroup(ctx context.Context, request domainModeration.GroupRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateModerationAuditLog(ctx context.Context, request *domainModeration.AuditLogRequest) error {
+	// Set default limit if not provided
+	if request.Limit == 0 {
+		request.Limit = 50
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.GroupID, validation.Required),
+		validation.Field(&request.Limit, validation.Min(1), validation.Max(100)),
+		validation.Field(&request.Offset, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func isRegexp(value any) error {
+	pattern, _ := value.(string)
+	if _, err := regexp.Compile(pattern); err != nil {
+		return errors.New("must be a valid regular expression")
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package validations
+
+import (
+	"context"
+	"testing"
+
+	domainModeration "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/moderation"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSetModerationRules(t *testing.T) {
+	type args struct {
+		request domainModeration.SetRulesRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		err  any
+	}{
+		{
+			name: "should success with valid rules",
+			args: args{request: domainModeration.SetRulesRequest{
+				GroupID:            "120363025246125486@g.us",
+				Enabled:            true,
+				BlockLinks:         true,
+				BannedPatterns:     []string{`(?i)free\s+money`},
+				FloodMaxMessages:   5,
+				FloodWindowSeconds: 10,
+				BlockedMediaTypes:  []string{domainModeration.MediaSticker},
+				Actions:            []string{domainModeration.ActionDelete, domainModeration.ActionWarn},
+			}},
+			err: nil,
+		},
+		{
+			name: "should success disabled without actions",
+			args: args{request: domainModeration.SetRulesRequest{
+				GroupID: "120363025246125486@g.us",
+			}},
+			err: nil,
+		},
+		{
+			name: "should error with empty group id",
+			args: args{request: domainModeration.SetRulesRequest{
+				Actions: []string{domainModeration.ActionDelete},
+			}},
+			err: pkgError.ValidationError("group_id: cannot be blank."),
+		},
+		{
+			name: "should error enabled without actions",
+			args: args{request: domainModeration.SetRulesRequest{
+				GroupID: "120363025246125486@g.us",
+				Enabled: true,
+			}},
+			err: pkgError.ValidationError("actions: cannot be blank."),
+		},
+		{
+			name: "should error with unknown action",
+			args: args{request: domainModeration.SetRulesRequest{
+				GroupID: "120363025246125486@g.us",
+				Actions: []string{"ban"},
+			}},
+			err: pkgError.ValidationError("actions: (0: must be delete, warn or remove.)."),
+		},
+		{
+			name: "should error with invalid pattern",
+			args: args{request: domainModeration.SetRulesRequest{
+				GroupID:        "120363025246125486@g.us",
+				BannedPatterns: []string{"(unclosed"},
+			}},
+			err: pkgError.ValidationError("banned_patterns: (0: must be a valid regular expression.)."),
+		},
+		{
+			name: "should error with flood limit without window",
+			args: args{request: domainModeration.SetRulesRequest{
+				GroupID:          "120363025246125486@g.us",
+				FloodMaxMessages: 5,
+			}},
+			err: pkgError.ValidationError("flood_window_seconds: cannot be blank."),
+		},
+		{
+			name: "should error with unknown media type",
+			args: args{request: domainModeration.SetRulesRequest{
+				GroupID:           "120363025246125486@g.us",
+				BlockedMediaTypes: []string{"gif"},
+			}},
+			err: pkgError.ValidationError("blocked_media_types: (0: must be a valid value.)."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetModerationRules(context.Background(), tt.args.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateModerationAuditLog(t *testing.T) {
+	request := domainModeration.AuditLogRequest{GroupID: "120363025246125486@g.us"}
+	assert.Nil(t, ValidateModerationAuditLog(context.Background(), &request))
+	assert.Equal(t, 50, request.Limit)
+
+	request = domainModeration.AuditLogRequest{GroupID: "120363025246125486@g.us", Limit: 500}
+	assert.Equal(t, pkgError.ValidationError("limit: must be no greater than 100."), ValidateModerationAuditLog(context.Background(), &request))
+}
@@ -2,9 +2,12 @@ package validations
 
 import (
 	"context"
+	"time"
+
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
 
 func ValidateUnfollowNewsletter(ctx context.Context, request domainNewsletter.UnfollowRequest) error {
@@ -18,3 +21,175 @@ func ValidateUnfollowNewsletter(ctx context.Context, request domainNewsletter.Un
 
 	return nil
 }
+
+func ValidateNewsletter(ctx context.Context, request domainNewsletter.NewsletterRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateCreateNewsletter(ctx context.Context, request domainNewsletter.CreateRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&request.Description, validation.Length(0, 2048)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Picture != nil && !isImageContentType(request.Picture.Header.Get("Content-Type")) {
+		return pkgError.ValidationError("picture must be an image")
+	}
+
+	return nil
+}
+
+func ValidateUpdateNewsletter(ctx context.Context, request domainNewsletter.UpdateRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.Name, validation.Length(0, 100)),
+		validation.Field(&request.Description, validation.Length(0, 2048)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Name == "" && request.Description == "" && request.Picture == nil {
+		return pkgError.ValidationError("name, description or picture is required")
+	}
+	if request.Picture != nil && !isImageContentType(request.Picture.Header.Get("Content-Type")) {
+		return pkgError.ValidationError("picture must be an image")
+	}
+
+	return nil
+}
+
+func ValidateFollowNewsletter(ctx context.Context, request domainNewsletter.FollowRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.InviteLink, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateNewsletterInfo(ctx context.Context, request domainNewsletter.InfoRequest) error {
+	if request.NewsletterID == "" && request.InviteLink == "" {
+		return pkgError.ValidationError("newsletter_id or invite_link is required")
+	}
+
+	return nil
+}
+
+func ValidateNewsletterMessages(ctx context.Context, request *domainNewsletter.MessagesRequest) error {
+	// Set default limit if not provided
+	if request.Limit == 0 {
+		request.Limit = 20
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.Limit, validation.Min(1), validation.Max(100)),
+		validation.Field(&request.Before, validation.Min(0)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateSendNewsletter(ctx context.Context, request domainNewsletter.SendRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.Message, validation.Length(0, 4096)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	sources := 0
+	for _, given := range []bool{request.Image != nil, request.ImageURL != nil, request.Video != nil, request.VideoURL != nil} {
+		if given {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return pkgError.ValidationError("send either an image or a video, from a file or a URL")
+	}
+	if sources == 0 && request.Message == "" {
+		return pkgError.ValidationError("message, image or video is required")
+	}
+
+	if request.Image != nil && !isImageContentType(request.Image.Header.Get("Content-Type")) {
+		return pkgError.ValidationError("image must be an image file")
+	}
+	if request.Video != nil && !isVideoContentType(request.Video.Header.Get("Content-Type")) {
+		return pkgError.ValidationError("your video type is not allowed. please use mp4/mkv/avi/x-msvideo")
+	}
+	if request.ImageURL != nil {
+		if err := validation.Validate(*request.ImageURL, validation.Required, is.URL); err != nil {
+			return pkgError.ValidationError("image_url must be a valid URL")
+		}
+	}
+	if request.VideoURL != nil {
+		if err := validation.Validate(*request.VideoURL, validation.Required, is.URL); err != nil {
+			return pkgError.ValidationError("video_url must be a valid URL")
+		}
+	}
+
+	return nil
+}
+
+func ValidateMuteNewsletter(ctx context.Context, request domainNewsletter.MuteRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.NewsletterID, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateNewsletterStats(ctx context.Context, request *domainNewsletter.StatsRequest) error {
+	// Set default limit if not provided
+	if request.Limit == 0 {
+		request.Limit = 20
+	}
+
+	err := validation.ValidateStructWithContext(ctx, request,
+		validation.Field(&request.NewsletterID, validation.Required),
+		validation.Field(&request.Limit, validation.Min(1), validation.Max(100)),
+		validation.Field(&request.Since, validation.Date(time.RFC3339).Error("must be an RFC3339 timestamp")),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func isVideoContentType(contentType string) bool {
+	switch contentType {
+	case "video/mp4", "video/x-matroska", "video/avi", "video/x-msvideo":
+		return true
+	default:
+		return false
+	}
+}
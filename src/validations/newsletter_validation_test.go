@@ -2,6 +2,8 @@ package validations
 
 import (
 	"context"
+	"mime/multipart"
+	"net/textproto"
 	"testing"
 
 	domainNewsletter "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/newsletter"
@@ -48,3 +50,91 @@ func TestValidateUnfollowNewsletter(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUpdateNewsletter(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainNewsletter.UpdateRequest
+		err     any
+	}{
+		{
+			name:    "should success with a new name",
+			request: domainNewsletter.UpdateRequest{NewsletterID: "120363123456789@newsletter", Name: "Product updates"},
+			err:     nil,
+		},
+		{
+			name:    "should error without changes",
+			request: domainNewsletter.UpdateRequest{NewsletterID: "120363123456789@newsletter"},
+			err:     pkgError.ValidationError("name, description or picture is required"),
+		},
+		{
+			name: "should error with a picture that is not an image",
+			request: domainNewsletter.UpdateRequest{
+				NewsletterID: "120363123456789@newsletter",
+				Picture:      &multipart.FileHeader{Header: textproto.MIMEHeader{"Content-Type": {"application/pdf"}}},
+			},
+			err: pkgError.ValidationError("picture must be an image"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUpdateNewsletter(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSendNewsletter(t *testing.T) {
+	imageURL := "https://example.com/launch.png"
+	videoURL := "https://example.com/launch.mp4"
+	emptyURL := ""
+
+	tests := []struct {
+		name    string
+		request domainNewsletter.SendRequest
+		err     any
+	}{
+		{
+			name:    "should success with text",
+			request: domainNewsletter.SendRequest{NewsletterID: "120363123456789@newsletter", Message: "Version 2 is out"},
+			err:     nil,
+		},
+		{
+			name:    "should success with image url and caption",
+			request: domainNewsletter.SendRequest{NewsletterID: "120363123456789@newsletter", Message: "New look", ImageURL: &imageURL},
+			err:     nil,
+		},
+		{
+			name:    "should error without content",
+			request: domainNewsletter.SendRequest{NewsletterID: "120363123456789@newsletter"},
+			err:     pkgError.ValidationError("message, image or video is required"),
+		},
+		{
+			name:    "should error with both image and video",
+			request: domainNewsletter.SendRequest{NewsletterID: "120363123456789@newsletter", ImageURL: &imageURL, VideoURL: &videoURL},
+			err:     pkgError.ValidationError("send either an image or a video, from a file or a URL"),
+		},
+		{
+			name:    "should error with empty video url",
+			request: domainNewsletter.SendRequest{NewsletterID: "120363123456789@newsletter", VideoURL: &emptyURL},
+			err:     pkgError.ValidationError("video_url must be a valid URL"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSendNewsletter(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateNewsletterStats(t *testing.T) {
+	request := domainNewsletter.StatsRequest{NewsletterID: "120363123456789@newsletter"}
+	assert.Nil(t, ValidateNewsletterStats(context.Background(), &request))
+	assert.Equal(t, 20, request.Limit)
+
+	request.Since = "yesterday"
+	assert.Equal(t, pkgError.ValidationError("since: must be an RFC3339 timestamp."), ValidateNewsletterStats(context.Background(), &request))
+}
@@ -2,12 +2,17 @@ package validations
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
 	domainSend "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/send"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/utils"
 	"github.com/dustin/go-humanize"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -218,7 +223,7 @@ func ValidateSendVideo(ctx context.Context, request domainSend.VideoRequest) err
 	}
 
 	// Ensure at least one of Video or VideoURL is provided
-	if request.Video == nil && (request.VideoURL == nil || *request.VideoURL == "") {
+	if request.Video == nil && request.VideoPath == "" && (request.VideoURL == nil || *request.VideoURL == "") {
 		return pkgError.ValidationError("either Video or VideoURL must be provided")
 	}
 
@@ -479,3 +484,132 @@ func ValidateSendChatPresence(ctx context.Context, request domainSend.ChatPresen
 
 	return nil
 }
+
+// maxBatchRecipients caps a single batch request so one call cannot hold the connection for too long.
+const maxBatchRecipients = 1000
+
+func validateBatchRecipients(recipients []domainSend.BatchRecipient) error {
+	if len(recipients) == 0 {
+		return pkgError.ValidationError("recipients cannot be empty")
+	}
+	if len(recipients) > maxBatchRecipients {
+		return pkgError.ValidationError(fmt.Sprintf("a batch can contain at most %d recipients", maxBatchRecipients))
+	}
+
+	seen := make(map[string]bool, len(recipients))
+	for _, recipient := range recipients {
+		if err := validatePhoneNumber(recipient.Phone); err != nil {
+			return err
+		}
+		if seen[recipient.Phone] {
+			return pkgError.ValidationError(fmt.Sprintf("duplicate recipient %s", recipient.Phone))
+		}
+		seen[recipient.Phone] = true
+	}
+	return nil
+}
+
+func ValidateSendMessageBatch(ctx context.Context, request domainSend.BatchMessageRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Message, validation.Required),
+	)
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if err := validateBatchRecipients(request.Recipients); err != nil {
+		return err
+	}
+
+	return validateDuration(request.Duration)
+}
+
+func ValidateSendImageBatch(ctx context.Context, request domainSend.BatchImageRequest) error {
+	if err := validateBatchRecipients(request.Recipients); err != nil {
+		return err
+	}
+
+	// The media rules are the same as a single send; check them once against the first recipient
+	return ValidateSendImage(ctx, domainSend.ImageRequest{
+		BaseRequest: request.ForRecipient(request.Recipients[0].Phone),
+		Caption:     request.Caption,
+		Image:       request.Image,
+		ImageURL:    request.ImageURL,
+		ViewOnce:    request.ViewOnce,
+		Compress:    request.Compress,
+	})
+}
+
+func ValidateSendFileBatch(ctx context.Context, request domainSend.BatchFileRequest) error {
+	if err := validateBatchRecipients(request.Recipients); err != nil {
+		return err
+	}
+
+	// The media rules are the same as a single send; check them once against the first recipient
+	return ValidateSendFile(ctx, domainSend.FileRequest{
+		BaseRequest: request.ForRecipient(request.Recipients[0].Phone),
+		File:        request.File,
+		Caption:     request.Caption,
+	})
+}
+
+// maxAlbumItems matches the number of photos/videos WhatsApp lets you pick for one album
+const maxAlbumItems = 30
+
+func ValidateSendAlbum(ctx context.Context, request domainSend.AlbumRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Phone, validation.Required),
+		validation.Field(&request.Items, validation.Required, validation.Length(2, maxAlbumItems)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	// Every item follows the same rules as a single image/video send
+	for i, item := range request.Items {
+		switch item.Type {
+		case domainSend.AlbumItemImage:
+			err = ValidateSendImage(ctx, domainSend.ImageRequest{BaseRequest: request.BaseRequest, Image: item.Upload, ImageURL: item.URL})
+		case domainSend.AlbumItemVideo:
+			err = ValidateSendVideo(ctx, domainSend.VideoRequest{BaseRequest: request.BaseRequest, Video: item.Upload, VideoURL: item.URL})
+		default:
+			err = pkgError.ValidationError("type must be image or video")
+		}
+		if err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("items[%d]: %v", i, err))
+		}
+	}
+
+	return nil
+}
+
+// ValidateCallbackURL checks the optional callback of an async send, the server POSTs the job result there.
+func ValidateCallbackURL(callbackURL string) error {
+	if err := publicHTTPURL(callbackURL); err != nil {
+		return pkgError.ValidationError(fmt.Sprintf("callback_url: %v.", err))
+	}
+	return nil
+}
+
+// publicHTTPURL is the rule for every URL the server calls on a caller's behalf. Only absolute http(s) URLs are
+// accepted and targets that are obviously internal (localhost, loopback, private and link-local addresses) are
+// refused; hostnames are checked again against the resolved address when delivering. Empty values pass.
+func publicHTTPURL(value any) error {
+	rawURL, _ := value.(string)
+	if rawURL == "" {
+		return nil
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return errors.New("must be an absolute http or https URL")
+	}
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.New("must not point to a loopback, private or link-local address")
+	}
+	if ip := net.ParseIP(host); ip != nil && !utils.IsPublicIP(ip) {
+		return errors.New("must not point to a loopback, private or link-local address")
+	}
+	return nil
+}
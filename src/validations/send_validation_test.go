@@ -1106,3 +1106,178 @@ func TestValidateSendAudio_WithDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSendMessageBatch(t *testing.T) {
+	recipients := []domainSend.BatchRecipient{
+		{Phone: "6289685028129@s.whatsapp.net", Variables: map[string]string{"name": "Budi"}},
+		{Phone: "6289685028130@s.whatsapp.net"},
+	}
+
+	tests := []struct {
+		name    string
+		request domainSend.BatchMessageRequest
+		err     any
+	}{
+		{
+			name: "should success with recipients and message",
+			request: domainSend.BatchMessageRequest{
+				BatchBaseRequest: domainSend.BatchBaseRequest{Recipients: recipients},
+				Message:          "Hello {{name}}",
+			},
+			err: nil,
+		},
+		{
+			name: "should error with empty recipients",
+			request: domainSend.BatchMessageRequest{
+				Message: "Hello",
+			},
+			err: pkgError.ValidationError("recipients cannot be empty"),
+		},
+		{
+			name: "should error with empty message",
+			request: domainSend.BatchMessageRequest{
+				BatchBaseRequest: domainSend.BatchBaseRequest{Recipients: recipients},
+			},
+			err: pkgError.ValidationError("message: cannot be blank."),
+		},
+		{
+			name: "should error with duplicate recipient",
+			request: domainSend.BatchMessageRequest{
+				BatchBaseRequest: domainSend.BatchBaseRequest{Recipients: []domainSend.BatchRecipient{
+					{Phone: "6289685028129@s.whatsapp.net"},
+					{Phone: "6289685028129@s.whatsapp.net"},
+				}},
+				Message: "Hello",
+			},
+			err: pkgError.ValidationError("duplicate recipient 6289685028129@s.whatsapp.net"),
+		},
+		{
+			name: "should error with local phone format",
+			request: domainSend.BatchMessageRequest{
+				BatchBaseRequest: domainSend.BatchBaseRequest{Recipients: []domainSend.BatchRecipient{
+					{Phone: "089685028129"},
+				}},
+				Message: "Hello",
+			},
+			err: pkgError.ValidationError("phone number must be in international format (should not start with 0). For Indonesian numbers, use 62xxx format instead of 08xxx"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSendMessageBatch(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateSendFileBatch(t *testing.T) {
+	file := &multipart.FileHeader{
+		Filename: "report.pdf",
+		Size:     100,
+		Header:   map[string][]string{"Content-Type": {"application/pdf"}},
+	}
+	recipients := []domainSend.BatchRecipient{{Phone: "6289685028129@s.whatsapp.net"}}
+
+	t.Run("should success with file", func(t *testing.T) {
+		err := ValidateSendFileBatch(context.Background(), domainSend.BatchFileRequest{
+			BatchBaseRequest: domainSend.BatchBaseRequest{Recipients: recipients},
+			File:             file,
+		})
+		assert.Nil(t, err)
+	})
+
+	t.Run("should error without file", func(t *testing.T) {
+		err := ValidateSendFileBatch(context.Background(), domainSend.BatchFileRequest{
+			BatchBaseRequest: domainSend.BatchBaseRequest{Recipients: recipients},
+		})
+		assert.Equal(t, pkgError.ValidationError("file: cannot be blank."), err)
+	})
+}
+
+func TestValidateSendAlbum(t *testing.T) {
+	photoURL := "https://example.com/photo.jpg"
+	videoURL := "https://example.com/clip.mp4"
+	base := domainSend.BaseRequest{Phone: "6289685028129@s.whatsapp.net"}
+
+	tests := []struct {
+		name    string
+		request domainSend.AlbumRequest
+		err     any
+	}{
+		{
+			name: "should success with image and video urls",
+			request: domainSend.AlbumRequest{
+				BaseRequest: base,
+				Items: []domainSend.AlbumItem{
+					{Type: domainSend.AlbumItemImage, URL: &photoURL, Caption: "Front"},
+					{Type: domainSend.AlbumItemVideo, URL: &videoURL},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "should error with a single item",
+			request: domainSend.AlbumRequest{
+				BaseRequest: base,
+				Items:       []domainSend.AlbumItem{{Type: domainSend.AlbumItemImage, URL: &photoURL}},
+			},
+			err: pkgError.ValidationError("items: the length must be between 2 and 30."),
+		},
+		{
+			name: "should error with unknown item type",
+			request: domainSend.AlbumRequest{
+				BaseRequest: base,
+				Items: []domainSend.AlbumItem{
+					{Type: domainSend.AlbumItemImage, URL: &photoURL},
+					{Type: "audio", URL: &photoURL},
+				},
+			},
+			err: pkgError.ValidationError("items[1]: type must be image or video"),
+		},
+		{
+			name: "should error with item without source",
+			request: domainSend.AlbumRequest{
+				BaseRequest: base,
+				Items: []domainSend.AlbumItem{
+					{Type: domainSend.AlbumItemImage},
+					{Type: domainSend.AlbumItemImage, URL: &photoURL},
+				},
+			},
+			err: pkgError.ValidationError("items[0]: either Image or ImageURL must be provided"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSendAlbum(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateCallbackURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		callbackURL string
+		err         any
+	}{
+		{name: "should success without callback", callbackURL: "", err: nil},
+		{name: "should success with https callback", callbackURL: "https://example.com/hooks/jobs", err: nil},
+		{name: "should success with http callback", callbackURL: "http://93.184.216.34:8080/jobs", err: nil},
+		{name: "should error with private address", callbackURL: "http://10.0.0.5:8080/jobs", err: pkgError.ValidationError("callback_url: must not point to a loopback, private or link-local address.")},
+		{name: "should error with loopback address", callbackURL: "http://127.0.0.1/jobs", err: pkgError.ValidationError("callback_url: must not point to a loopback, private or link-local address.")},
+		{name: "should error with ipv6 loopback address", callbackURL: "http://[::1]:3000/jobs", err: pkgError.ValidationError("callback_url: must not point to a loopback, private or link-local address.")},
+		{name: "should error with link-local address", callbackURL: "http://169.254.169.254/latest/meta-data", err: pkgError.ValidationError("callback_url: must not point to a loopback, private or link-local address.")},
+		{name: "should error with localhost", callbackURL: "http://localhost:3000/jobs", err: pkgError.ValidationError("callback_url: must not point to a loopback, private or link-local address.")},
+		{name: "should error without scheme", callbackURL: "example.com/hooks", err: pkgError.ValidationError("callback_url: must be an absolute http or https URL.")},
+		{name: "should error with other scheme", callbackURL: "file:///etc/passwd", err: pkgError.ValidationError("callback_url: must be an absolute http or https URL.")},
+		{name: "should error without host", callbackURL: "https://", err: pkgError.ValidationError("callback_url: must be an absolute http or https URL.")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.err, ValidateCallbackURL(tt.callbackURL))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package validations
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/aldinokemal/go-whatsapp-web-multidevice/config"
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/dustin/go-humanize"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"go.mau.fi/whatsmeow/proto/waE2E"
+)
+
+// statusColorPattern accepts #RRGGBB or #AARRGGBB
+var statusColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+func validateStatusAudience(request domainStatus.BaseRequest) error {
+	switch request.AudienceType {
+	case "", domainStatus.AudienceContacts:
+		if len(request.AudienceJIDs) > 0 {
+			return pkgError.ValidationError("audience_jids can only be used with audience_type allow or deny")
+		}
+	case domainStatus.AudienceAllow, domainStatus.AudienceDeny:
+		if len(request.AudienceJIDs) == 0 {
+			return pkgError.ValidationError(fmt.Sprintf("audience_jids is required for audience_type %s", request.AudienceType))
+		}
+		for _, jid := range request.AudienceJIDs {
+			if err := validatePhoneNumber(jid); err != nil {
+				return err
+			}
+		}
+	default:
+		return pkgError.ValidationError("audience_type must be one of contacts, allow, deny")
+	}
+	return nil
+}
+
+func ValidatePostStatusText(ctx context.Context, request domainStatus.TextRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Text, validation.Required, validation.RuneLength(1, 700)),
+		validation.Field(&request.BackgroundColor, validation.Match(statusColorPattern).Error("must be a hex color like #RRGGBB or #AARRGGBB")),
+	)
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Font != nil {
+		if _, ok := waE2E.ExtendedTextMessage_FontType_name[*request.Font]; !ok {
+			return pkgError.ValidationError(fmt.Sprintf("font %d is not supported", *request.Font))
+		}
+	}
+
+	return validateStatusAudience(request.BaseRequest)
+}
+
+func ValidatePostStatusImage(ctx context.Context, request domainStatus.ImageRequest) error {
+	if request.Image == nil && (request.ImageURL == nil || *request.ImageURL == "") {
+		return pkgError.ValidationError("either Image or ImageURL must be provided")
+	}
+
+	if request.Image != nil {
+		availableMimes := map[string]bool{
+			"image/jpeg": true,
+			"image/jpg":  true,
+			"image/png":  true,
+		}
+		if !availableMimes[request.Image.Header.Get("Content-Type")] {
+			return pkgError.ValidationError("your image is not allowed. please use jpg/jpeg/png")
+		}
+	}
+
+	if request.ImageURL != nil && *request.ImageURL != "" {
+		if err := validation.Validate(*request.ImageURL, is.URL); err != nil {
+			return pkgError.ValidationError("ImageURL must be a valid URL")
+		}
+	}
+
+	return validateStatusAudience(request.BaseRequest)
+}
+
+func ValidatePostStatusVideo(ctx context.Context, request domainStatus.VideoRequest) error {
+	if request.Video == nil && (request.VideoURL == nil || *request.VideoURL == "") {
+		return pkgError.ValidationError("either Video or VideoURL must be provided")
+	}
+
+	if request.Video != nil {
+		availableMimes := map[string]bool{
+			"video/mp4":        true,
+			"video/x-matroska": true,
+			"video/avi":        true,
+			"video/x-msvideo":  true,
+		}
+		if !availableMimes[request.Video.Header.Get("Content-Type")] {
+			return pkgError.ValidationError("your video type is not allowed. please use mp4/mkv/avi/x-msvideo")
+		}
+		if request.Video.Size > config.WhatsappSettingMaxVideoSize {
+			return pkgError.ValidationError(fmt.Sprintf("max video upload is %s", humanize.Bytes(uint64(config.WhatsappSettingMaxVideoSize))))
+		}
+	}
+
+	if request.VideoURL != nil && *request.VideoURL != "" {
+		if err := validation.Validate(*request.VideoURL, is.URL); err != nil {
+			return pkgError.ValidationError("VideoURL must be a valid URL")
+		}
+	}
+
+	return validateStatusAudience(request.BaseRequest)
+}
@@ -0,0 +1,101 @@
+package validations
+
+import (
+	"context"
+	"mime/multipart"
+	"testing"
+
+	domainStatus "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/status"
+	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePostStatusText(t *testing.T) {
+	font := int32(7)
+	unknownFont := int32(5)
+
+	tests := []struct {
+		name    string
+		request domainStatus.TextRequest
+		err     any
+	}{
+		{
+			name:    "should success with text, color and font",
+			request: domainStatus.TextRequest{Text: "Promo today", BackgroundColor: "#FF7F50", Font: &font},
+			err:     nil,
+		},
+		{
+			name:    "should success with ARGB color",
+			request: domainStatus.TextRequest{Text: "Promo today", BackgroundColor: "#CC7F50FF"},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty text",
+			request: domainStatus.TextRequest{},
+			err:     pkgError.ValidationError("text: cannot be blank."),
+		},
+		{
+			name:    "should error with invalid color",
+			request: domainStatus.TextRequest{Text: "Promo", BackgroundColor: "red"},
+			err:     pkgError.ValidationError("background_color: must be a hex color like #RRGGBB or #AARRGGBB."),
+		},
+		{
+			name:    "should error with unsupported font",
+			request: domainStatus.TextRequest{Text: "Promo", Font: &unknownFont},
+			err:     pkgError.ValidationError("font 5 is not supported"),
+		},
+		{
+			name: "should error with unknown audience",
+			request: domainStatus.TextRequest{
+				BaseRequest: domainStatus.BaseRequest{AudienceType: "everyone"},
+				Text:        "Promo",
+			},
+			err: pkgError.ValidationError("audience_type must be one of contacts, allow, deny"),
+		},
+		{
+			name: "should error with allow audience without jids",
+			request: domainStatus.TextRequest{
+				BaseRequest: domainStatus.BaseRequest{AudienceType: domainStatus.AudienceAllow},
+				Text:        "Promo",
+			},
+			err: pkgError.ValidationError("audience_jids is required for audience_type allow"),
+		},
+		{
+			name: "should success with deny audience",
+			request: domainStatus.TextRequest{
+				BaseRequest: domainStatus.BaseRequest{
+					AudienceType: domainStatus.AudienceDeny,
+					AudienceJIDs: []string{"6289685028129@s.whatsapp.net"},
+				},
+				Text: "Promo",
+			},
+			err: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePostStatusText(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidatePostStatusImage(t *testing.T) {
+	image := &multipart.FileHeader{
+		Filename: "promo.png",
+		Size:     100,
+		Header:   map[string][]string{"Content-Type": {"image/png"}},
+	}
+	gif := &multipart.FileHeader{
+		Filename: "promo.gif",
+		Size:     100,
+		Header:   map[string][]string{"Content-Type": {"image/gif"}},
+	}
+
+	assert.Nil(t, ValidatePostStatusImage(context.Background(), domainStatus.ImageRequest{Image: image}))
+	assert.Equal(t, pkgError.ValidationError("either Image or ImageURL must be provided"),
+		ValidatePostStatusImage(context.Background(), domainStatus.ImageRequest{}))
+	assert.Equal(t, pkgError.ValidationError("your image is not allowed. please use jpg/jpeg/png"),
+		ValidatePostStatusImage(context.Background(), domainStatus.ImageRequest{Image: gif}))
+}
@@ -2,10 +2,16 @@ package validations
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 
 	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"go.mau.fi/whatsmeow/types"
 )
 
 func ValidateUserInfo(ctx context.Context, request domainUser.InfoRequest) error {
@@ -44,3 +50,163 @@ func ValidateBusinessProfile(ctx context.Context, request domainUser.BusinessPro
 
 	return nil
 }
+
+func ValidateSetPrivacySetting(ctx context.Context, request domainUser.SetPrivacySettingRequest) error {
+	audience := []any{domainUser.PrivacyAll, domainUser.PrivacyContacts, domainUser.PrivacyContactBlacklist, domainUser.PrivacyNone}
+	audienceError := "must be all, contacts, contact_blacklist or none"
+
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.GroupAdd, validation.NilOrNotEmpty, validation.In(audience...).Error(audienceError)),
+		validation.Field(&request.LastSeen, validation.NilOrNotEmpty, validation.In(audience...).Error(audienceError)),
+		validation.Field(&request.Status, validation.NilOrNotEmpty, validation.In(audience...).Error(audienceError)),
+		validation.Field(&request.Profile, validation.NilOrNotEmpty, validation.In(audience...).Error(audienceError)),
+		validation.Field(&request.ReadReceipts, validation.NilOrNotEmpty,
+			validation.In(domainUser.PrivacyAll, domainUser.PrivacyNone).Error("must be all or none")),
+		validation.Field(&request.Online, validation.NilOrNotEmpty,
+			validation.In(domainUser.PrivacyAll, domainUser.PrivacyMatchLastSeen).Error("must be all or match_last_seen")),
+		validation.Field(&request.CallAdd, validation.NilOrNotEmpty,
+			validation.In(domainUser.PrivacyAll, domainUser.PrivacyKnown).Error("must be all or known")),
+		validation.Field(&request.DefaultDisappearingSeconds, disappearingTimerRule),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.GroupAdd == nil && request.LastSeen == nil && request.Status == nil && request.Profile == nil &&
+		request.ReadReceipts == nil && request.Online == nil && request.CallAdd == nil && request.DefaultDisappearingSeconds == nil {
+		return pkgError.ValidationError("at least one privacy setting is required")
+	}
+
+	return nil
+}
+
+func ValidateBlockUser(ctx context.Context, request domainUser.BlockRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Phone, validation.Required),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+func ValidateLIDMapping(ctx context.Context, request domainUser.LIDMappingRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.JID, validation.Required, validation.By(isUserJID)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+var phoneNumberPattern = regexp.MustCompile(`^\+?\d+$`)
+
+// isUserJID accepts a bare phone number or the JID of a user in either its phone number or LID form
+func isUserJID(value any) error {
+	jid, _ := value.(string)
+	if !strings.ContainsRune(jid, '@') {
+		if !phoneNumberPattern.MatchString(jid) {
+			return errors.New("must be a phone number, phone number JID or LID")
+		}
+		return nil
+	}
+	parsed, err := types.ParseJID(jid)
+	if err != nil || parsed.User == "" || (parsed.Server != types.DefaultUserServer && parsed.Server != types.HiddenUserServer) {
+		return errors.New("must be a phone number, phone number JID or LID")
+	}
+	return nil
+}
+
+// maxAboutLength is the longest about text WhatsApp accepts
+const maxAboutLength = 139
+
+func ValidateChangeAbout(ctx context.Context, request domainUser.ChangeAboutRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.About, validation.Required, validation.RuneLength(1, maxAboutLength)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	return nil
+}
+
+// businessHourTimePattern matches the HH:MM times FormatBusinessHourTime renders
+var businessHourTimePattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+var businessDays = []any{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func ValidateUpdateBusinessProfile(ctx context.Context, request domainUser.UpdateBusinessProfileRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Description, validation.NilOrNotEmpty, validation.RuneLength(0, 512)),
+		validation.Field(&request.Address, validation.NilOrNotEmpty, validation.RuneLength(0, 256)),
+		validation.Field(&request.Email, validation.NilOrNotEmpty, is.EmailFormat),
+		validation.Field(&request.Websites, validation.Length(0, 2), validation.Each(validation.Required, is.URL)),
+		validation.Field(&request.CategoryIDs, validation.Length(0, 3), validation.Each(validation.Required)),
+		validation.Field(&request.BusinessHoursTimeZone, validation.When(request.BusinessHours != nil, validation.Required)),
+		validation.Field(&request.BusinessHours, validation.Length(0, 7)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if request.Description == nil && request.Address == nil && request.Email == nil && request.Websites == nil &&
+		request.CategoryIDs == nil && request.BusinessHours == nil {
+		return pkgError.ValidationError("at least one business profile field is required")
+	}
+
+	days := make(map[string]bool, len(request.BusinessHours))
+	for i, hours := range request.BusinessHours {
+		specificHours := hours.Mode == domainUser.BusinessHoursSpecificHours
+		err = validation.ValidateStructWithContext(ctx, &hours,
+			validation.Field(&hours.DayOfWeek, validation.Required,
+				validation.In(businessDays...).Error("must be sun, mon, tue, wed, thu, fri or sat")),
+			validation.Field(&hours.Mode, validation.Required,
+				validation.In(domainUser.BusinessHoursSpecificHours, domainUser.BusinessHoursOpen24h, domainUser.BusinessHoursAppointmentOnly).
+					Error("must be specific_hours, open_24h or appointment_only")),
+			validation.Field(&hours.OpenTime, validation.When(specificHours, validation.Required,
+				validation.Match(businessHourTimePattern).Error("must be in HH:MM format"))),
+			validation.Field(&hours.CloseTime, validation.When(specificHours, validation.Required,
+				validation.Match(businessHourTimePattern).Error("must be in HH:MM format"))),
+		)
+		if err == nil && days[hours.DayOfWeek] {
+			err = fmt.Errorf("day_of_week %s is given more than once", hours.DayOfWeek)
+		}
+		if err != nil {
+			return pkgError.ValidationError(fmt.Sprintf("business_hours[%d]: %v", i, err))
+		}
+		days[hours.DayOfWeek] = true
+	}
+
+	return nil
+}
+
+// maxBulkCheckFileSize fits MaxBulkCheckNumbers phone numbers with a few columns next to them
+const maxBulkCheckFileSize = 5 << 20
+
+func ValidateBulkCheck(ctx context.Context, request domainUser.BulkCheckRequest) error {
+	err := validation.ValidateStructWithContext(ctx, &request,
+		validation.Field(&request.Phones, validation.Length(0, domainUser.MaxBulkCheckNumbers)),
+	)
+
+	if err != nil {
+		return pkgError.ValidationError(err.Error())
+	}
+
+	if (len(request.Phones) == 0) == (request.File == nil) {
+		return pkgError.ValidationError("either phones or file is required")
+	}
+	if request.File != nil && request.File.Size > maxBulkCheckFileSize {
+		return pkgError.ValidationError("file: must not be larger than 5MB")
+	}
+
+	return nil
+}
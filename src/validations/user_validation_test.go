@@ -5,6 +5,8 @@ import (
 	domainUser "github.com/aldinokemal/go-whatsapp-web-multidevice/domains/user"
 	pkgError "github.com/aldinokemal/go-whatsapp-web-multidevice/pkg/error"
 	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"strings"
 	"testing"
 )
 
@@ -110,3 +112,283 @@ func TestValidateBusinessProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSetPrivacySetting(t *testing.T) {
+	value := func(v string) *string { return &v }
+	timer := func(v uint32) *uint32 { return &v }
+
+	tests := []struct {
+		name    string
+		request domainUser.SetPrivacySettingRequest
+		err     any
+	}{
+		{
+			name: "should success with every setting",
+			request: domainUser.SetPrivacySettingRequest{
+				GroupAdd:                   value(domainUser.PrivacyContactBlacklist),
+				LastSeen:                   value(domainUser.PrivacyContacts),
+				Status:                     value(domainUser.PrivacyAll),
+				Profile:                    value(domainUser.PrivacyNone),
+				ReadReceipts:               value(domainUser.PrivacyNone),
+				Online:                     value(domainUser.PrivacyMatchLastSeen),
+				CallAdd:                    value(domainUser.PrivacyKnown),
+				DefaultDisappearingSeconds: timer(604800),
+			},
+			err: nil,
+		},
+		{
+			name:    "should success turning default disappearing off",
+			request: domainUser.SetPrivacySettingRequest{DefaultDisappearingSeconds: timer(0)},
+			err:     nil,
+		},
+		{
+			name:    "should error without settings",
+			request: domainUser.SetPrivacySettingRequest{},
+			err:     pkgError.ValidationError("at least one privacy setting is required"),
+		},
+		{
+			name:    "should error with contacts for read receipts",
+			request: domainUser.SetPrivacySettingRequest{ReadReceipts: value(domainUser.PrivacyContacts)},
+			err:     pkgError.ValidationError("read_receipts: must be all or none."),
+		},
+		{
+			name:    "should error with none for online",
+			request: domainUser.SetPrivacySettingRequest{Online: value(domainUser.PrivacyNone)},
+			err:     pkgError.ValidationError("online: must be all or match_last_seen."),
+		},
+		{
+			name:    "should error with empty last seen",
+			request: domainUser.SetPrivacySettingRequest{LastSeen: value("")},
+			err:     pkgError.ValidationError("last_seen: cannot be blank."),
+		},
+		{
+			name:    "should error with unsupported timer",
+			request: domainUser.SetPrivacySettingRequest{DefaultDisappearingSeconds: timer(3600)},
+			err:     pkgError.ValidationError("default_disappearing_seconds: must be one of 0, 86400, 604800 or 7776000."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetPrivacySetting(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateBlockUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainUser.BlockRequest
+		err     any
+	}{
+		{
+			name:    "should success",
+			request: domainUser.BlockRequest{Phone: "6289685028129@s.whatsapp.net"},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty phone",
+			request: domainUser.BlockRequest{},
+			err:     pkgError.ValidationError("phone: cannot be blank."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBlockUser(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateLIDMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainUser.LIDMappingRequest
+		err     any
+	}{
+		{
+			name:    "should success with phone number",
+			request: domainUser.LIDMappingRequest{JID: "+6289685028129"},
+			err:     nil,
+		},
+		{
+			name:    "should success with phone number JID",
+			request: domainUser.LIDMappingRequest{JID: "6289685028129@s.whatsapp.net"},
+			err:     nil,
+		},
+		{
+			name:    "should success with LID",
+			request: domainUser.LIDMappingRequest{JID: "123456789012345@lid"},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty jid",
+			request: domainUser.LIDMappingRequest{},
+			err:     pkgError.ValidationError("jid: cannot be blank."),
+		},
+		{
+			name:    "should error with group jid",
+			request: domainUser.LIDMappingRequest{JID: "120363000000000000@g.us"},
+			err:     pkgError.ValidationError("jid: must be a phone number, phone number JID or LID."),
+		},
+		{
+			name:    "should error with text",
+			request: domainUser.LIDMappingRequest{JID: "someone"},
+			err:     pkgError.ValidationError("jid: must be a phone number, phone number JID or LID."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLIDMapping(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateChangeAbout(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainUser.ChangeAboutRequest
+		err     any
+	}{
+		{
+			name:    "should success",
+			request: domainUser.ChangeAboutRequest{About: "Support hours 09:00-17:00"},
+			err:     nil,
+		},
+		{
+			name:    "should error with empty about",
+			request: domainUser.ChangeAboutRequest{},
+			err:     pkgError.ValidationError("about: cannot be blank."),
+		},
+		{
+			name:    "should error with too long about",
+			request: domainUser.ChangeAboutRequest{About: strings.Repeat("a", 140)},
+			err:     pkgError.ValidationError("about: the length must be between 1 and 139."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChangeAbout(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateUpdateBusinessProfile(t *testing.T) {
+	email := "support@example.com"
+	badEmail := "support"
+	tests := []struct {
+		name    string
+		request domainUser.UpdateBusinessProfileRequest
+		err     any
+	}{
+		{
+			name: "should success with business hours",
+			request: domainUser.UpdateBusinessProfileRequest{
+				Email:                 &email,
+				BusinessHoursTimeZone: "Asia/Jakarta",
+				BusinessHours: []domainUser.BusinessProfileHoursConfig{
+					{DayOfWeek: "mon", Mode: domainUser.BusinessHoursSpecificHours, OpenTime: "09:00", CloseTime: "17:00"},
+					{DayOfWeek: "sun", Mode: domainUser.BusinessHoursOpen24h},
+				},
+			},
+			err: nil,
+		},
+		{
+			name:    "should error without any field",
+			request: domainUser.UpdateBusinessProfileRequest{},
+			err:     pkgError.ValidationError("at least one business profile field is required"),
+		},
+		{
+			name:    "should error with invalid email",
+			request: domainUser.UpdateBusinessProfileRequest{Email: &badEmail},
+			err:     pkgError.ValidationError("email: must be a valid email address."),
+		},
+		{
+			name: "should error with business hours without timezone",
+			request: domainUser.UpdateBusinessProfileRequest{
+				BusinessHours: []domainUser.BusinessProfileHoursConfig{{DayOfWeek: "sun", Mode: domainUser.BusinessHoursOpen24h}},
+			},
+			err: pkgError.ValidationError("business_hours_timezone: cannot be blank."),
+		},
+		{
+			name: "should error with invalid open time",
+			request: domainUser.UpdateBusinessProfileRequest{
+				BusinessHoursTimeZone: "Asia/Jakarta",
+				BusinessHours: []domainUser.BusinessProfileHoursConfig{
+					{DayOfWeek: "mon", Mode: domainUser.BusinessHoursSpecificHours, OpenTime: "9am", CloseTime: "17:00"},
+				},
+			},
+			err: pkgError.ValidationError("business_hours[0]: open_time: must be in HH:MM format."),
+		},
+		{
+			name: "should error with repeated day",
+			request: domainUser.UpdateBusinessProfileRequest{
+				BusinessHoursTimeZone: "Asia/Jakarta",
+				BusinessHours: []domainUser.BusinessProfileHoursConfig{
+					{DayOfWeek: "mon", Mode: domainUser.BusinessHoursOpen24h},
+					{DayOfWeek: "mon", Mode: domainUser.BusinessHoursAppointmentOnly},
+				},
+			},
+			err: pkgError.ValidationError("business_hours[1]: day_of_week mon is given more than once"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUpdateBusinessProfile(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestValidateBulkCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		request domainUser.BulkCheckRequest
+		err     any
+	}{
+		{
+			name:    "should success with phones",
+			request: domainUser.BulkCheckRequest{Phones: []string{"6281234567890"}},
+			err:     nil,
+		},
+		{
+			name:    "should success with file",
+			request: domainUser.BulkCheckRequest{File: &multipart.FileHeader{Size: 1024}},
+			err:     nil,
+		},
+		{
+			name:    "should error without phones or file",
+			request: domainUser.BulkCheckRequest{},
+			err:     pkgError.ValidationError("either phones or file is required"),
+		},
+		{
+			name:    "should error with both phones and file",
+			request: domainUser.BulkCheckRequest{Phones: []string{"6281234567890"}, File: &multipart.FileHeader{Size: 1024}},
+			err:     pkgError.ValidationError("either phones or file is required"),
+		},
+		{
+			name:    "should error with too large file",
+			request: domainUser.BulkCheckRequest{File: &multipart.FileHeader{Size: 6 << 20}},
+			err:     pkgError.ValidationError("file: must not be larger than 5MB"),
+		},
+		{
+			name:    "should error with too many phones",
+			request: domainUser.BulkCheckRequest{Phones: make([]string, domainUser.MaxBulkCheckNumbers+1)},
+			err:     pkgError.ValidationError("phones: the length must be no more than 10000."),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBulkCheck(context.Background(), tt.request)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}